@@ -0,0 +1,162 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/anindyar/kuber/src/app"
+)
+
+// Build information, set via -ldflags at build time
+var (
+	Version   = "1.0.0-dev"
+	BuildTime = "unknown"
+	GitCommit = "unknown"
+)
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	config := parseFlags()
+
+	application, err := app.InitApp(config)
+	if err != nil {
+		log.Fatalf("Failed to initialize kUber: %v", err)
+	}
+	defer application.Cleanup()
+
+	// kUber is the full-featured build: enable editing and other write paths
+	app.AddEditingCapability(application)
+
+	// Handle interrupts gracefully
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		<-sigChan
+		application.Cleanup()
+		os.Exit(0)
+	}()
+
+	// Start the TUI application
+	if err := application.Run(); err != nil {
+		log.Fatalf("Error running kUber: %v", err)
+	}
+
+	application.Cleanup()
+}
+
+// parseFlags parses command line flags
+func parseFlags() *app.Config {
+	config := &app.Config{}
+
+	flag.StringVar(&config.KubeConfig, "kubeconfig", "", "Path to kubeconfig file (default: ~/.kube/config)")
+	flag.StringVar(&config.Context, "context", "", "Kubernetes context to use")
+	fleet := flag.String("fleet", "", "Comma-separated contexts for the fleet overview (default: all contexts)")
+	flag.StringVar(&config.Namespace, "namespace", "", "Default namespace")
+	flag.DurationVar(&config.RefreshInterval, "refresh", 30*time.Second, "Resource refresh interval")
+	flag.StringVar(&config.LogLevel, "log-level", "info", "Log level (debug, info, warn, error)")
+	flag.StringVar(&config.Theme, "theme", "default", "UI theme")
+
+	help := flag.Bool("help", false, "Show help information")
+	version := flag.Bool("version", false, "Show version information")
+
+	flag.Parse()
+
+	if *fleet != "" {
+		for _, name := range strings.Split(*fleet, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				config.FleetContexts = append(config.FleetContexts, name)
+			}
+		}
+	}
+
+	if *help {
+		showHelp()
+		os.Exit(0)
+	}
+
+	if *version {
+		showVersion()
+		os.Exit(0)
+	}
+
+	// Set default kubeconfig if not provided
+	if config.KubeConfig == "" {
+		homeDir, err := os.UserHomeDir()
+		if err == nil {
+			config.KubeConfig = filepath.Join(homeDir, ".kube", "config")
+		}
+	}
+
+	return config
+}
+
+func showHelp() {
+	fmt.Print(`kUber - An Uber Kubernetes Manager
+
+A full-featured terminal interface for browsing, inspecting and modifying
+Kubernetes resources, with live logs, shell access and YAML editing.
+
+Usage:
+
+Keyboard Shortcuts:
+  ↑↓         Navigate resources
+  Enter      Select/View details
+  Tab        Switch between panes
+  Esc        Go back/Cancel
+  Ctrl+C     Exit application
+  r          Refresh resources
+  l          View logs
+  L          Follow logs of all pods matching a label selector
+  c          View cluster logs
+  C          Switch kubeconfig context
+  F          Fleet overview of all contexts
+  f          Port-forward the selected pod or service
+  P          Active port forwards (x to stop)
+  E          Live events (cluster-wide from dashboard, namespace from resources)
+  !          What's broken: crash loops, OOM kills, image pulls, unschedulable pods, bad nodes
+  T          Top pods by CPU/memory (s: sort, Enter: containers)
+  M          Metrics history charts of nodes or pods (t: time range, p: nodes/pods)
+  b          Browse and copy container files (pod details)
+  o          Ownership tree of the selected resource
+  1-9        Open a related object (details view)
+  Ctrl+K     Search all namespaces (kind:pod ns:prod status:running age<1h restarts>3 app=web)
+  s          Open pod shell
+  d          Describe resource
+
+Resource Management:
+  e          Edit resource YAML
+  Ctrl+D     Delete resource
+  S          Scale deployment/statefulset
+  R          Restart deployment/statefulset
+  D          Attach an ephemeral debug container to a pod
+
+Editor:
+  Ctrl+S     Save (kubectl apply)
+  Ctrl+Z     Revert changes
+  Esc        Close editor
+
+Log View:
+  /          Search/Filter logs
+  p          Toggle pretty-printed structured logs
+  f          Toggle follow mode
+  Esc        Exit search mode
+
+`)
+	flag.PrintDefaults()
+}
+
+func showVersion() {
+	fmt.Printf("kUber version %s\n", Version)
+	fmt.Printf("Build time: %s\n", BuildTime)
+	fmt.Printf("Git commit: %s\n", GitCommit)
+	fmt.Println("Built with Go and Bubble Tea")
+}
@@ -0,0 +1,1342 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+	metricscollector "github.com/anindyar/kuber/src/libraries/metrics-collector"
+	resourcemanager "github.com/anindyar/kuber/src/libraries/resource-manager"
+	tuicomponents "github.com/anindyar/kuber/src/libraries/tui-components"
+	"github.com/anindyar/kuber/src/models"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Application represents the shared kTop/kUber terminal application.
+// It is read-only unless AddEditingCapability has been called on it.
+type Application struct {
+	config           *Config
+	client           *kubernetesclient.KubernetesClient
+	resourceManager  *resourcemanager.ResourceManager
+	
+	// UI Components
+	statusBar          *tuicomponents.StatusBarComponent
+	breadcrumb         *tuicomponents.BreadcrumbComponent
+	namespaceList      *tuicomponents.ListComponent
+	contextList        *tuicomponents.ListComponent
+	fleetTable         *tuicomponents.TableComponent
+	portForwardTable   *tuicomponents.TableComponent
+	fileTable          *tuicomponents.TableComponent
+	eventTable         *tuicomponents.TableComponent
+	searchTable        *tuicomponents.TableComponent
+	problemTable       *tuicomponents.TableComponent
+	topTable           *tuicomponents.TableComponent
+	metricsTable       *tuicomponents.TableComponent
+	resourceTabs       *tuicomponents.ListComponent
+	resourceTable      *tuicomponents.TableComponent
+	detailViewport     *tuicomponents.ViewportComponent
+	
+	// State
+	width, height    int
+	currentView      ViewType
+	activeComponent  tuicomponents.Component
+	selectedNamespace string
+	currentResourceType string
+	ready            bool
+	error            string
+	info             string
+	
+	// Search functionality (read-only)
+	searchMode         bool
+	searchQuery        string
+	originalLogContent string
+	
+	// Follow mode for live log streaming
+	followMode         bool
+	logStreamCancel    context.CancelFunc
+	logBuffer          *models.LogBuffer
+	logSelector        *kubernetesclient.LogSelector // set when following several pods
+	logPretty          bool                          // pretty-print structured log lines
+	logFilter          *models.LogFilter             // active field filter of the log view
+	logFilterContent   string                        // filtered log view, appended to while following
+	logFilterMatched   int
+	currentPodName     string
+	program            *tea.Program
+
+	// Live resource table fed by a watch
+	tableResources      []*models.Resource
+	rowHighlights       map[string]rowHighlight
+	watchedNamespace    string
+	watchedResourceType string
+	
+	// Dashboard data
+	clusterMetrics   *ClusterMetrics
+	metricsCollector *metricscollector.MetricsCollector
+	clusterUsage     *clusterUsage      // the dashboard's reading of the latest collection
+	problemCounts    models.IssueCounts // the dashboard's latest problem count
+
+	// Fleet mode: one client per kubeconfig context
+	fleet          *kubernetesclient.ClientPool
+	fleetSummaries []*FleetSummary
+
+	// Port forward panel returns to this view on Esc
+	portForwardReturnView ViewType
+
+	// Container file browser
+	fileBrowser *fileBrowser
+
+	// Live events view
+	eventStream *eventStream
+
+	// Ownership tree returns to this view on Esc
+	ownersReturnView ViewType
+
+	// "What's broken" view
+	problems *problemsState
+
+	// Top consumers view
+	top *topState
+
+	// Metrics history view
+	metrics *metricsState
+
+	// ctrl+k search palette
+	searchPalette *searchPalette
+
+	// Objects related to the resource in the details view, by number
+	relatedObjects []resourcemanager.Relation
+
+	// Why the pending pod in the details view fits on no node
+	schedulingFits []resourcemanager.NodeFit
+
+	// Editing (kUber only)
+	editingEnabled     bool
+	editor             *EditorView
+	detailResourceName string
+	prompt             *inputPrompt
+}
+
+// ViewType represents different application views (simplified)
+type ViewType int
+
+const (
+	ViewOverview ViewType = iota
+	ViewNamespaces
+	ViewResources
+	ViewDetails
+	ViewLogs
+	ViewClusterLogs
+	ViewMetrics
+	ViewShell
+	ViewEditor
+	ViewContexts
+	ViewFleet
+	ViewPortForwards
+	ViewFiles
+	ViewEvents
+	ViewOwners
+	ViewSearch
+	ViewProblems
+	ViewTop
+)
+
+// ClusterMetrics holds cluster performance information (same as kUber)
+type ClusterMetrics struct {
+	Nodes struct {
+		Total       int
+		Ready       int
+		NotReady    int
+		CPU         ResourceMetric
+		Memory      ResourceMetric
+		Storage     ResourceMetric
+		UsageNodes  int // nodes metrics-server reported usage for
+		// Requests, limits and usage against allocatable, over all nodes
+		Allocation struct {
+			CPU    metricscollector.ResourceUsage
+			Memory metricscollector.ResourceUsage
+		}
+		Pressure map[string]int // nodes per pressure condition
+		Details  []NodeDetail
+	}
+	Workloads struct {
+		Deployments  int
+		StatefulSets int
+		Pods         int
+		Services     int
+		Ingresses    int
+	}
+	Problems    models.IssueCounts
+	LastUpdated time.Time
+}
+
+type ResourceMetric struct {
+	Used       float64
+	Available  float64
+	Total      float64
+	Percentage float64
+	Unit       string
+}
+
+// NodeDetail is one node on the dashboard
+type NodeDetail struct {
+	Name       string
+	Status     string // Ready or NotReady
+	CPU        metricscollector.ResourceUsage
+	Memory     metricscollector.ResourceUsage
+	Conditions []string // pressure conditions that are True
+}
+
+// Config holds application configuration
+type Config struct {
+	KubeConfig      string
+	Context         string
+	FleetContexts   []string
+	Namespace       string
+	RefreshInterval time.Duration
+	LogLevel        string
+	Theme           string
+}
+
+// InitApp connects to the cluster and initializes the application
+func InitApp(config *Config) (*Application, error) {
+	client, resourceManager, err := connect(config.KubeConfig, config.Context)
+	if err != nil {
+		return nil, err
+	}
+	
+	app := &Application{
+		config:              config,
+		client:              client,
+		resourceManager:     resourceManager,
+		currentView:         ViewOverview,
+		currentResourceType: "pods",
+		clusterMetrics:      &ClusterMetrics{LastUpdated: time.Now()},
+		metricsCollector:    newMetricsCollector(client, resourceManager),
+		rowHighlights:       make(map[string]rowHighlight),
+	}
+	
+	// Initialize UI components (same as kUber but simplified)
+	if err := app.initializeComponents(); err != nil {
+		return nil, fmt.Errorf("failed to initialize UI components: %w", err)
+	}
+	app.updateClusterInfo()
+	
+	app.ready = true
+	return app, nil
+}
+
+// connect builds a Kubernetes client and resource manager for a kubeconfig
+// context (empty means the kubeconfig's current-context)
+func connect(kubeconfig, contextName string) (*kubernetesclient.KubernetesClient, *resourcemanager.ResourceManager, error) {
+	client, err := connectClient(kubeconfig, contextName)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	rmConfig := resourcemanager.DefaultConfig()
+	rmConfig.WatchEnabled = true // Watches only read; they keep tables live
+	rmConfig.CacheTTL = 2 * time.Minute // Longer cache for read-only
+	
+	resourceManager, err := resourcemanager.NewResourceManager(client, rmConfig)
+	if err != nil {
+		client.Close()
+		return nil, nil, fmt.Errorf("failed to create resource manager: %w", err)
+	}
+
+	// Printing would corrupt the TUI; broken watches restart on their own
+	resourceManager.SetWatchErrorHandler(func(error) {})
+	
+	return client, resourceManager, nil
+}
+
+// connectClient builds a Kubernetes client for a kubeconfig context and
+// checks that the cluster answers
+func connectClient(kubeconfig, contextName string) (*kubernetesclient.KubernetesClient, error) {
+	cluster := &models.Cluster{
+		Name:     "default",
+		Endpoint: "",
+		Auth: models.AuthConfig{
+			Type:       "kubeconfig",
+			Kubeconfig: kubeconfig,
+			Context:    contextName,
+		},
+	}
+	
+	client, err := kubernetesclient.NewKubernetesClient(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	
+	if err := client.TestConnection(context.Background()); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Kubernetes cluster: %w", err)
+	}
+	
+	return client, nil
+}
+
+// Message types for internal communication
+type RefreshMsg struct{}
+type ErrorMsg struct{ Error string }
+type InfoMsg struct{ Info string }
+
+func (app *Application) initializeComponents() error {
+	// Initialize UI components - same as kUber but simplified
+	app.statusBar = tuicomponents.NewKubernetesStatusBar(app.width)
+	app.breadcrumb = tuicomponents.NewKubernetesBreadcrumb()
+	app.detailViewport = tuicomponents.NewViewportComponent(app.width, app.height-5, "")
+	app.namespaceList = tuicomponents.NewListComponent([]list.Item{}, "Namespaces")
+	app.contextList = tuicomponents.NewListComponent([]list.Item{}, "☸ Contexts")
+	app.fleetTable = tuicomponents.NewTableComponent(fleetColumns(), []table.Row{})
+	app.fleetTable.SetTitle("🌐 Fleet")
+	app.portForwardTable = tuicomponents.NewTableComponent(portForwardColumns(), []table.Row{})
+	app.portForwardTable.SetTitle("🔌 Port Forwards")
+	app.fileTable = tuicomponents.NewTableComponent(fileColumns(), []table.Row{})
+	app.eventTable = tuicomponents.NewTableComponent(eventColumns(true), []table.Row{})
+	app.eventTable.SetTitle("⚡ Events")
+	app.searchTable = tuicomponents.NewTableComponent(searchColumns(), []table.Row{})
+	app.searchTable.SetTitle("🔍 Search")
+	app.problemTable = tuicomponents.NewTableComponent(problemColumns(true), []table.Row{})
+	app.problemTable.SetTitle("🩺 Problems")
+	app.topTable = tuicomponents.NewTableComponent(topColumns(false), []table.Row{})
+	app.topTable.SetTitle("📈 Top")
+	app.metricsTable = tuicomponents.NewTableComponent(metricsColumns(false), []table.Row{})
+	app.metricsTable.SetTitle("📉 Metrics")
+	
+	// Initialize resource table with pod columns
+	columns := []table.Column{
+		{Title: "Name", Width: 30},
+		{Title: "Status", Width: 15},
+		{Title: "Age", Width: 10},
+	}
+	app.resourceTable = tuicomponents.NewTableComponent(columns, []table.Row{})
+	
+	// Resource tabs (same as kuber but read-only)
+	app.resourceTabs = tuicomponents.NewListComponent(builtinResourceTabs(), "Resource Types")
+	app.resourceTabs.SetTitle("📋 Resources")
+	app.resourceTabs.SetShowFilter(false) // Disable filtering for resource tabs
+	app.resourceTabs.SetShowHelp(false)   // Disable help for cleaner UI
+	app.resourceTabs.SetShowStatusBar(false) // Clean up the tabs view
+	
+	app.statusBar.AddLeftItem("resource", "-")
+	
+	return nil
+}
+
+// builtinResourceTabs returns the resource tab items for the built-in types
+func builtinResourceTabs() []list.Item {
+	resourceTypes := []list.Item{}
+	resourceList := []string{"pods", "deployments", "statefulsets", "services", "configmaps", "secrets", "ingress", "persistentvolumes", "persistentvolumeclaims"}
+	icons := []string{"🐳", "🚀", "📊", "🌐", "⚙️", "🔐", "🌍", "💾", "📀"}
+
+	for i, rt := range resourceList {
+		icon := "📦"
+		if i < len(icons) {
+			icon = icons[i]
+		}
+		resourceTypes = append(resourceTypes, tuicomponents.NewListItem(rt, fmt.Sprintf("Kubernetes %s", rt), icon, rt))
+	}
+
+	return resourceTypes
+}
+
+// Run starts the TUI and blocks until the user exits
+func (app *Application) Run() error {
+	p := tea.NewProgram(app, tea.WithAltScreen())
+	app.program = p
+	_, err := p.Run()
+	return err
+}
+
+// Cleanup stops log streaming and releases cluster connections
+func (app *Application) Cleanup() {
+	// Stop any active log streaming
+	if app.logStreamCancel != nil {
+		app.logStreamCancel()
+		app.logStreamCancel = nil
+	}
+	app.stopEventWatch()
+	
+	// Clean up resources
+	if app.fleet != nil {
+		app.fleet.Close()
+	}
+	if app.metricsCollector != nil {
+		app.metricsCollector.Close()
+	}
+	if app.resourceManager != nil {
+		app.resourceManager.Close()
+	}
+	if app.client != nil {
+		app.client.Close()
+	}
+}
+
+// Bubble Tea interface methods
+func (app *Application) Init() tea.Cmd {
+	cmds := []tea.Cmd{
+		app.loadClusterMetrics(),
+		app.loadCustomResourceTabs(),
+		app.startPeriodicRefresh(),
+		tickProblemCount(),
+		app.checkWatchHealth(),
+		tea.EnterAltScreen,
+	}
+	if app.currentView == ViewOverview {
+		cmds = append(cmds, app.countProblems())
+	}
+	if app.currentView == ViewTop {
+		cmds = append(cmds, app.loadTop(), app.tickTop())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (app *Application) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		app.width = msg.Width
+		app.height = msg.Height
+		app.updateComponentSizes()
+		if app.editor != nil {
+			app.editor.Update(msg)
+		}
+		return app, nil
+
+	case tea.KeyMsg:
+		// If we're showing an info message, any key dismisses it
+		if app.info != "" {
+			app.info = ""
+			return app, nil
+		}
+
+		// The embedded editor owns the keyboard while it is open
+		if app.currentView == ViewEditor && app.editor != nil {
+			_, cmd := app.editor.Update(msg)
+			return app, cmd
+		}
+
+		// Handle prompt input (confirmations, replica counts)
+		if app.prompt != nil {
+			return app.handlePromptInput(msg)
+		}
+
+		// The search palette takes typed keys as its query
+		if app.currentView == ViewSearch {
+			return app, app.handleSearchPaletteKey(msg)
+		}
+		if msg.String() == "ctrl+k" {
+			return app, app.openSearchPalette()
+		}
+
+		if app.editingEnabled {
+			if cmd, handled := app.handleEditingKey(msg); handled {
+				return app, cmd
+			}
+		}
+
+		// Handle search mode input
+		if app.searchMode && (app.currentView == ViewLogs || app.currentView == ViewClusterLogs) {
+			return app.handleSearchInput(msg)
+		}
+		
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return app, tea.Quit
+		case "r":
+			return app, app.refreshCurrentView()
+		case "tab":
+			app.switchActiveComponent()
+			return app, nil
+		case "C":
+			if app.currentView == ViewOverview || app.currentView == ViewNamespaces {
+				return app, app.openContextPicker()
+			}
+		case "F":
+			if app.currentView == ViewOverview {
+				return app, app.openFleet()
+			}
+		case "P":
+			if app.currentView == ViewOverview || app.currentView == ViewNamespaces || app.currentView == ViewResources {
+				return app, app.openPortForwards()
+			}
+		case "E":
+			if app.currentView == ViewOverview {
+				return app, app.openEvents("")
+			}
+			if app.currentView == ViewResources {
+				return app, app.openEvents(app.selectedNamespace)
+			}
+		case "!":
+			if app.currentView == ViewOverview || app.currentView == ViewNamespaces {
+				return app, app.openProblems("")
+			}
+			if app.currentView == ViewResources {
+				return app, app.openProblems(app.selectedNamespace)
+			}
+		case "T":
+			if app.currentView == ViewOverview || app.currentView == ViewNamespaces {
+				return app, app.openTop("")
+			}
+			if app.currentView == ViewResources {
+				return app, app.openTop(app.selectedNamespace)
+			}
+		case "M":
+			if app.currentView == ViewOverview || app.currentView == ViewNamespaces {
+				return app, app.openMetrics("")
+			}
+			if app.currentView == ViewResources {
+				return app, app.openMetrics(app.selectedNamespace)
+			}
+		case "t":
+			if app.currentView == ViewMetrics {
+				return app, app.cycleMetricsRange()
+			}
+		case "o":
+			if app.currentView == ViewDetails {
+				return app, app.openOwnerTree(app.currentResourceType, app.detailResourceName)
+			}
+			if app.currentView == ViewResources && app.activeComponent == app.resourceTable {
+				selectedRow := app.resourceTable.GetSelectedRow()
+				if selectedRow != nil && len(selectedRow) > 0 {
+					return app, app.openOwnerTree(app.currentResourceType, selectedRow[0])
+				}
+			}
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			if app.currentView == ViewDetails {
+				return app, app.openRelated(int(msg.String()[0] - '1'))
+			}
+		case "w":
+			if app.currentView == ViewEvents {
+				app.toggleEventWarnings()
+				return app, nil
+			}
+		case "x":
+			if app.currentView == ViewPortForwards {
+				return app, app.stopSelectedPortForward()
+			}
+		case "b":
+			if app.currentView == ViewDetails && app.currentResourceType == "pods" {
+				return app, app.openFileBrowser(app.detailResourceName)
+			}
+		case "g":
+			if app.currentView == ViewFiles {
+				return app, app.promptDownload()
+			}
+		case "u":
+			if app.currentView == ViewFiles {
+				return app, app.promptUpload()
+			}
+		case "backspace":
+			if app.currentView == ViewFiles && app.fileBrowser.dir != "/" {
+				return app, app.listFiles(path.Dir(app.fileBrowser.dir))
+			}
+		case "c":
+			if app.currentView == ViewOverview {
+				// Show cluster logs view
+				app.currentView = ViewClusterLogs
+				return app, app.loadClusterLogsView()
+			}
+		case "enter":
+			if app.currentView == ViewOverview {
+				// Navigate to namespaces view
+				app.currentView = ViewNamespaces
+				app.switchActiveComponent()
+				return app, app.loadNamespaces()
+			} else if app.currentView == ViewNamespaces {
+				// Handle namespace selection
+				return app, app.selectNamespace()
+			} else if app.currentView == ViewContexts {
+				return app, app.selectContext()
+			} else if app.currentView == ViewFleet {
+				return app, app.selectFleetCluster()
+			} else if app.currentView == ViewFiles {
+				return app, app.openSelectedFile()
+			} else if app.currentView == ViewProblems {
+				return app, app.openSelectedProblem()
+			} else if app.currentView == ViewTop {
+				app.drillIntoTopPod()
+				return app, nil
+			} else if app.currentView == ViewResources {
+				if app.activeComponent == app.resourceTabs {
+					// Handle resource tab selection
+					selectedItem := app.resourceTabs.GetSelectedItem()
+					if selectedItem != nil {
+						if listItem, ok := selectedItem.(tuicomponents.ListItem); ok {
+							if data := listItem.Data(); data != nil {
+								if resourceType, ok := data.(string); ok {
+									// Update the resource type and reload resources
+									app.currentResourceType = resourceType
+									return app, app.loadNamespaceResources(app.selectedNamespace)
+								}
+							}
+						}
+					}
+					return app, nil
+				} else if app.activeComponent == app.resourceTable {
+					// Handle resource selection based on type
+					selectedRow := app.resourceTable.GetSelectedRow()
+					if selectedRow != nil && len(selectedRow) > 0 {
+						if app.currentResourceType == "pods" {
+							// For pods, view logs
+							return app, app.selectPodForLogs()
+						} else {
+							// For other resources, view details
+							app.currentView = ViewDetails
+							return app, app.loadResourceDetails(app.selectedNamespace, app.currentResourceType, selectedRow[0])
+						}
+					}
+				}
+			}
+		case "l":
+			if app.currentView == ViewResources {
+				// View logs for pods, deployments, statefulsets
+				if app.currentResourceType == "pods" {
+					return app, app.selectPodForLogs()
+				} else if app.currentResourceType == "deployments" || app.currentResourceType == "statefulsets" {
+					// View aggregated logs for workload resources
+					selectedRow := app.resourceTable.GetSelectedRow()
+					if selectedRow != nil && len(selectedRow) > 0 {
+						return app, app.selectWorkloadForLogs(selectedRow[0])
+					}
+				} else {
+					return app, func() tea.Msg {
+						return InfoMsg{Info: fmt.Sprintf("Logs are not available for %s resources. Only pods, deployments, and statefulsets support log viewing.", app.currentResourceType)}
+					}
+				}
+			}
+		case "L":
+			if app.currentView == ViewResources && app.currentResourceType == "pods" {
+				app.prompt = &inputPrompt{
+					label:    "Follow logs of pods matching label selector:",
+					onSubmit: app.selectLabelSelectorForLogs,
+				}
+				return app, nil
+			}
+		case "p":
+			if app.currentView == ViewLogs {
+				app.toggleLogPretty()
+				return app, nil
+			}
+			if app.currentView == ViewMetrics {
+				return app, app.toggleMetricsPods()
+			}
+		case "f":
+			if app.currentView == ViewLogs {
+				// Toggle follow mode
+				return app, app.toggleFollowMode()
+			}
+			if app.currentView == ViewResources && app.activeComponent == app.resourceTable {
+				if app.currentResourceType == "pods" || app.currentResourceType == "services" {
+					selectedRow := app.resourceTable.GetSelectedRow()
+					if selectedRow != nil && len(selectedRow) > 0 {
+						return app, app.promptPortForward(selectedRow[0])
+					}
+				} else {
+					return app, func() tea.Msg {
+						return InfoMsg{Info: fmt.Sprintf("Port forwarding is only available for pods and services. Current view: %s", app.currentResourceType)}
+					}
+				}
+			}
+		case "/":
+			if app.currentView == ViewEvents {
+				return app, app.promptEventFilter()
+			}
+			if app.currentView == ViewLogs || app.currentView == ViewClusterLogs {
+				app.searchMode = !app.searchMode
+				if !app.searchMode {
+					// Exit search mode, restore original content
+					app.searchQuery = ""
+					if app.originalLogContent != "" {
+						app.detailViewport.SetContent(app.originalLogContent)
+					}
+				}
+				return app, nil
+			}
+		case "s":
+			if app.currentView == ViewTop {
+				app.cycleTopSort()
+				return app, nil
+			}
+			if app.currentView == ViewResources {
+				if app.currentResourceType == "pods" {
+					selectedRow := app.resourceTable.GetSelectedRow()
+					if selectedRow != nil && len(selectedRow) > 0 {
+						return app, app.execShell(selectedRow[0])
+					}
+				} else {
+					return app, func() tea.Msg {
+						return InfoMsg{Info: fmt.Sprintf("Shell access is only available for pods. Current view: %s", app.currentResourceType)}
+					}
+				}
+			}
+		case "d":
+			if app.currentView == ViewResources {
+				selectedRow := app.resourceTable.GetSelectedRow()
+				if selectedRow != nil && len(selectedRow) > 0 {
+					app.currentView = ViewDetails
+					return app, app.loadResourceDetails(app.selectedNamespace, app.currentResourceType, selectedRow[0])
+				}
+			}
+		case "esc":
+			return app, app.navigateBack()
+		default:
+			// Forward navigation keys to active component
+			if app.currentView == ViewNamespaces && app.namespaceList != nil {
+				var updatedComponent tuicomponents.Component
+				updatedComponent, cmd = app.namespaceList.Update(msg)
+				if list, ok := updatedComponent.(*tuicomponents.ListComponent); ok {
+					app.namespaceList = list
+				}
+				cmds = append(cmds, cmd)
+			} else if app.currentView == ViewContexts && app.contextList != nil {
+				var updatedComponent tuicomponents.Component
+				updatedComponent, cmd = app.contextList.Update(msg)
+				if list, ok := updatedComponent.(*tuicomponents.ListComponent); ok {
+					app.contextList = list
+				}
+				cmds = append(cmds, cmd)
+			} else if app.currentView == ViewFleet && app.fleetTable != nil {
+				var updatedComponent tuicomponents.Component
+				updatedComponent, cmd = app.fleetTable.Update(msg)
+				if table, ok := updatedComponent.(*tuicomponents.TableComponent); ok {
+					app.fleetTable = table
+				}
+				cmds = append(cmds, cmd)
+			} else if app.currentView == ViewFiles && app.fileTable != nil {
+				var updatedComponent tuicomponents.Component
+				updatedComponent, cmd = app.fileTable.Update(msg)
+				if table, ok := updatedComponent.(*tuicomponents.TableComponent); ok {
+					app.fileTable = table
+				}
+				cmds = append(cmds, cmd)
+			} else if app.currentView == ViewEvents && app.eventTable != nil {
+				var updatedComponent tuicomponents.Component
+				updatedComponent, cmd = app.eventTable.Update(msg)
+				if table, ok := updatedComponent.(*tuicomponents.TableComponent); ok {
+					app.eventTable = table
+				}
+				cmds = append(cmds, cmd)
+			} else if app.currentView == ViewProblems && app.problemTable != nil {
+				var updatedComponent tuicomponents.Component
+				updatedComponent, cmd = app.problemTable.Update(msg)
+				if table, ok := updatedComponent.(*tuicomponents.TableComponent); ok {
+					app.problemTable = table
+				}
+				cmds = append(cmds, cmd)
+			} else if app.currentView == ViewTop && app.topTable != nil {
+				var updatedComponent tuicomponents.Component
+				updatedComponent, cmd = app.topTable.Update(msg)
+				if table, ok := updatedComponent.(*tuicomponents.TableComponent); ok {
+					app.topTable = table
+				}
+				cmds = append(cmds, cmd)
+			} else if app.currentView == ViewMetrics && app.metricsTable != nil {
+				var updatedComponent tuicomponents.Component
+				updatedComponent, cmd = app.metricsTable.Update(msg)
+				if table, ok := updatedComponent.(*tuicomponents.TableComponent); ok {
+					app.metricsTable = table
+				}
+				cmds = append(cmds, cmd)
+			} else if app.currentView == ViewPortForwards && app.portForwardTable != nil {
+				var updatedComponent tuicomponents.Component
+				updatedComponent, cmd = app.portForwardTable.Update(msg)
+				if table, ok := updatedComponent.(*tuicomponents.TableComponent); ok {
+					app.portForwardTable = table
+				}
+				cmds = append(cmds, cmd)
+			} else if app.currentView == ViewResources {
+				// Forward to the active component in resource view
+				if app.activeComponent == app.resourceTabs && app.resourceTabs != nil {
+					var updatedComponent tuicomponents.Component
+					updatedComponent, cmd = app.resourceTabs.Update(msg)
+					if list, ok := updatedComponent.(*tuicomponents.ListComponent); ok {
+						app.resourceTabs = list
+					}
+					cmds = append(cmds, cmd)
+				} else if app.activeComponent == app.resourceTable && app.resourceTable != nil {
+					var updatedComponent tuicomponents.Component
+					updatedComponent, cmd = app.resourceTable.Update(msg)
+					if table, ok := updatedComponent.(*tuicomponents.TableComponent); ok {
+						app.resourceTable = table
+					}
+					cmds = append(cmds, cmd)
+				}
+			} else if (app.currentView == ViewDetails || app.currentView == ViewLogs || app.currentView == ViewClusterLogs || app.currentView == ViewOwners) && app.detailViewport != nil {
+				// Forward to viewport for detail views
+				var updatedComponent tuicomponents.Component
+				updatedComponent, cmd = app.detailViewport.Update(msg)
+				if viewport, ok := updatedComponent.(*tuicomponents.ViewportComponent); ok {
+					app.detailViewport = viewport
+				}
+				cmds = append(cmds, cmd)
+			}
+		}
+
+	case RefreshMsg:
+		// Skip automatic refresh for certain views
+		if app.currentView == ViewDetails || app.currentView == ViewLogs || app.currentView == ViewClusterLogs || app.currentView == ViewFiles || app.currentView == ViewEvents || app.currentView == ViewOwners || app.currentView == ViewSearch || app.currentView == ViewTop || app.currentView == ViewMetrics {
+			return app, app.startPeriodicRefresh()
+		}
+		// Diagnosing doesn't answer with a RefreshMsg, so keep the ticks going
+		if app.currentView == ViewProblems {
+			return app, tea.Batch(app.loadProblems(), app.startPeriodicRefresh())
+		}
+		return app, app.refreshCurrentView()
+
+	case ErrorMsg:
+		app.error = msg.Error
+		return app, nil
+
+	case InfoMsg:
+		app.info = msg.Info
+		app.error = ""
+		return app, nil
+
+	case ContainersMsg:
+		return app, app.handleContainers(msg)
+
+	case ShellReadyMsg:
+		return app, app.handleShellReady(msg)
+
+	case NoShellMsg:
+		return app, app.handleNoShell(msg)
+
+	case DebugReadyMsg:
+		return app, app.handleDebugReady(msg)
+
+	case EditingMsg:
+		return app.handleEditingMsg(msg)
+
+	case ResourceMutatedMsg:
+		return app, app.handleResourceMutated(msg)
+
+	case ContextSwitchedMsg:
+		return app, app.handleContextSwitched(msg)
+
+	case ResourceEventMsg:
+		return app, app.handleResourceEvent(msg)
+
+	case highlightExpiredMsg:
+		app.expireHighlights()
+		return app, nil
+
+	case WatchHealthMsg:
+		return app, app.handleWatchHealth(msg)
+
+	case FilesLoadedMsg:
+		app.handleFilesLoaded(msg)
+		return app, nil
+
+	case CopyProgressMsg:
+		app.handleCopyProgress(msg)
+		return app, nil
+
+	case CopyDoneMsg:
+		return app, app.handleCopyDone(msg)
+
+	case portForwardTickMsg:
+		return app, app.handlePortForwardTick()
+
+	case FleetLoadedMsg:
+		app.handleFleetLoaded(msg)
+		return app, nil
+
+	case LogEntriesMsg:
+		app.handleLogEntries(msg)
+		return app, nil
+
+	case LogFollowEndedMsg:
+		return app, app.handleLogFollowEnded(msg)
+
+	case EventsMsg:
+		app.handleEvents(msg)
+		return app, nil
+
+	case EventWatchEndedMsg:
+		app.handleEventWatchEnded(msg)
+		return app, nil
+
+	case OwnerTreeMsg:
+		app.handleOwnerTree(msg)
+		return app, nil
+
+	case WorkloadLogsMsg:
+		return app, app.openMultiplexedLogs(msg.Selector, msg.Title)
+
+	case ProblemsMsg:
+		app.handleProblems(msg)
+		return app, nil
+
+	case ProblemCountMsg:
+		app.handleProblemCount(msg)
+		return app, nil
+
+	case problemCountTickMsg:
+		return app, app.handleProblemCountTick()
+
+	case TopMsg:
+		app.handleTop(msg)
+		return app, nil
+
+	case topTickMsg:
+		return app, app.handleTopTick(msg)
+
+	case MetricsHistoryMsg:
+		app.handleMetricsHistory(msg)
+		return app, nil
+
+	case metricsTickMsg:
+		return app, app.handleMetricsTick(msg)
+
+	case searchDueMsg:
+		return app, app.runSearch(msg)
+
+	case SearchResultsMsg:
+		app.handleSearchResults(msg)
+		return app, nil
+	}
+
+	return app, tea.Batch(cmds...)
+}
+
+func (app *Application) View() string {
+	if !app.ready {
+		return app.renderLoading()
+	}
+
+	if app.error != "" {
+		return app.renderError()
+	}
+
+	if app.info != "" {
+		return app.renderInfo()
+	}
+
+	if app.currentView == ViewEditor && app.editor != nil {
+		return app.editor.View()
+	}
+
+	return app.renderMainView()
+}
+
+// renderMainView renders the main application interface
+func (app *Application) renderMainView() string {
+	var content strings.Builder
+
+	// Header: application title
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39")). // Bright blue
+		Background(lipgloss.Color("0")).  // Black background
+		Padding(0, 1)
+
+	titleText := "kTop - Kubernetes Monitoring Tool (Read-Only)"
+	if app.editingEnabled {
+		titleText = "kUber - Kubernetes Manager"
+	}
+	title := titleStyle.Render(titleText)
+	content.WriteString(title + "\n")
+
+	// Breadcrumb
+	app.breadcrumb.SetSize(app.width, 1)
+	content.WriteString(app.breadcrumb.View() + "\n")
+
+	// Main content area
+	mainHeight := app.height - 4 // Reserve space for title, breadcrumb, and footer
+
+	switch app.currentView {
+	case ViewOverview:
+		content.WriteString(app.renderClusterOverview())
+
+	case ViewNamespaces:
+		app.namespaceList.SetSize(app.width, mainHeight)
+		content.WriteString(app.namespaceList.View())
+
+	case ViewContexts:
+		app.contextList.SetSize(app.width, mainHeight)
+		content.WriteString(app.contextList.View())
+
+	case ViewFleet:
+		content.WriteString(app.renderFleetView(mainHeight))
+
+	case ViewPortForwards:
+		content.WriteString(app.renderPortForwardsView(mainHeight))
+
+	case ViewFiles:
+		content.WriteString(app.renderFilesView(mainHeight))
+
+	case ViewEvents:
+		content.WriteString(app.renderEventsView(mainHeight))
+
+	case ViewSearch:
+		content.WriteString(app.renderSearchPalette(mainHeight))
+
+	case ViewProblems:
+		content.WriteString(app.renderProblemsView(mainHeight))
+
+	case ViewTop:
+		content.WriteString(app.renderTopView(mainHeight))
+
+	case ViewMetrics:
+		content.WriteString(app.renderMetricsView(mainHeight))
+
+	case ViewResources:
+		app.resourceTable.SetSize(app.width, mainHeight)
+		content.WriteString(app.renderResourcesView())
+
+	case ViewDetails, ViewClusterLogs, ViewLogs, ViewOwners:
+		app.detailViewport.SetSize(app.width, mainHeight)
+		content.WriteString(app.detailViewport.View())
+	}
+
+	// Add search status if in search mode
+	if app.searchMode && (app.currentView == ViewLogs || app.currentView == ViewClusterLogs) {
+		content.WriteString("\n")
+		searchStatus := fmt.Sprintf("🔍 Search: '%s' (Press ESC to exit, Enter to apply)", app.searchQuery)
+		if app.searchQuery == "" {
+			searchStatus = "🔍 Search mode ACTIVE (Type to search, ESC to exit)"
+		}
+		// Make search status more prominent with styling
+		searchStyle := lipgloss.NewStyle().
+			Background(lipgloss.Color("240")).
+			Foreground(lipgloss.Color("15")).
+			Padding(0, 1)
+		content.WriteString(searchStyle.Render(searchStatus) + "\n")
+	}
+
+	// Add follow mode status if in logs view
+	if app.currentView == ViewLogs {
+		content.WriteString("\n")
+		var statusParts []string
+		if app.followMode {
+			statusParts = append(statusParts, "📡 LIVE")
+		}
+		if app.logPretty {
+			statusParts = append(statusParts, "✨ PRETTY")
+		}
+		statusParts = append(statusParts, "f: toggle follow", "p: pretty/raw", "/: filter (level>=warn key=value)")
+
+		statusText := strings.Join(statusParts, " • ")
+		statusStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("8")).
+			Italic(true)
+		content.WriteString(statusStyle.Render(statusText) + "\n")
+	}
+
+	// Add prompt line if a question is pending
+	if app.prompt != nil {
+		content.WriteString("\n")
+		content.WriteString(app.renderPrompt() + "\n")
+	}
+
+	// Footer: Status bar
+	content.WriteString("\n")
+	app.statusBar.SetSize(app.width, 1)
+	content.WriteString(app.statusBar.View())
+
+	return content.String()
+}
+
+// renderLoading renders loading screen
+func (app *Application) renderLoading() string {
+	style := lipgloss.NewStyle().
+		Width(app.width).
+		Height(app.height).
+		Align(lipgloss.Center, lipgloss.Center).
+		Foreground(lipgloss.Color("205"))
+
+	return style.Render("🔄 Connecting to Kubernetes cluster...")
+}
+
+// renderError renders error screen
+func (app *Application) renderError() string {
+	style := lipgloss.NewStyle().
+		Width(app.width).
+		Height(app.height).
+		Align(lipgloss.Center, lipgloss.Center).
+		Foreground(lipgloss.Color("196")).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("196")).
+		Padding(1)
+
+	content := fmt.Sprintf("❌ Error\n\n%s\n\nPress 'q' to quit", app.error)
+	return style.Render(content)
+}
+
+// renderInfo renders info screen
+func (app *Application) renderInfo() string {
+	style := lipgloss.NewStyle().
+		Width(app.width).
+		Height(app.height).
+		Align(lipgloss.Center, lipgloss.Center).
+		Foreground(lipgloss.Color("46")). // Green
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("46")).
+		Padding(1)
+
+	content := fmt.Sprintf("ℹ️  Information\n\n%s\n\nPress any key to continue", app.info)
+	return style.Render(content)
+}
+
+// renderClusterOverview renders the enhanced dashboard with metrics and logs
+func (app *Application) renderClusterOverview() string {
+	var content strings.Builder
+
+	// Calculate layout dimensions
+	totalWidth := app.width
+	totalHeight := app.height - 5 // Reserve space for header and footer
+
+	// For kTop, focus more on metrics since it's monitoring-focused
+	metricsWidth := totalWidth
+	metricsHeight := totalHeight
+
+	// Performance metrics
+	performanceHeight := metricsHeight / 2
+	performanceContent := app.renderPerformanceMetrics(metricsWidth, performanceHeight)
+
+	// Resource counts  
+	resourceHeight := metricsHeight - performanceHeight
+	resourceContent := app.renderResourceMetrics(metricsWidth, resourceHeight)
+
+	// Combine metrics sections
+	performanceLines := strings.Split(performanceContent, "\n")
+	resourceLines := strings.Split(resourceContent, "\n")
+
+	for _, line := range performanceLines {
+		content.WriteString(line + "\n")
+	}
+	for _, line := range resourceLines {
+		content.WriteString(line + "\n")
+	}
+
+	// Add footer with navigation hint
+	content.WriteString("\n")
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8")).
+		Italic(true)
+	content.WriteString(hintStyle.Render("Press Enter to navigate to namespaces • Press 'c' for cluster logs • Press 'C' to switch context • Press 'F' for fleet overview • Press 'P' for port forwards • Press '!' for problems • Press 'T' for top pods • Press 'M' for metrics history • Press 'r' to refresh"))
+
+	return content.String()
+}
+
+// loadClusterMetrics loads cluster performance metrics
+func (app *Application) loadClusterMetrics() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		metrics := &ClusterMetrics{LastUpdated: time.Now()}
+
+		// Load node metrics
+		if err := app.loadNodeMetrics(ctx, metrics); err != nil {
+			metrics.Nodes.Total = 0
+			metrics.Nodes.Ready = 0
+			metrics.Nodes.NotReady = 0
+		}
+
+		// Load workload counts
+		if err := app.loadWorkloadCounts(ctx, metrics); err != nil {
+			metrics.Workloads.Deployments = 0
+			metrics.Workloads.StatefulSets = 0
+			metrics.Workloads.Pods = 0
+			metrics.Workloads.Services = 0
+			metrics.Workloads.Ingresses = 0
+		}
+
+		// Problems are counted on their own ticker, see countProblems
+		metrics.Problems = app.problemCounts
+
+		app.clusterMetrics = metrics
+		return RefreshMsg{}
+	}
+}
+
+// loadResourceDetails loads detailed information for a specific resource
+func (app *Application) loadResourceDetails(namespace, resourceType, resourceName string) tea.Cmd {
+	app.detailResourceName = resourceName
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		resources, err := app.resourceManager.GetResourcesByType(ctx, namespace, resourceType)
+		if err != nil {
+			return ErrorMsg{Error: fmt.Sprintf("Failed to load resource details: %v", err)}
+		}
+
+		// Find the specific resource
+		var targetResource *models.Resource
+		for _, resource := range resources {
+			if resource.Metadata.Name == resourceName {
+				targetResource = resource
+				break
+			}
+		}
+
+		if targetResource == nil {
+			return ErrorMsg{Error: fmt.Sprintf("Resource %s not found", resourceName)}
+		}
+
+		// Events are best effort; the details are still useful without them
+		events, err := app.client.GetEvents(ctx, kubernetesclient.EventQuery{
+			Namespace: targetResource.Metadata.Namespace,
+			Kind:      targetResource.Kind,
+			Name:      targetResource.Metadata.Name,
+			UID:       targetResource.Metadata.UID,
+		})
+		if err == nil {
+			for _, event := range events {
+				targetResource.AddEvent(event)
+			}
+		}
+
+		// Related objects are best effort too
+		related, err := app.resourceManager.Relationships(ctx, namespace, targetResource.Kind, resourceName)
+		if err != nil {
+			related = nil
+		}
+		app.relatedObjects = related
+
+		// Explain pods the scheduler couldn't place, best effort as well
+		app.schedulingFits = nil
+		if scheduled := targetResource.GetCondition("PodScheduled"); targetResource.Kind == "Pod" && scheduled != nil && scheduled.Status == "False" {
+			if fits, err := app.resourceManager.ExplainScheduling(ctx, namespace, resourceName); err == nil {
+				app.schedulingFits = fits
+			}
+		}
+
+		// Format resource details
+		details := app.formatResourceDetails(targetResource)
+		app.detailViewport.SetContent(details)
+		app.detailViewport.SetTitle(fmt.Sprintf("🔍 Details: %s/%s", resourceType, resourceName))
+		app.switchActiveComponent()
+
+		return RefreshMsg{}
+	}
+}
+
+// formatResourceDetails formats detailed resource information
+func (app *Application) formatResourceDetails(resource *models.Resource) string {
+	var details strings.Builder
+
+	// Header
+	details.WriteString(fmt.Sprintf("=== %s: %s ===\n\n", resource.Kind, resource.Metadata.Name))
+
+	// Metadata
+	details.WriteString("📋 Metadata:\n")
+	details.WriteString(fmt.Sprintf("  Name: %s\n", resource.Metadata.Name))
+	details.WriteString(fmt.Sprintf("  Namespace: %s\n", resource.Metadata.Namespace))
+	details.WriteString(fmt.Sprintf("  UID: %s\n", resource.Metadata.UID))
+	details.WriteString(fmt.Sprintf("  Creation Time: %s\n", resource.Metadata.CreationTimestamp.Format(time.RFC3339)))
+	// Use a static age calculation to prevent constant screen updates
+	age := formatAgeFromTime(resource.Metadata.CreationTimestamp)
+	details.WriteString(fmt.Sprintf("  Age: %s (at page load)\n", age))
+
+	if resource.IsDeleting() {
+		details.WriteString(fmt.Sprintf("  ⚠️  Deletion Time: %s\n", resource.Metadata.DeletionTimestamp.Format(time.RFC3339)))
+	}
+
+	details.WriteString("\n")
+
+	// Labels
+	if len(resource.Metadata.Labels) > 0 {
+		details.WriteString("🏷️  Labels:\n")
+		for key, value := range resource.Metadata.Labels {
+			details.WriteString(fmt.Sprintf("  %s: %s\n", key, value))
+		}
+		details.WriteString("\n")
+	}
+
+	// Annotations
+	if len(resource.Metadata.Annotations) > 0 {
+		details.WriteString("📝 Annotations:\n")
+		for key, value := range resource.Metadata.Annotations {
+			// Truncate long annotation values
+			if len(value) > 100 {
+				value = value[:97] + "..."
+			}
+			details.WriteString(fmt.Sprintf("  %s: %s\n", key, value))
+		}
+		details.WriteString("\n")
+	}
+
+	// Status
+	details.WriteString("📊 Status:\n")
+	details.WriteString(fmt.Sprintf("  Phase: %s %s\n", resource.GetStatusIcon(), resource.ComputeStatus()))
+
+	for key, value := range resource.Status {
+		details.WriteString(fmt.Sprintf("  %s: %v\n", key, value))
+	}
+
+	details.WriteString("\n")
+
+	details.WriteString(formatScheduling(app.schedulingFits))
+	details.WriteString(formatEvents(resource.Events))
+	details.WriteString(formatRelated(app.relatedObjects))
+
+	// Instructions
+	details.WriteString("=== Instructions ===\n")
+	details.WriteString("Press 'l' to view logs (pods only)\n")
+	details.WriteString("Press 's' to exec shell (pods only)\n")
+	details.WriteString("Press 'b' to browse and copy files (pods only)\n")
+	details.WriteString("Press 'o' to show the ownership tree\n")
+	if len(app.relatedObjects) > 0 {
+		details.WriteString("Press '1'-'9' to open a related object\n")
+	}
+	if app.editingEnabled {
+		details.WriteString("Press 'e' to edit YAML\n")
+		details.WriteString("Press 'Ctrl+D' to delete\n")
+		if resource.Kind == "Pod" {
+			details.WriteString("Press 'D' to attach a debug container\n")
+		}
+		if isScalable(strings.ToLower(resource.Kind) + "s") {
+			details.WriteString("Press 'S' to scale, 'R' to restart\n")
+		}
+	}
+	details.WriteString("Press 'r' to refresh\n")
+	details.WriteString("Press 'Esc' to go back\n")
+
+	return details.String()
+}
+
+func (app *Application) refreshCurrentView() tea.Cmd {
+	switch app.currentView {
+	case ViewOverview:
+		return app.loadClusterMetrics()
+	case ViewNamespaces:
+		return app.loadNamespaces()
+	case ViewClusterLogs:
+		return app.loadClusterLogsView()
+	case ViewFleet:
+		return app.loadFleet()
+	case ViewPortForwards:
+		app.refreshPortForwards()
+	case ViewFiles:
+		if app.fileBrowser != nil {
+			return app.listFiles(app.fileBrowser.dir)
+		}
+	case ViewProblems:
+		return app.loadProblems()
+	case ViewTop:
+		return app.loadTop()
+	case ViewMetrics:
+		return app.loadMetricsHistory()
+	}
+	return nil
+}
+
+func (app *Application) startPeriodicRefresh() tea.Cmd {
+	return tea.Tick(30*time.Second, func(t time.Time) tea.Msg {
+		return RefreshMsg{}
+	})
+}
+
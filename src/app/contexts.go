@@ -0,0 +1,155 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+	resourcemanager "github.com/anindyar/kuber/src/libraries/resource-manager"
+	tuicomponents "github.com/anindyar/kuber/src/libraries/tui-components"
+	"github.com/anindyar/kuber/src/models"
+)
+
+// ContextSwitchedMsg carries the connections for a newly selected kubeconfig context
+type ContextSwitchedMsg struct {
+	Context         string
+	Client          *kubernetesclient.KubernetesClient
+	ResourceManager *resourcemanager.ResourceManager
+}
+
+// openContextPicker lists the kubeconfig contexts and shows the picker
+func (app *Application) openContextPicker() tea.Cmd {
+	contexts, err := kubernetesclient.ListContexts(app.config.KubeConfig)
+	if err != nil {
+		return func() tea.Msg {
+			return InfoMsg{Info: fmt.Sprintf("Cannot list contexts: %v", err)}
+		}
+	}
+
+	active := app.client.GetCluster().Context
+
+	var items []list.Item
+	selected := 0
+	for i, kctx := range contexts {
+		icon := "  "
+		if kctx.Name == active {
+			icon = "▶"
+			selected = i
+		} else if kctx.Current {
+			icon = "*"
+		}
+
+		details := []string{"cluster: " + kctx.Cluster}
+		if kctx.User != "" {
+			details = append(details, "user: "+kctx.User)
+		}
+		if kctx.Namespace != "" {
+			details = append(details, "namespace: "+kctx.Namespace)
+		}
+
+		items = append(items, tuicomponents.NewListItem(kctx.Name, strings.Join(details, " • "), icon, kctx.Name))
+	}
+
+	app.contextList.SetItems(items)
+	app.contextList.SetSelectedIndex(selected)
+	app.currentView = ViewContexts
+	app.switchActiveComponent()
+
+	return nil
+}
+
+// selectContext switches to the context highlighted in the picker
+func (app *Application) selectContext() tea.Cmd {
+	selectedItem := app.contextList.GetSelectedItem()
+	if selectedItem == nil {
+		return nil
+	}
+
+	listItem, ok := selectedItem.(tuicomponents.ListItem)
+	if !ok {
+		return nil
+	}
+
+	contextName, _ := listItem.Data().(string)
+	if contextName == "" || contextName == app.client.GetCluster().Context {
+		app.currentView = ViewOverview
+		app.switchActiveComponent()
+		return nil
+	}
+
+	return app.switchContext(contextName)
+}
+
+// switchContext connects to another kubeconfig context in the background
+func (app *Application) switchContext(contextName string) tea.Cmd {
+	kubeconfig := app.config.KubeConfig
+	return func() tea.Msg {
+		client, resourceManager, err := connect(kubeconfig, contextName)
+		if err != nil {
+			// Keep the current connection; the user can pick again
+			return InfoMsg{Info: fmt.Sprintf("Cannot switch to context %s: %v", contextName, err)}
+		}
+
+		return ContextSwitchedMsg{
+			Context:         contextName,
+			Client:          client,
+			ResourceManager: resourceManager,
+		}
+	}
+}
+
+// handleContextSwitched swaps in the new connections and resets the views
+func (app *Application) handleContextSwitched(msg ContextSwitchedMsg) tea.Cmd {
+	app.stopLogFollow()
+	app.searchMode = false
+	app.searchQuery = ""
+
+	// Closing the resource manager also ends its watches
+	app.watchedNamespace = ""
+	app.watchedResourceType = ""
+	app.tableResources = nil
+	app.rowHighlights = make(map[string]rowHighlight)
+
+	if app.metricsCollector != nil {
+		app.metricsCollector.Close()
+	}
+	app.resourceManager.Close()
+	app.client.Close()
+
+	app.client = msg.Client
+	app.resourceManager = msg.ResourceManager
+	app.metricsCollector = newMetricsCollector(msg.Client, msg.ResourceManager)
+	app.clusterUsage = nil
+	app.problemCounts = models.IssueCounts{}
+	app.config.Context = msg.Context
+
+	app.selectedNamespace = ""
+	app.currentResourceType = "pods"
+	app.resourceTabs.SetItems(builtinResourceTabs())
+	app.namespaceList.SetItems([]list.Item{})
+	app.currentView = ViewOverview
+	app.switchActiveComponent()
+	app.updateClusterInfo()
+
+	return tea.Batch(
+		app.loadClusterMetrics(),
+		app.loadCustomResourceTabs(),
+		app.countProblems(),
+	)
+}
+
+// updateClusterInfo shows the active cluster and context in the status bar and breadcrumb
+func (app *Application) updateClusterInfo() {
+	cluster := app.client.GetCluster()
+
+	namespace := app.selectedNamespace
+	if namespace == "" {
+		namespace = "-"
+	}
+
+	app.statusBar.SetClusterInfo(cluster.GetDisplayName(), namespace, cluster.Context)
+	app.breadcrumb.SetCluster(cluster.Context)
+}
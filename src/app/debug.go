@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+)
+
+// debugStartTimeout bounds how long pulling and starting a debug image may take
+const debugStartTimeout = 2 * time.Minute
+
+// DebugReadyMsg reports a running debug container, ready to attach to
+type DebugReadyMsg struct {
+	PodName   string
+	Namespace string
+	Container string
+	Target    string
+}
+
+// handleNoShell offers a debug container when the container has no shell.
+// Adding one changes the pod, so kTop only explains the option.
+func (app *Application) handleNoShell(msg NoShellMsg) tea.Cmd {
+	if !app.editingEnabled {
+		return func() tea.Msg {
+			return ErrorMsg{Error: fmt.Sprintf(`Container %s in pod %s has no shell, as is common for distroless images.
+
+kUber can attach an ephemeral debug container that shares its processes: press 'D' on the pod.`, msg.Container, msg.PodName)}
+		}
+	}
+
+	app.prompt = &inputPrompt{
+		label: fmt.Sprintf("%s/%s has no shell. Debug with image [%s] (Esc to cancel):", msg.PodName, msg.Container, kubernetesclient.DefaultDebugImage),
+		onSubmit: func(image string) tea.Cmd {
+			return app.startDebugContainer(msg.PodName, msg.Namespace, msg.Container, image)
+		},
+	}
+	return nil
+}
+
+// promptDebugContainer asks for the image of a debug container targeting a container
+func (app *Application) promptDebugContainer(podName, namespace, target string) tea.Cmd {
+	app.prompt = &inputPrompt{
+		label: fmt.Sprintf("Debug %s/%s with image [%s]:", podName, target, kubernetesclient.DefaultDebugImage),
+		onSubmit: func(image string) tea.Cmd {
+			return app.startDebugContainer(podName, namespace, target, image)
+		},
+	}
+	return nil
+}
+
+// startDebugContainer adds an ephemeral container sharing the target's
+// process namespace and waits for it to start
+func (app *Application) startDebugContainer(podName, namespace, target, image string) tea.Cmd {
+	client := app.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), debugStartTimeout)
+		defer cancel()
+
+		name, err := client.CreateDebugContainer(ctx, kubernetesclient.DebugOptions{
+			Namespace:       namespace,
+			PodName:         podName,
+			Image:           image,
+			TargetContainer: target,
+		})
+		if err != nil {
+			return ErrorMsg{Error: err.Error()}
+		}
+
+		if err := client.WaitForDebugContainer(ctx, namespace, podName, name); err != nil {
+			return ErrorMsg{Error: err.Error()}
+		}
+
+		return DebugReadyMsg{PodName: podName, Namespace: namespace, Container: name, Target: target}
+	}
+}
+
+// handleDebugReady hands the terminal to the debug container
+func (app *Application) handleDebugReady(msg DebugReadyMsg) tea.Cmd {
+	session := &podShell{
+		client:    app.client,
+		namespace: msg.Namespace,
+		podName:   msg.PodName,
+		container: msg.Container,
+		attach:    true,
+	}
+
+	return tea.Exec(session, func(err error) tea.Msg {
+		if err != nil {
+			return ErrorMsg{Error: fmt.Sprintf("Debug session in %s failed: %v", msg.PodName, err)}
+		}
+		return InfoMsg{Info: fmt.Sprintf(`Debug session in %s ended.
+
+The ephemeral container %s (sharing processes with %s) stays in the pod until the pod is deleted.`, msg.PodName, msg.Container, msg.Target)}
+	})
+}
@@ -0,0 +1,293 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ResourceMutatedMsg reports a successful delete, scale or restart. The
+// resource table is reloaded when it arrives.
+type ResourceMutatedMsg struct {
+	ResourceType string
+	Namespace    string
+	Name         string
+	Deleted      bool
+	Info         string
+}
+
+// inputPrompt is a single-line question shown above the status bar, used to
+// confirm destructive actions or to read a value such as a replica count
+type inputPrompt struct {
+	label    string
+	value    string
+	confirm  bool // answer with y/n instead of free text
+	onSubmit func(value string) tea.Cmd
+}
+
+// handleEditingKey handles the kUber-only mutation keys. It reports whether
+// the key was consumed.
+func (app *Application) handleEditingKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if app.currentView != ViewResources && app.currentView != ViewDetails {
+		return nil, false
+	}
+
+	switch msg.String() {
+	case "e":
+		name := app.selectedResourceName()
+		if name == "" {
+			return nil, true
+		}
+		return app.openEditor(app.currentResourceType, name), true
+
+	case "ctrl+d":
+		name := app.selectedResourceName()
+		if name == "" {
+			return nil, true
+		}
+		resourceType, namespace := app.currentResourceType, app.selectedNamespace
+		app.prompt = &inputPrompt{
+			label:   fmt.Sprintf("Delete %s/%s? (y/N)", resourceType, name),
+			confirm: true,
+			onSubmit: func(string) tea.Cmd {
+				return app.deleteResource(resourceType, namespace, name)
+			},
+		}
+		return nil, true
+
+	case "S":
+		name := app.selectedResourceName()
+		if name == "" {
+			return nil, true
+		}
+		if !isScalable(app.currentResourceType) {
+			return func() tea.Msg {
+				return InfoMsg{Info: fmt.Sprintf("Scaling is only available for deployments and statefulsets. Current view: %s", app.currentResourceType)}
+			}, true
+		}
+		resourceType, namespace := app.currentResourceType, app.selectedNamespace
+		app.prompt = &inputPrompt{
+			label: fmt.Sprintf("Scale %s/%s to replicas:", resourceType, name),
+			onSubmit: func(value string) tea.Cmd {
+				return app.scaleResource(resourceType, namespace, name, value)
+			},
+		}
+		return nil, true
+
+	case "D":
+		name := app.selectedResourceName()
+		if name == "" {
+			return nil, true
+		}
+		if app.currentResourceType != "pods" {
+			return func() tea.Msg {
+				return InfoMsg{Info: fmt.Sprintf("Debug containers are only available for pods. Current view: %s", app.currentResourceType)}
+			}, true
+		}
+		return app.pickContainer(name, "debug target", app.promptDebugContainer), true
+
+	case "R":
+		name := app.selectedResourceName()
+		if name == "" {
+			return nil, true
+		}
+		if !isScalable(app.currentResourceType) {
+			return func() tea.Msg {
+				return InfoMsg{Info: fmt.Sprintf("Rollout restart is only available for deployments and statefulsets. Current view: %s", app.currentResourceType)}
+			}, true
+		}
+		resourceType, namespace := app.currentResourceType, app.selectedNamespace
+		app.prompt = &inputPrompt{
+			label:   fmt.Sprintf("Restart %s/%s? (y/N)", resourceType, name),
+			confirm: true,
+			onSubmit: func(string) tea.Cmd {
+				return app.restartResource(resourceType, namespace, name)
+			},
+		}
+		return nil, true
+	}
+
+	return nil, false
+}
+
+// handlePromptInput processes keyboard input while a prompt is open
+func (app *Application) handlePromptInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := app.prompt
+
+	if p.confirm {
+		app.prompt = nil
+		switch msg.String() {
+		case "y", "Y":
+			return app, p.onSubmit("y")
+		}
+		return app, nil
+	}
+
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		app.prompt = nil
+		return app, nil
+	case "enter":
+		app.prompt = nil
+		return app, p.onSubmit(strings.TrimSpace(p.value))
+	case "backspace":
+		if len(p.value) > 0 {
+			p.value = p.value[:len(p.value)-1]
+		}
+		return app, nil
+	default:
+		// Add character to the value (only printable characters)
+		if len(msg.String()) == 1 && msg.String()[0] >= 32 && msg.String()[0] <= 126 {
+			p.value += msg.String()
+		}
+		return app, nil
+	}
+}
+
+// renderPrompt renders the open prompt line
+func (app *Application) renderPrompt() string {
+	promptStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("208")).
+		Foreground(lipgloss.Color("0")).
+		Padding(0, 1)
+
+	text := app.prompt.label
+	if !app.prompt.confirm {
+		text += " " + app.prompt.value + "█"
+	}
+	return promptStyle.Render(text)
+}
+
+// selectedResourceName returns the name of the resource the user is acting on
+func (app *Application) selectedResourceName() string {
+	if app.currentView == ViewDetails {
+		return app.detailResourceName
+	}
+
+	selectedRow := app.resourceTable.GetSelectedRow()
+	if len(selectedRow) == 0 {
+		return ""
+	}
+	return selectedRow[0]
+}
+
+// openEditor switches to the YAML editor for a resource
+func (app *Application) openEditor(resourceType, name string) tea.Cmd {
+	app.editor = NewEditorView(app.client, resourceType, name, app.selectedNamespace)
+	app.editor.Update(tea.WindowSizeMsg{Width: app.width, Height: app.height})
+	app.currentView = ViewEditor
+	return app.editor.Init()
+}
+
+// handleEditingMsg routes editor messages and closes the editor on cancel
+func (app *Application) handleEditingMsg(msg EditingMsg) (tea.Model, tea.Cmd) {
+	if app.editor == nil {
+		return app, nil
+	}
+
+	if msg.Action == "cancel" {
+		resourceType := app.editor.resourceType
+		app.editor = nil
+		app.currentView = ViewResources
+		app.switchActiveComponent()
+		app.resourceManager.InvalidateResources(app.selectedNamespace, resourceType)
+		return app, app.loadNamespaceResources(app.selectedNamespace)
+	}
+
+	_, cmd := app.editor.Update(msg)
+	return app, cmd
+}
+
+// deleteResource deletes a resource
+func (app *Application) deleteResource(resourceType, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := app.client.DeleteResource(ctx, resourceType, namespace, name); err != nil {
+			return ErrorMsg{Error: err.Error()}
+		}
+
+		return ResourceMutatedMsg{
+			ResourceType: resourceType,
+			Namespace:    namespace,
+			Name:         name,
+			Deleted:      true,
+			Info:         fmt.Sprintf("Deleted %s/%s", resourceType, name),
+		}
+	}
+}
+
+// scaleResource parses the requested replica count and scales the workload
+func (app *Application) scaleResource(resourceType, namespace, name, value string) tea.Cmd {
+	replicas, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || replicas < 0 {
+		return func() tea.Msg {
+			return ErrorMsg{Error: fmt.Sprintf("Invalid replica count: %q", value)}
+		}
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := app.client.ScaleResource(ctx, resourceType, namespace, name, int32(replicas)); err != nil {
+			return ErrorMsg{Error: err.Error()}
+		}
+
+		return ResourceMutatedMsg{
+			ResourceType: resourceType,
+			Namespace:    namespace,
+			Name:         name,
+			Info:         fmt.Sprintf("Scaled %s/%s to %d replicas", resourceType, name, replicas),
+		}
+	}
+}
+
+// restartResource triggers a rollout restart of the workload
+func (app *Application) restartResource(resourceType, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := app.client.RestartResource(ctx, resourceType, namespace, name); err != nil {
+			return ErrorMsg{Error: err.Error()}
+		}
+
+		return ResourceMutatedMsg{
+			ResourceType: resourceType,
+			Namespace:    namespace,
+			Name:         name,
+			Info:         fmt.Sprintf("Restarted %s/%s", resourceType, name),
+		}
+	}
+}
+
+// handleResourceMutated leaves the details of a deleted resource and reloads
+// the resource table. A failed reload is reported instead of the result.
+func (app *Application) handleResourceMutated(msg ResourceMutatedMsg) tea.Cmd {
+	app.resourceManager.InvalidateResources(msg.Namespace, msg.ResourceType)
+	app.info = msg.Info
+	app.error = ""
+
+	if msg.Deleted && app.currentView == ViewDetails && app.currentResourceType == msg.ResourceType && app.detailResourceName == msg.Name {
+		app.currentView = ViewResources
+		app.switchActiveComponent()
+	}
+
+	// The user may have moved on to another namespace meanwhile
+	if msg.Namespace != app.selectedNamespace {
+		return nil
+	}
+	return app.loadNamespaceResources(msg.Namespace)
+}
+
+// isScalable reports whether a resource type has a scale subresource we support
+func isScalable(resourceType string) bool {
+	return resourceType == "deployments" || resourceType == "statefulsets"
+}
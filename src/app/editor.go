@@ -1,4 +1,4 @@
-package main
+package app
 
 import (
 	"context"
@@ -82,7 +82,10 @@ func (e *EditorView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				e.isModified = false // Mark as handled
 				return e, nil
 			}
-			return e, tea.Quit
+			// Hand control back to the hosting application
+			return e, func() tea.Msg {
+				return EditingMsg{Action: "cancel"}
+			}
 		case "ctrl+z":
 			// Undo changes
 			e.textEditor.SetValue(e.originalYAML)
@@ -152,7 +155,7 @@ func (e *EditorView) View() string {
 		Padding(0, 1).
 		Width(e.width)
 
-	helpText := "Ctrl+S: Save | Ctrl+Z: Undo | Esc: Cancel | Ctrl+C: Force Exit"
+	helpText := "Ctrl+S: Save | Ctrl+Z: Undo | Esc: Close"
 	view.WriteString(helpStyle.Render(helpText) + "\n")
 
 	// Status bar
@@ -268,7 +271,7 @@ func (e *EditorView) getResourceYAMLViaKubectl(ctx context.Context) (string, err
 		args = append(args, "-n", e.namespace)
 	}
 
-	cmd := kubernetesclient.NewKubectlCommand(ctx, args...)
+	cmd := kubernetesclient.NewKubectlCommand(ctx, e.client.KubectlArgs(args...)...)
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("kubectl get failed: %w", err)
@@ -279,7 +282,7 @@ func (e *EditorView) getResourceYAMLViaKubectl(ctx context.Context) (string, err
 
 // applyResourceYAMLViaKubectl applies YAML using kubectl
 func (e *EditorView) applyResourceYAMLViaKubectl(ctx context.Context, yaml string) error {
-	cmd := kubernetesclient.NewKubectlCommandWithStdin(ctx, yaml, "apply", "-f", "-")
+	cmd := kubernetesclient.NewKubectlCommandWithStdin(ctx, yaml, e.client.KubectlArgs("apply", "-f", "-")...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("kubectl apply failed: %w\nOutput: %s", err, string(output))
@@ -287,8 +290,9 @@ func (e *EditorView) applyResourceYAMLViaKubectl(ctx context.Context, yaml strin
 	return nil
 }
 
-// AddEditingCapability adds the 'e' key handler to the main kUber application
-func AddEditingCapability(app interface{}) {
-	// This function will be called from the main kUber app to add editing capability
-	// We'll implement this as part of the main kUber update
-}
\ No newline at end of file
+// AddEditingCapability enables the write paths of the main kUber application:
+// the 'e' key opens the EditorView for the selected resource, and the delete,
+// scale and restart actions become available. kTop never calls this.
+func AddEditingCapability(app *Application) {
+	app.editingEnabled = true
+}
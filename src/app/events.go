@@ -0,0 +1,283 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+	"github.com/anindyar/kuber/src/models"
+)
+
+// eventBatchInterval is how often streamed events are handed to the UI
+const eventBatchInterval = 250 * time.Millisecond
+
+// eventCapacity is how many events the live events view keeps
+const eventCapacity = 5000
+
+// eventStream is the state of the live events view
+type eventStream struct {
+	namespace    string // empty for the whole cluster
+	events       *models.EventList
+	filter       *models.EventFilter
+	warningsOnly bool
+	cancel       context.CancelFunc
+	returnView   ViewType
+	err          error // set when the watch ended on its own
+}
+
+// EventsMsg delivers a batch of watched events
+type EventsMsg struct {
+	Namespace string
+	Events    []models.Event
+}
+
+// EventWatchEndedMsg reports that the event watch stopped
+type EventWatchEndedMsg struct {
+	Namespace string
+	Error     error
+}
+
+// eventColumns are the columns of the events view; the namespace column is
+// only shown for the whole cluster
+func eventColumns(withNamespace bool) []table.Column {
+	columns := []table.Column{
+		{Title: "Last Seen", Width: 10},
+		{Title: "Type", Width: 9},
+		{Title: "Reason", Width: 20},
+		{Title: "Object", Width: 36},
+		{Title: "Count", Width: 6},
+		{Title: "Message", Width: 70},
+	}
+	if withNamespace {
+		columns = append([]table.Column{{Title: "Namespace", Width: 18}}, columns...)
+	}
+	return columns
+}
+
+// openEvents switches to the live events view for a namespace, or the whole
+// cluster when namespace is empty
+func (app *Application) openEvents(namespace string) tea.Cmd {
+	app.stopEventWatch()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &eventStream{
+		namespace:  namespace,
+		events:     models.NewEventList(eventCapacity),
+		filter:     &models.EventFilter{},
+		cancel:     cancel,
+		returnView: app.currentView,
+	}
+	app.eventStream = stream
+
+	app.eventTable.SetRows(nil)
+	app.eventTable.SetColumns(eventColumns(namespace == ""))
+	app.currentView = ViewEvents
+	app.switchActiveComponent()
+	app.refreshEventRows()
+
+	client, program := app.client, app.program
+	go streamEvents(ctx, program, namespace, func(ctx context.Context, eventChan chan<- *models.Event) error {
+		return client.WatchEvents(ctx, kubernetesclient.EventQuery{Namespace: namespace}, eventChan)
+	})
+	return nil
+}
+
+// stopEventWatch stops the live events view's watch, if running
+func (app *Application) stopEventWatch() {
+	if app.eventStream != nil && app.eventStream.cancel != nil {
+		app.eventStream.cancel()
+		app.eventStream.cancel = nil
+	}
+}
+
+// streamEvents runs an event watch and sends what it sees to the program in
+// batches, so an event storm doesn't trigger a render per event
+func streamEvents(ctx context.Context, program *tea.Program, namespace string, watch func(context.Context, chan<- *models.Event) error) {
+	if program == nil {
+		return
+	}
+
+	events := make(chan *models.Event, 256)
+	done := make(chan error, 1)
+	go func() {
+		done <- watch(ctx, events)
+	}()
+
+	ticker := time.NewTicker(eventBatchInterval)
+	defer ticker.Stop()
+
+	var batch []models.Event
+	flush := func() {
+		if len(batch) > 0 {
+			program.Send(EventsMsg{Namespace: namespace, Events: batch})
+			batch = nil
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			batch = append(batch, *event)
+		case <-ticker.C:
+			flush()
+		case err := <-done:
+			for len(events) > 0 {
+				batch = append(batch, *<-events)
+			}
+			flush()
+			if ctx.Err() == nil {
+				program.Send(EventWatchEndedMsg{Namespace: namespace, Error: err})
+			}
+			return
+		}
+	}
+}
+
+// handleEvents adds a batch of events to the live events view
+func (app *Application) handleEvents(msg EventsMsg) {
+	if app.eventStream == nil || app.eventStream.namespace != msg.Namespace {
+		return
+	}
+	app.eventStream.events.Upsert(msg.Events...)
+	if app.currentView == ViewEvents {
+		app.refreshEventRows()
+	}
+}
+
+// handleEventWatchEnded records why the event watch stopped
+func (app *Application) handleEventWatchEnded(msg EventWatchEndedMsg) {
+	if app.eventStream == nil || app.eventStream.namespace != msg.Namespace {
+		return
+	}
+	app.eventStream.err = msg.Error
+}
+
+// refreshEventRows rebuilds the events table from the collected events,
+// collapsing repeats and applying the filter
+func (app *Application) refreshEventRows() {
+	stream := app.eventStream
+
+	var rows []table.Row
+	for _, event := range stream.events.Grouped(stream.filter) {
+		if stream.warningsOnly && event.Type != "Warning" {
+			continue
+		}
+
+		row := table.Row{
+			formatAgeFromTime(event.LastTimestamp),
+			eventTypeIcon(event.Type) + " " + event.Type,
+			event.Reason,
+			event.Object.String(),
+			fmt.Sprintf("%d", event.Count),
+			strings.ReplaceAll(event.Message, "\n", " "),
+		}
+		if stream.namespace == "" {
+			row = append(table.Row{event.Namespace}, row...)
+		}
+		rows = append(rows, row)
+	}
+
+	app.eventTable.SetRows(rows)
+}
+
+// promptEventFilter asks for an events filter, starting from the current one
+func (app *Application) promptEventFilter() tea.Cmd {
+	app.prompt = &inputPrompt{
+		label: "Filter events (type=warning reason=backoff ns=default kind=pod text):",
+		value: app.eventStream.filter.String(),
+		onSubmit: func(value string) tea.Cmd {
+			app.eventStream.filter = models.ParseEventFilter(value)
+			app.refreshEventRows()
+			return nil
+		},
+	}
+	return nil
+}
+
+// toggleEventWarnings switches between all events and warnings only
+func (app *Application) toggleEventWarnings() {
+	app.eventStream.warningsOnly = !app.eventStream.warningsOnly
+	app.refreshEventRows()
+}
+
+// renderEventsView renders the live events view
+func (app *Application) renderEventsView(height int) string {
+	var content strings.Builder
+	stream := app.eventStream
+
+	scope := "all namespaces"
+	if stream.namespace != "" {
+		scope = stream.namespace
+	}
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+	content.WriteString(headerStyle.Render(fmt.Sprintf("⚡ Events in %s • %d collected", scope, stream.events.Len())) + "\n")
+
+	app.eventTable.SetSize(app.width, height-4)
+	content.WriteString(app.eventTable.View() + "\n")
+
+	var status []string
+	if stream.err != nil {
+		status = append(status, fmt.Sprintf("⚠️  watch stopped: %v", stream.err))
+	} else {
+		status = append(status, "📡 LIVE")
+	}
+	if stream.warningsOnly {
+		status = append(status, "warnings only")
+	}
+	if !stream.filter.IsEmpty() {
+		status = append(status, "filter: "+stream.filter.String())
+	}
+	statusStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214"))
+	content.WriteString(statusStyle.Render(strings.Join(status, " • ")) + "\n")
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8")).
+		Italic(true)
+	content.WriteString(hintStyle.Render("/: Filter | w: Warnings only | Esc: Back"))
+
+	return content.String()
+}
+
+// formatEvents renders events for the details page, newest first with
+// repeats collapsed
+func formatEvents(events []models.Event) string {
+	var details strings.Builder
+
+	details.WriteString("⚡ Events:\n")
+	if len(events) == 0 {
+		details.WriteString("  <none>\n\n")
+		return details.String()
+	}
+
+	for _, event := range models.DedupEvents(events) {
+		count := ""
+		if event.Count > 1 {
+			count = fmt.Sprintf(" (x%d over %s)", event.Count, formatAgeFromTime(event.FirstTimestamp))
+		}
+		details.WriteString(fmt.Sprintf("  %s %-8s %s ago  %s%s\n", eventTypeIcon(event.Type), event.Type,
+			formatAgeFromTime(event.LastTimestamp), event.Reason, count))
+		details.WriteString(fmt.Sprintf("      %s\n", strings.ReplaceAll(event.Message, "\n", "\n      ")))
+	}
+	details.WriteString("\n")
+
+	return details.String()
+}
+
+// eventTypeIcon returns the icon for an event type
+func eventTypeIcon(eventType string) string {
+	if eventType == "Warning" {
+		return "⚠️"
+	}
+	return "ℹ️"
+}
@@ -0,0 +1,312 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+)
+
+// fileBrowser is the state of the container file browser
+type fileBrowser struct {
+	namespace string
+	podName   string
+	container string
+	dir       string
+	files     []kubernetesclient.PodFile
+	progress  string // current copy, empty when idle
+}
+
+// FilesLoadedMsg carries a directory listing from a container
+type FilesLoadedMsg struct {
+	Dir   string
+	Files []kubernetesclient.PodFile
+}
+
+// CopyProgressMsg reports the progress of a running copy
+type CopyProgressMsg struct {
+	Label    string // e.g. "⬇ heap.hprof"
+	Progress kubernetesclient.CopyProgress
+}
+
+// CopyDoneMsg reports a finished copy
+type CopyDoneMsg struct {
+	Summary string
+	Error   error
+}
+
+// fileColumns are the columns of the file browser table
+func fileColumns() []table.Column {
+	return []table.Column{
+		{Title: "", Width: 2},
+		{Title: "Name", Width: 40},
+		{Title: "Size", Width: 10},
+		{Title: "Mode", Width: 11},
+		{Title: "Modified", Width: 14},
+	}
+}
+
+// openFileBrowser opens the file browser on a container of the pod
+func (app *Application) openFileBrowser(podName string) tea.Cmd {
+	return app.pickContainer(podName, "file browser", func(podName, namespace, container string) tea.Cmd {
+		app.fileBrowser = &fileBrowser{
+			namespace: namespace,
+			podName:   podName,
+			container: container,
+			dir:       "/",
+		}
+		app.fileTable.SetRows(nil)
+		app.currentView = ViewFiles
+		app.switchActiveComponent()
+		return app.listFiles("/")
+	})
+}
+
+// listFiles lists a directory of the browsed container
+func (app *Application) listFiles(dir string) tea.Cmd {
+	client, browser := app.client, app.fileBrowser
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		// The trailing slash makes ls follow a symlinked directory
+		listPath := strings.TrimSuffix(dir, "/") + "/"
+		files, err := client.ListPodFiles(ctx, browser.namespace, browser.podName, browser.container, listPath)
+		if err != nil {
+			return ErrorMsg{Error: fmt.Sprintf("Failed to list %s in %s/%s: %v", dir, browser.podName, browser.container, err)}
+		}
+
+		return FilesLoadedMsg{Dir: dir, Files: files}
+	}
+}
+
+// handleFilesLoaded shows a directory listing, directories first
+func (app *Application) handleFilesLoaded(msg FilesLoadedMsg) {
+	if app.fileBrowser == nil {
+		return
+	}
+	app.fileBrowser.dir = msg.Dir
+	app.fileBrowser.files = msg.Files
+
+	var rows []table.Row
+	if msg.Dir != "/" {
+		rows = append(rows, table.Row{"⬆", "..", "", "", ""})
+	}
+
+	for _, dirs := range []bool{true, false} {
+		for _, file := range msg.Files {
+			if file.IsDir != dirs {
+				continue
+			}
+
+			icon, name, size := "📄", file.Name, formatByteCount(file.Size)
+			switch {
+			case file.IsDir:
+				icon, size = "📁", ""
+			case file.IsLink():
+				icon = "🔗"
+				name += " → " + file.LinkTarget
+			}
+			rows = append(rows, table.Row{icon, name, size, file.Mode, file.Modified})
+		}
+	}
+
+	app.fileTable.SetRows(rows)
+	app.fileTable.SetSelectedIndex(0)
+	app.fileTable.SetTitle(fmt.Sprintf("📁 %s/%s:%s", app.fileBrowser.podName, app.fileBrowser.container, msg.Dir))
+}
+
+// selectedFile returns the highlighted entry, or nil for ".."
+func (app *Application) selectedFile() *kubernetesclient.PodFile {
+	selectedRow := app.fileTable.GetSelectedRow()
+	if len(selectedRow) < 2 || selectedRow[1] == ".." {
+		return nil
+	}
+
+	name, _, _ := strings.Cut(selectedRow[1], " → ")
+	for i := range app.fileBrowser.files {
+		if app.fileBrowser.files[i].Name == name {
+			return &app.fileBrowser.files[i]
+		}
+	}
+	return nil
+}
+
+// openSelectedFile enters the highlighted directory, or the parent for ".."
+func (app *Application) openSelectedFile() tea.Cmd {
+	selectedRow := app.fileTable.GetSelectedRow()
+	if len(selectedRow) > 1 && selectedRow[1] == ".." {
+		return app.listFiles(path.Dir(app.fileBrowser.dir))
+	}
+
+	file := app.selectedFile()
+	if file == nil {
+		return nil
+	}
+	if !file.IsDir && !file.IsLink() {
+		return app.promptDownload()
+	}
+	// Links are tried as directories; ls reports an error if they aren't
+	return app.listFiles(path.Join(app.fileBrowser.dir, file.Name))
+}
+
+// promptDownload asks where to save the highlighted file or directory
+func (app *Application) promptDownload() tea.Cmd {
+	file := app.selectedFile()
+	if file == nil {
+		return nil
+	}
+
+	remotePath := path.Join(app.fileBrowser.dir, file.Name)
+	app.prompt = &inputPrompt{
+		label: fmt.Sprintf("Download %s to [./%s]:", remotePath, file.Name),
+		onSubmit: func(value string) tea.Cmd {
+			if value == "" {
+				value = "."
+			}
+			return app.copyFromPod(remotePath, expandHome(value))
+		},
+	}
+	return nil
+}
+
+// promptUpload asks which local file or directory to upload to the current directory
+func (app *Application) promptUpload() tea.Cmd {
+	dir := app.fileBrowser.dir
+	app.prompt = &inputPrompt{
+		label: fmt.Sprintf("Upload local file or directory to %s:", dir),
+		onSubmit: func(value string) tea.Cmd {
+			if value == "" {
+				return nil
+			}
+			return app.copyToPod(expandHome(value), strings.TrimSuffix(dir, "/")+"/")
+		},
+	}
+	return nil
+}
+
+// copyFromPod downloads a path from the browsed container, reporting progress
+func (app *Application) copyFromPod(remotePath, localPath string) tea.Cmd {
+	label := "⬇ " + path.Base(remotePath)
+	opts := app.copyOptions(label)
+	client := app.client
+	app.fileBrowser.progress = label
+
+	return func() tea.Msg {
+		if err := client.CopyFromPod(context.Background(), opts, remotePath, localPath); err != nil {
+			return CopyDoneMsg{Error: err}
+		}
+		return CopyDoneMsg{Summary: fmt.Sprintf("Downloaded %s to %s", remotePath, localPath)}
+	}
+}
+
+// copyToPod uploads a local path into the browsed container, reporting progress
+func (app *Application) copyToPod(localPath, remotePath string) tea.Cmd {
+	label := "⬆ " + filepath.Base(localPath)
+	opts := app.copyOptions(label)
+	client := app.client
+	app.fileBrowser.progress = label
+
+	return func() tea.Msg {
+		if err := client.CopyToPod(context.Background(), opts, localPath, remotePath); err != nil {
+			return CopyDoneMsg{Error: err}
+		}
+		return CopyDoneMsg{Summary: fmt.Sprintf("Uploaded %s to %s", localPath, remotePath)}
+	}
+}
+
+// copyOptions targets the browsed container and forwards progress to the UI
+func (app *Application) copyOptions(label string) kubernetesclient.CopyOptions {
+	program := app.program
+	return kubernetesclient.CopyOptions{
+		Namespace:     app.fileBrowser.namespace,
+		PodName:       app.fileBrowser.podName,
+		ContainerName: app.fileBrowser.container,
+		Progress: func(progress kubernetesclient.CopyProgress) {
+			if program != nil {
+				program.Send(CopyProgressMsg{Label: label, Progress: progress})
+			}
+		},
+	}
+}
+
+// handleCopyProgress updates the progress line of the file browser
+func (app *Application) handleCopyProgress(msg CopyProgressMsg) {
+	if app.fileBrowser == nil || msg.Progress.Done {
+		return
+	}
+
+	p := msg.Progress
+	progress := fmt.Sprintf("%s • %s • %s total", msg.Label, p.File, formatByteCount(p.Bytes))
+	if p.FileSize > 0 {
+		progress = fmt.Sprintf("%s • %s %s / %s (%.0f%%) • %d file(s) done",
+			msg.Label, p.File, formatByteCount(p.FileBytes), formatByteCount(p.FileSize),
+			float64(p.FileBytes)*100/float64(p.FileSize), p.Files)
+	}
+	app.fileBrowser.progress = progress
+}
+
+// handleCopyDone reports a finished copy and refreshes the listing after uploads
+func (app *Application) handleCopyDone(msg CopyDoneMsg) tea.Cmd {
+	if app.fileBrowser != nil {
+		app.fileBrowser.progress = ""
+	}
+	if msg.Error != nil {
+		return func() tea.Msg { return ErrorMsg{Error: msg.Error.Error()} }
+	}
+
+	cmds := []tea.Cmd{func() tea.Msg { return InfoMsg{Info: msg.Summary} }}
+	if app.fileBrowser != nil && app.currentView == ViewFiles {
+		cmds = append(cmds, app.listFiles(app.fileBrowser.dir))
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderFilesView renders the file browser
+func (app *Application) renderFilesView(height int) string {
+	var content strings.Builder
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+	content.WriteString(headerStyle.Render(fmt.Sprintf("📁 Files in %s/%s: %s",
+		app.fileBrowser.podName, app.fileBrowser.container, app.fileBrowser.dir)) + "\n")
+
+	app.fileTable.SetSize(app.width, height-4)
+	content.WriteString(app.fileTable.View() + "\n")
+
+	if app.fileBrowser.progress != "" {
+		progressStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("214"))
+		content.WriteString(progressStyle.Render(app.fileBrowser.progress))
+	}
+	content.WriteString("\n")
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8")).
+		Italic(true)
+	content.WriteString(hintStyle.Render("Enter: Open | Backspace: Up | g: Download | u: Upload here | r: Refresh | Esc: Back"))
+
+	return content.String()
+}
+
+// expandHome expands a leading ~ to the home directory
+func expandHome(p string) string {
+	if p != "~" && !strings.HasPrefix(p, "~/") {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return p
+	}
+	return filepath.Join(home, strings.TrimPrefix(p, "~"))
+}
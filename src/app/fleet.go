@@ -0,0 +1,250 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+	"github.com/anindyar/kuber/src/models"
+)
+
+// FleetSummary holds the headline numbers for one cluster in fleet mode
+type FleetSummary struct {
+	Cluster     *models.Cluster
+	Nodes       int
+	ReadyNodes  int
+	Pods        int
+	RunningPods int
+	FailingPods int
+	Deployments int
+	Degraded    int // deployments that are not available
+}
+
+// FleetLoadedMsg carries fresh per-cluster summaries for the fleet view
+type FleetLoadedMsg struct {
+	Summaries []*FleetSummary
+}
+
+// fleetColumns are the columns of the fleet summary table
+func fleetColumns() []table.Column {
+	return []table.Column{
+		{Title: "", Width: 2},
+		{Title: "Context", Width: 24},
+		{Title: "Cluster", Width: 20},
+		{Title: "Version", Width: 12},
+		{Title: "Nodes", Width: 8},
+		{Title: "Pods", Width: 10},
+		{Title: "Failing", Width: 8},
+		{Title: "Deploys", Width: 10},
+		{Title: "Health", Width: 30},
+	}
+}
+
+// openFleet switches to the fleet view and starts loading it
+func (app *Application) openFleet() tea.Cmd {
+	app.currentView = ViewFleet
+	app.switchActiveComponent()
+	return app.loadFleet()
+}
+
+// fleetContexts returns the contexts to include in the fleet: the ones given
+// with --fleet, or every context in the kubeconfig
+func (app *Application) fleetContexts() ([]string, error) {
+	if len(app.config.FleetContexts) > 0 {
+		return app.config.FleetContexts, nil
+	}
+
+	contexts, err := kubernetesclient.ListContexts(app.config.KubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(contexts))
+	for _, kctx := range contexts {
+		names = append(names, kctx.Name)
+	}
+	return names, nil
+}
+
+// loadFleet connects to every fleet context and summarizes each concurrently
+func (app *Application) loadFleet() tea.Cmd {
+	if app.fleet == nil {
+		app.fleet = kubernetesclient.NewClientPool(app.config.KubeConfig)
+	}
+	pool := app.fleet
+
+	return func() tea.Msg {
+		contexts, err := app.fleetContexts()
+		if err != nil {
+			return ErrorMsg{Error: fmt.Sprintf("Failed to list kubeconfig contexts: %v", err)}
+		}
+
+		connectCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		clusters := pool.Connect(connectCtx, contexts)
+		cancel()
+
+		summaries := make([]*FleetSummary, len(clusters))
+		var wg sync.WaitGroup
+		for i, cluster := range clusters {
+			summaries[i] = &FleetSummary{Cluster: cluster.Clone()}
+
+			client, ok := pool.Get(cluster.Context)
+			if !ok {
+				continue
+			}
+
+			wg.Add(1)
+			go func(summary *FleetSummary, client *kubernetesclient.KubernetesClient) {
+				defer wg.Done()
+				ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+				defer cancel()
+				summarizeCluster(ctx, client, summary)
+			}(summaries[i], client)
+		}
+		wg.Wait()
+
+		return FleetLoadedMsg{Summaries: summaries}
+	}
+}
+
+// summarizeCluster fills in node, pod and deployment counts for one cluster
+func summarizeCluster(ctx context.Context, client *kubernetesclient.KubernetesClient, summary *FleetSummary) {
+	if nodes, err := client.GetResources(ctx, "nodes", ""); err == nil {
+		summary.Nodes = len(nodes)
+		for _, node := range nodes {
+			if ready, ok := node.Status["ready"].(string); ok && ready == "True" {
+				summary.ReadyNodes++
+			}
+		}
+		summary.Cluster.SetNodeCount(len(nodes))
+	} else {
+		summary.Cluster.SetError(err)
+		return
+	}
+
+	if pods, err := client.GetResources(ctx, "pods", ""); err == nil {
+		summary.Pods = len(pods)
+		for _, pod := range pods {
+			switch pod.ComputeStatus() {
+			case models.ResourceStatusRunning, models.ResourceStatusSucceeded:
+				summary.RunningPods++
+			case models.ResourceStatusFailed:
+				summary.FailingPods++
+			}
+		}
+	}
+
+	if deployments, err := client.GetResources(ctx, "deployments", ""); err == nil {
+		summary.Deployments = len(deployments)
+		for _, dep := range deployments {
+			if ready, ok := dep.Status["ready"].(string); ok && ready != "True" {
+				summary.Degraded++
+			}
+		}
+	}
+}
+
+// health describes a cluster in a few words for the fleet table
+func (s *FleetSummary) health() string {
+	if !s.Cluster.IsHealthy() {
+		if s.Cluster.Error != "" {
+			return s.Cluster.Error
+		}
+		return string(s.Cluster.Status)
+	}
+
+	var problems []string
+	if notReady := s.Nodes - s.ReadyNodes; notReady > 0 {
+		problems = append(problems, fmt.Sprintf("%d node(s) not ready", notReady))
+	}
+	if s.FailingPods > 0 {
+		problems = append(problems, fmt.Sprintf("%d pod(s) failing", s.FailingPods))
+	}
+	if s.Degraded > 0 {
+		problems = append(problems, fmt.Sprintf("%d deploy(s) degraded", s.Degraded))
+	}
+	if len(problems) == 0 {
+		return "Healthy"
+	}
+	return strings.Join(problems, ", ")
+}
+
+// handleFleetLoaded stores the summaries and refreshes the fleet table
+func (app *Application) handleFleetLoaded(msg FleetLoadedMsg) {
+	app.fleetSummaries = msg.Summaries
+
+	var rows []table.Row
+	for _, s := range msg.Summaries {
+		row := table.Row{
+			s.Cluster.GetStatusIcon(),
+			s.Cluster.Context,
+			s.Cluster.GetDisplayName(),
+			"-", "-", "-", "-", "-",
+			s.health(),
+		}
+		if s.Cluster.IsConnected() {
+			row[3] = s.Cluster.Version
+			row[4] = fmt.Sprintf("%d/%d", s.ReadyNodes, s.Nodes)
+			row[5] = fmt.Sprintf("%d/%d", s.RunningPods, s.Pods)
+			row[6] = fmt.Sprintf("%d", s.FailingPods)
+			row[7] = fmt.Sprintf("%d/%d", s.Deployments-s.Degraded, s.Deployments)
+		}
+		rows = append(rows, row)
+	}
+
+	app.fleetTable.SetRows(rows)
+}
+
+// selectFleetCluster drills into the highlighted cluster by switching context
+func (app *Application) selectFleetCluster() tea.Cmd {
+	selectedRow := app.fleetTable.GetSelectedRow()
+	if len(selectedRow) < 2 {
+		return nil
+	}
+
+	contextName := selectedRow[1]
+	if contextName == app.client.GetCluster().Context {
+		app.currentView = ViewOverview
+		app.switchActiveComponent()
+		return app.loadClusterMetrics()
+	}
+	return app.switchContext(contextName)
+}
+
+// renderFleetView renders the fleet totals line and the per-cluster table
+func (app *Application) renderFleetView(height int) string {
+	var content strings.Builder
+
+	var healthy, nodes, pods, failing int
+	for _, s := range app.fleetSummaries {
+		if s.Cluster.IsHealthy() && s.health() == "Healthy" {
+			healthy++
+		}
+		nodes += s.Nodes
+		pods += s.Pods
+		failing += s.FailingPods
+	}
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+	content.WriteString(headerStyle.Render(fmt.Sprintf("🌐 Fleet: %d clusters • %d healthy • %d nodes • %d pods • %d failing",
+		len(app.fleetSummaries), healthy, nodes, pods, failing)) + "\n")
+
+	app.fleetTable.SetSize(app.width, height-3)
+	content.WriteString(app.fleetTable.View() + "\n")
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8")).
+		Italic(true)
+	content.WriteString(hintStyle.Render("Press Enter to open a cluster • Press 'r' to refresh • Press Esc to go back"))
+
+	return content.String()
+}
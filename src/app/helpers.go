@@ -0,0 +1,641 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+	metricscollector "github.com/anindyar/kuber/src/libraries/metrics-collector"
+	resourcemanager "github.com/anindyar/kuber/src/libraries/resource-manager"
+	tuicomponents "github.com/anindyar/kuber/src/libraries/tui-components"
+	"github.com/anindyar/kuber/src/models"
+)
+
+// clusterMetricsWindow is how far back the dashboard looks for the latest
+// collected samples; metrics-server readings can be a minute or so old
+const clusterMetricsWindow = 3 * time.Minute
+
+// bytesPerGB converts collected memory to the dashboard's GB (GiB)
+const bytesPerGB = 1024 * 1024 * 1024
+
+// newMetricsCollector creates and starts the collector behind the dashboard
+// and the metrics view, which only read what it collected. It collects once
+// per collection interval, listing nodes and pods from the resource
+// manager's informers. History is kept on disk per context; when that store
+// cannot be opened, e.g. while another kuber has it open, metrics are kept
+// in memory.
+func newMetricsCollector(client *kubernetesclient.KubernetesClient, resourceManager *resourcemanager.ResourceManager) *metricscollector.MetricsCollector {
+	config := metricscollector.DefaultMetricsConfig()
+	// Each collection stores a few samples per running pod
+	config.MaxDataPoints = 50000
+	config.Lister = resourceManager.GetResourcesByType
+
+	collector, err := newMetricsStore(client, config)
+	if err != nil {
+		return nil
+	}
+	collector.Start()
+	return collector
+}
+
+// newMetricsStore creates a collector keeping its history on disk, or in
+// memory when the disk store cannot be opened
+func newMetricsStore(client *kubernetesclient.KubernetesClient, config *metricscollector.MetricsConfig) (*metricscollector.MetricsCollector, error) {
+	if dir, err := metricsDir(client); err == nil {
+		config.StorageDir = dir
+		if collector, err := metricscollector.NewMetricsCollector(client, config); err == nil {
+			return collector, nil
+		}
+		config.StorageDir = ""
+	}
+	return metricscollector.NewMetricsCollector(client, config)
+}
+
+// metricsDir is where the metrics history of the client's context is kept
+func metricsDir(client *kubernetesclient.KubernetesClient) (string, error) {
+	dataDir, err := metricscollector.DefaultDataDir()
+	if err != nil {
+		return "", err
+	}
+
+	name := "default"
+	if cluster := client.GetCluster(); cluster != nil && cluster.Context != "" {
+		name = cluster.Context
+	}
+	// Context names can hold slashes and colons, e.g. EKS ARNs
+	name = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, name)
+	if strings.Trim(name, ".") == "" {
+		name = "default"
+	}
+	return filepath.Join(dataDir, "metrics", name), nil
+}
+
+// loadNodeMetrics loads node capacity and usage metrics
+func (app *Application) loadNodeMetrics(ctx context.Context, metrics *ClusterMetrics) error {
+	// Get all nodes
+	nodes, err := app.resourceManager.GetResourcesByType(ctx, "", "nodes")
+	if err != nil {
+		return fmt.Errorf("failed to get nodes: %w", err)
+	}
+
+	metrics.Nodes.Total = len(nodes)
+	for _, node := range nodes {
+		if ready, _ := node.Status["ready"].(string); ready == "True" {
+			metrics.Nodes.Ready++
+		} else {
+			metrics.Nodes.NotReady++
+		}
+	}
+
+	collector := app.metricsCollector
+	if collector == nil {
+		return fmt.Errorf("metrics collector not available")
+	}
+
+	// Without metrics-server the collector still collects capacity;
+	// UsageNodes tells the dashboard usage is missing
+	usage, err := app.readClusterUsage(collector)
+	if err != nil {
+		return err
+	}
+	cluster := usage.cluster
+
+	metrics.Nodes.UsageNodes = cluster.NodesWithUsage
+	metrics.Nodes.CPU = newResourceMetric(cluster.UsedCPU, cluster.TotalCPU, "cores")
+	metrics.Nodes.Memory = newResourceMetric(cluster.UsedMemory/bytesPerGB, cluster.TotalMemory/bytesPerGB, "GB")
+
+	// Storage metrics (simplified - would need PV data in real implementation)
+	metrics.Nodes.Storage = ResourceMetric{
+		Used:       500.0,  // Mock data
+		Total:      1000.0, // Mock data
+		Available:  500.0,
+		Percentage: 50.0,
+		Unit:       "GB",
+	}
+
+	// Per-node requests, limits, usage and pressure
+	loadNodeDetails(nodes, usage.nodes, metrics)
+
+	return nil
+}
+
+// clusterUsage is what the dashboard read of a collection
+type clusterUsage struct {
+	collected time.Time
+	cluster   *metricscollector.ClusterMetrics
+	nodes     []metricscollector.NodeUsage
+}
+
+// readClusterUsage returns the cluster and per-node usage as of the latest
+// collection, reading the store again only once there is a newer one
+func (app *Application) readClusterUsage(collector *metricscollector.MetricsCollector) (*clusterUsage, error) {
+	collected, _ := collector.LastCollection()
+	if cached := app.clusterUsage; cached != nil && cached.collected.Equal(collected) {
+		return cached, nil
+	}
+
+	cluster, err := collector.GetClusterMetrics(metricscollector.NewTimeRange(clusterMetricsWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster metrics: %w", err)
+	}
+	nodes, err := collector.GetNodeUsage(metricscollector.NewTimeRange(clusterMetricsWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node usage: %w", err)
+	}
+
+	app.clusterUsage = &clusterUsage{collected: collected, cluster: cluster, nodes: nodes}
+	return app.clusterUsage, nil
+}
+
+// pressureConditions are the node conditions under which the kubelet starts
+// evicting pods
+var pressureConditions = []string{"MemoryPressure", "DiskPressure", "PIDPressure"}
+
+// loadNodeDetails fills in the per-node figures and conditions and their
+// cluster totals, listing the nodes that need attention first
+func loadNodeDetails(nodes []*models.Resource, usage []metricscollector.NodeUsage, metrics *ClusterMetrics) {
+	usageByNode := make(map[string]metricscollector.NodeUsage, len(usage))
+	for _, nodeUsage := range usage {
+		usageByNode[nodeUsage.Node] = nodeUsage
+	}
+
+	metrics.Nodes.Pressure = make(map[string]int)
+	metrics.Nodes.Details = make([]NodeDetail, 0, len(nodes))
+	for _, node := range nodes {
+		detail := NodeDetail{Name: node.Metadata.Name, Status: "NotReady"}
+		if ready, _ := node.Status["ready"].(string); ready == "True" {
+			detail.Status = "Ready"
+		}
+		for _, conditionType := range pressureConditions {
+			if condition := node.GetCondition(conditionType); condition != nil && condition.Status == "True" {
+				detail.Conditions = append(detail.Conditions, conditionType)
+				metrics.Nodes.Pressure[conditionType]++
+			}
+		}
+
+		nodeUsage := usageByNode[detail.Name]
+		detail.CPU = nodeUsage.CPU
+		detail.Memory = nodeUsage.Memory
+		metrics.Nodes.Allocation.CPU.Add(nodeUsage.CPU)
+		metrics.Nodes.Allocation.Memory.Add(nodeUsage.Memory)
+
+		metrics.Nodes.Details = append(metrics.Nodes.Details, detail)
+	}
+
+	sort.SliceStable(metrics.Nodes.Details, func(i, j int) bool {
+		a, b := metrics.Nodes.Details[i], metrics.Nodes.Details[j]
+		if (a.Status == "Ready") != (b.Status == "Ready") {
+			return a.Status != "Ready"
+		}
+		if len(a.Conditions) != len(b.Conditions) {
+			return len(a.Conditions) > len(b.Conditions)
+		}
+		return nodeLoad(a) > nodeLoad(b)
+	})
+}
+
+// nodeLoad is the highest share of a node's allocatable CPU or memory that
+// is requested or in use
+func nodeLoad(node NodeDetail) float64 {
+	return math.Max(
+		math.Max(node.CPU.RequestsPercent(), node.CPU.UsagePercent()),
+		math.Max(node.Memory.RequestsPercent(), node.Memory.UsagePercent()),
+	)
+}
+
+// newResourceMetric fills in the available amount and percentage used
+func newResourceMetric(used, total float64, unit string) ResourceMetric {
+	percentage := 0.0
+	if total > 0 {
+		percentage = (used / total) * 100
+	}
+	return ResourceMetric{
+		Used:       used,
+		Total:      total,
+		Available:  total - used,
+		Percentage: percentage,
+		Unit:       unit,
+	}
+}
+
+// loadWorkloadCounts loads counts of various workload resources
+func (app *Application) loadWorkloadCounts(ctx context.Context, metrics *ClusterMetrics) error {
+	// Count deployments across all namespaces
+	deployments, err := app.resourceManager.GetResourcesByType(ctx, "", "deployments")
+	if err == nil {
+		metrics.Workloads.Deployments = len(deployments)
+	}
+
+	// Count statefulsets
+	statefulsets, err := app.resourceManager.GetResourcesByType(ctx, "", "statefulsets")
+	if err == nil {
+		metrics.Workloads.StatefulSets = len(statefulsets)
+	}
+
+	// Count pods
+	pods, err := app.resourceManager.GetResourcesByType(ctx, "", "pods")
+	if err == nil {
+		metrics.Workloads.Pods = len(pods)
+	}
+
+	// Count services
+	services, err := app.resourceManager.GetResourcesByType(ctx, "", "services")
+	if err == nil {
+		metrics.Workloads.Services = len(services)
+	}
+
+	// Count ingresses
+	ingresses, err := app.resourceManager.GetResourcesByType(ctx, "", "ingress")
+	if err == nil {
+		metrics.Workloads.Ingresses = len(ingresses)
+	}
+
+	return nil
+}
+
+// maxDashboardNodes is how many nodes the dashboard lists
+const maxDashboardNodes = 5
+
+// formatAllocation renders requests, limits and usage as percentages of
+// allocatable, e.g. "45/120/30%"; usage is "-" without metrics-server
+func formatAllocation(usage metricscollector.ResourceUsage) string {
+	used := "-"
+	if usage.HasUsage {
+		used = fmt.Sprintf("%.0f", usage.UsagePercent())
+	}
+	return fmt.Sprintf("%.0f/%.0f/%s%%", usage.RequestsPercent(), usage.LimitsPercent(), used)
+}
+
+// formatPressure renders how many nodes are under each pressure condition,
+// e.g. "2 MemoryPressure, 1 DiskPressure"
+func formatPressure(pressure map[string]int) string {
+	var parts []string
+	for _, conditionType := range pressureConditions {
+		if count := pressure[conditionType]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count, conditionType))
+		}
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderPerformanceMetrics renders the performance monitoring section
+func (app *Application) renderPerformanceMetrics(width, height int) string {
+	var content strings.Builder
+
+	// Title
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39")). // Blue
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("39")).
+		Padding(0, 1).
+		Width(width - 2)
+
+	content.WriteString(titleStyle.Render("📊 Cluster Performance Monitor") + "\n")
+
+	if app.clusterMetrics == nil {
+		content.WriteString("Loading metrics...\n")
+		return content.String()
+	}
+
+	metrics := app.clusterMetrics
+
+	// Node status section
+	nodeStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("46")). // Green
+		Bold(true)
+
+	content.WriteString(nodeStyle.Render("🖥️  Nodes:") + "\n")
+	content.WriteString(fmt.Sprintf("  Total: %d  Ready: %d  Not Ready: %d\n",
+		metrics.Nodes.Total, metrics.Nodes.Ready, metrics.Nodes.NotReady))
+	content.WriteString("\n")
+
+	// Resource utilization with progress bars
+	content.WriteString(nodeStyle.Render("⚡ Resource Utilization:") + "\n")
+
+	if metrics.Nodes.UsageNodes == 0 {
+		// Capacity is known, usage needs metrics-server
+		content.WriteString(fmt.Sprintf("  CPU:    usage unavailable (%.1f %s total)\n", metrics.Nodes.CPU.Total, metrics.Nodes.CPU.Unit))
+		content.WriteString(fmt.Sprintf("  Memory: usage unavailable (%.1f %s total)\n", metrics.Nodes.Memory.Total, metrics.Nodes.Memory.Unit))
+		content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("  Install metrics-server to see CPU and memory usage") + "\n")
+	} else {
+		// CPU utilization
+		cpuBar := app.renderProgressBar(metrics.Nodes.CPU.Percentage, width-10)
+		content.WriteString(fmt.Sprintf("  CPU:    %s %.1f%% (%.1f/%.1f %s)\n",
+			cpuBar, metrics.Nodes.CPU.Percentage, metrics.Nodes.CPU.Used, metrics.Nodes.CPU.Total, metrics.Nodes.CPU.Unit))
+
+		// Memory utilization
+		memoryBar := app.renderProgressBar(metrics.Nodes.Memory.Percentage, width-10)
+		content.WriteString(fmt.Sprintf("  Memory: %s %.1f%% (%.1f/%.1f %s)\n",
+			memoryBar, metrics.Nodes.Memory.Percentage, metrics.Nodes.Memory.Used, metrics.Nodes.Memory.Total, metrics.Nodes.Memory.Unit))
+
+		if metrics.Nodes.UsageNodes < metrics.Nodes.Total {
+			content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(
+				fmt.Sprintf("  Usage reported by %d of %d nodes", metrics.Nodes.UsageNodes, metrics.Nodes.Total)) + "\n")
+		}
+	}
+
+	// Storage utilization
+	storageBar := app.renderProgressBar(metrics.Nodes.Storage.Percentage, width-10)
+	content.WriteString(fmt.Sprintf("  Storage:%s %.1f%% (%.1f/%.1f %s)\n",
+		storageBar, metrics.Nodes.Storage.Percentage, metrics.Nodes.Storage.Used, metrics.Nodes.Storage.Total, metrics.Nodes.Storage.Unit))
+
+	// Allocation section: what pods request and may use against what the
+	// nodes can allocate
+	content.WriteString("\n")
+	loadStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("208")). // Orange
+		Bold(true)
+	content.WriteString(loadStyle.Render("📈 Cluster Allocation:") + "\n")
+	cpu, memory := metrics.Nodes.Allocation.CPU, metrics.Nodes.Allocation.Memory
+	content.WriteString(fmt.Sprintf("  CPU:    requests %.0f%%  limits %.0f%% of %.1f cores allocatable\n",
+		cpu.RequestsPercent(), cpu.LimitsPercent(), cpu.Allocatable))
+	content.WriteString(fmt.Sprintf("  Memory: requests %.0f%%  limits %.0f%% of %.1f GB allocatable\n",
+		memory.RequestsPercent(), memory.LimitsPercent(), memory.Allocatable/bytesPerGB))
+
+	overcommitStyle := lipgloss.NewStyle()
+	if cpu.Overcommit() > 1 || memory.Overcommit() > 1 {
+		overcommitStyle = overcommitStyle.Foreground(lipgloss.Color("214"))
+	}
+	content.WriteString(overcommitStyle.Render(fmt.Sprintf("  Overcommit (limits/allocatable): CPU %.2fx  Memory %.2fx",
+		cpu.Overcommit(), memory.Overcommit())) + "\n")
+
+	pressureStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	if len(metrics.Nodes.Pressure) > 0 {
+		pressureStyle = pressureStyle.Foreground(lipgloss.Color("214"))
+	}
+	content.WriteString("  Pressure: " + pressureStyle.Render(formatPressure(metrics.Nodes.Pressure)) + "\n")
+
+	// Per-node breakdown, nodes needing attention first
+	if len(metrics.Nodes.Details) > 0 {
+		content.WriteString("\n")
+		nodeBreakdownStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("33")). // Cyan
+			Bold(true)
+		content.WriteString(nodeBreakdownStyle.Render("🖥️  Per-Node Status:") + "\n")
+		content.WriteString(fmt.Sprintf("     %-22s %-16s %-16s\n", "NODE", "CPU req/lim/use", "MEM req/lim/use"))
+
+		details := metrics.Nodes.Details
+		if len(details) > maxDashboardNodes {
+			details = details[:maxDashboardNodes]
+		}
+		for _, nodeDetail := range details {
+			statusIcon := "🟢"
+			if nodeDetail.Status != "Ready" {
+				statusIcon = "🔴"
+			} else if len(nodeDetail.Conditions) > 0 || nodeLoad(nodeDetail) > 90 {
+				statusIcon = "🟡"
+			}
+
+			// Truncate long node names for display
+			displayName := nodeDetail.Name
+			if len(displayName) > 22 {
+				displayName = displayName[:19] + "..."
+			}
+
+			line := fmt.Sprintf("  %s %-22s %-16s %-16s", statusIcon, displayName,
+				formatAllocation(nodeDetail.CPU), formatAllocation(nodeDetail.Memory))
+			if nodeDetail.Status != "Ready" {
+				line += " NotReady"
+			}
+			if len(nodeDetail.Conditions) > 0 {
+				line += " " + strings.Join(nodeDetail.Conditions, ",")
+			}
+			content.WriteString(line + "\n")
+		}
+		if more := len(metrics.Nodes.Details) - len(details); more > 0 {
+			content.WriteString(fmt.Sprintf("  ... and %d more nodes\n", more))
+		}
+	}
+
+	content.WriteString("\n")
+
+	// Last updated
+	updatedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8")).
+		Italic(true)
+	content.WriteString(updatedStyle.Render(fmt.Sprintf("Last updated: %s",
+		metrics.LastUpdated.Format("15:04:05"))) + "\n")
+
+	return content.String()
+}
+
+// renderResourceMetrics renders the resource counts section
+func (app *Application) renderResourceMetrics(width, height int) string {
+	var content strings.Builder
+
+	if app.clusterMetrics == nil {
+		return "Loading resource metrics..."
+	}
+
+	workloads := app.clusterMetrics.Workloads
+
+	// Title
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("226")). // Yellow
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("226")).
+		Padding(0, 1).
+		Width(width - 2)
+
+	content.WriteString(titleStyle.Render("🚀 Workload Resources") + "\n")
+
+	// Resource counts in a grid layout
+	iconStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("46")) // Green
+
+	content.WriteString("\n")
+	content.WriteString(fmt.Sprintf("  %s Deployments:   %d\n", iconStyle.Render("🚀"), workloads.Deployments))
+	content.WriteString(fmt.Sprintf("  %s StatefulSets:  %d\n", iconStyle.Render("📊"), workloads.StatefulSets))
+	content.WriteString(fmt.Sprintf("  %s Pods:          %d\n", iconStyle.Render("🐳"), workloads.Pods))
+	content.WriteString(fmt.Sprintf("  %s Services:      %d\n", iconStyle.Render("🌐"), workloads.Services))
+	content.WriteString(fmt.Sprintf("  %s Ingresses:     %d\n", iconStyle.Render("🌍"), workloads.Ingresses))
+
+	problems := app.clusterMetrics.Problems
+	problemStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	if problems.Critical > 0 {
+		problemStyle = problemStyle.Foreground(lipgloss.Color("196"))
+	} else if problems.Warning > 0 {
+		problemStyle = problemStyle.Foreground(lipgloss.Color("214"))
+	}
+	content.WriteString(fmt.Sprintf("\n  🩺 Problems:      %s  (press '!')\n", problemStyle.Render(formatIssueCounts(problems))))
+
+	return content.String()
+}
+
+// renderProgressBar creates a visual progress bar
+func (app *Application) renderProgressBar(percentage float64, width int) string {
+	if width < 10 {
+		width = 10
+	}
+
+	barWidth := width - 8 // Reserve space for brackets and percentage
+	if barWidth < 1 {
+		barWidth = 1
+	}
+
+	filledWidth := int((percentage / 100.0) * float64(barWidth))
+	emptyWidth := barWidth - filledWidth
+
+	// Choose color based on percentage
+	var barColor string
+	if percentage < 50 {
+		barColor = "46" // Green
+	} else if percentage < 80 {
+		barColor = "226" // Yellow
+	} else {
+		barColor = "196" // Red
+	}
+
+	filledStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(barColor))
+	emptyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+
+	filled := filledStyle.Render(strings.Repeat("█", filledWidth))
+	empty := emptyStyle.Render(strings.Repeat("░", emptyWidth))
+
+	return fmt.Sprintf("[%s%s]", filled, empty)
+}
+
+// loadNamespaces loads and displays namespaces
+func (app *Application) loadNamespaces() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		namespaces, err := app.resourceManager.GetNamespaces(ctx)
+		if err != nil {
+			return ErrorMsg{Error: fmt.Sprintf("Failed to load namespaces: %v", err)}
+		}
+
+		// Convert to list items
+		var items []list.Item
+		for _, ns := range namespaces {
+			resourceCount := 0 // TODO: Get actual resource count
+			age := formatAgeFromTime(ns.CreationTime)
+			item := tuicomponents.NewNamespaceListItem(
+				ns.Name,
+				string(ns.Status),
+				age,
+				resourceCount,
+			)
+			items = append(items, item)
+		}
+
+		app.namespaceList.SetItems(items)
+		app.updateStatusBar("namespaces", len(namespaces))
+
+		return RefreshMsg{}
+	}
+}
+
+// loadClusterLogsView loads the cluster logs view
+func (app *Application) loadClusterLogsView() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var logContent strings.Builder
+		logContent.WriteString("=== Cluster Logs (Read-Only) ===\n\n")
+
+		// System namespaces to get logs from
+		namespaces := []string{"kube-system", "default"}
+
+		for _, namespace := range namespaces {
+			logContent.WriteString(fmt.Sprintf("--- Namespace: %s ---\n", namespace))
+
+			// Get pods in this namespace
+			pods, err := app.resourceManager.GetResourcesByType(ctx, namespace, "pods")
+			if err != nil {
+				logContent.WriteString(fmt.Sprintf("Error getting pods: %v\n\n", err))
+				continue
+			}
+
+			// Get logs from first 2 pods (reduced for kTop)
+			maxPods := min(2, len(pods))
+			for i := 0; i < maxPods; i++ {
+				pod := pods[i]
+				podName := pod.Metadata.Name
+
+				logContent.WriteString(fmt.Sprintf("Pod: %s\n", podName))
+
+				// Get recent logs
+				cmd := exec.CommandContext(ctx, "kubectl", app.client.KubectlArgs("logs", "--tail=5", podName, "-n", namespace)...)
+				output, err := cmd.Output()
+				if err != nil {
+					logContent.WriteString(fmt.Sprintf("  Error: %v\n", err))
+				} else if len(output) == 0 {
+					logContent.WriteString("  (no logs)\n")
+				} else {
+					lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+					for _, line := range lines {
+						if line != "" {
+							logContent.WriteString(fmt.Sprintf("  %s\n", line))
+						}
+					}
+				}
+				logContent.WriteString("\n")
+			}
+			logContent.WriteString("\n")
+		}
+
+		// Add instructions
+		logContent.WriteString("=== Instructions ===\n")
+		logContent.WriteString("Press 'r' to refresh logs\n")
+		logContent.WriteString("Press 'Esc' to go back to overview\n")
+		logContent.WriteString("Press 'q' to quit\n")
+
+		app.detailViewport.SetContent(logContent.String())
+		app.detailViewport.SetTitle("📜 Cluster Logs (Read-Only)")
+
+		return RefreshMsg{}
+	}
+}
+
+// formatAgeFromTime formats a time as a human-readable age
+func formatAgeFromTime(t time.Time) string {
+	age := time.Since(t)
+	
+	if age < time.Minute {
+		return fmt.Sprintf("%ds", int(age.Seconds()))
+	} else if age < time.Hour {
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	} else if age < 24*time.Hour {
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	} else {
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	}
+}
+
+// updateStatusBar updates the status bar with current context
+func (app *Application) updateStatusBar(resourceType string, count int) {
+	app.statusBar.UpdateItem("resource", resourceType)
+	app.statusBar.SetResourceCount(count)
+	app.statusBar.SetConnectionStatus("connected")
+	app.updateClusterInfo()
+}
+
+// min returns minimum of two ints
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
\ No newline at end of file
@@ -0,0 +1,206 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+
+	resourcemanager "github.com/anindyar/kuber/src/libraries/resource-manager"
+	"github.com/anindyar/kuber/src/models"
+)
+
+// highlightDuration is how long added, modified and deleted rows stay marked
+const highlightDuration = 3 * time.Second
+
+// watchHealthInterval is how often the live-update indicator is refreshed
+const watchHealthInterval = 5 * time.Second
+
+// ResourceEventMsg delivers a watch event for the resource table
+type ResourceEventMsg struct {
+	Namespace    string
+	ResourceType string
+	EventType    string
+	Resource     *models.Resource
+}
+
+// highlightExpiredMsg asks the resource table to drop stale highlights
+type highlightExpiredMsg struct{}
+
+// WatchHealthMsg reports whether live updates are currently degraded
+type WatchHealthMsg struct {
+	Error error
+}
+
+// rowHighlight marks a table row that recently changed
+type rowHighlight struct {
+	eventType string
+	expires   time.Time
+}
+
+// watchTableResources replaces the current table watch with one for the
+// namespace and type now on screen
+func (app *Application) watchTableResources(namespace, resourceType string) {
+	if app.watchedNamespace == namespace && app.watchedResourceType == resourceType {
+		return
+	}
+	app.stopTableWatch()
+
+	err := app.resourceManager.WatchResources(context.Background(), namespace, resourceType, func(resource *models.Resource, eventType string) {
+		if app.program == nil {
+			return
+		}
+		app.program.Send(ResourceEventMsg{
+			Namespace:    namespace,
+			ResourceType: resourceType,
+			EventType:    eventType,
+			Resource:     resource,
+		})
+	})
+	if err != nil {
+		// Types without a watch still work, they just refresh on 'r'
+		return
+	}
+
+	app.watchedNamespace = namespace
+	app.watchedResourceType = resourceType
+	app.statusBar.SetLiveStatus("📡 live", false)
+}
+
+// stopTableWatch stops the resource table watch, if any
+func (app *Application) stopTableWatch() {
+	if app.watchedResourceType == "" {
+		return
+	}
+	app.resourceManager.StopWatch(app.watchedNamespace, app.watchedResourceType)
+	app.watchedNamespace = ""
+	app.watchedResourceType = ""
+	app.rowHighlights = make(map[string]rowHighlight)
+	app.statusBar.SetLiveStatus("", false)
+}
+
+// handleResourceEvent patches the resource table with a watch event
+func (app *Application) handleResourceEvent(msg ResourceEventMsg) tea.Cmd {
+	if msg.Namespace != app.watchedNamespace || msg.ResourceType != app.watchedResourceType {
+		return nil // Stale event from a watch that has since been replaced
+	}
+
+	key := msg.Resource.GetIdentifier()
+	highlight := msg.EventType
+
+	// A new watch replays existing objects as ADDED; only show real changes
+	if existing := app.findTableResource(key); existing != nil && msg.EventType != "DELETED" {
+		if existing.Metadata.ResourceVersion == msg.Resource.Metadata.ResourceVersion {
+			return nil
+		}
+		if highlight == "ADDED" {
+			highlight = "MODIFIED"
+		}
+	}
+
+	if previous, ok := app.rowHighlights[key]; ok && previous.eventType == "ADDED" && highlight == "MODIFIED" {
+		highlight = "ADDED" // Still new; keep showing it as added
+	}
+	app.rowHighlights[key] = rowHighlight{eventType: highlight, expires: time.Now().Add(highlightDuration)}
+
+	// Deleted rows stay in the table until their highlight expires
+	if msg.EventType != "DELETED" {
+		app.tableResources = resourcemanager.PatchResourceList(app.tableResources, msg.Resource, msg.EventType)
+	}
+
+	app.refreshResourceRows()
+	app.updateStatusBar(msg.ResourceType+" in "+msg.Namespace, len(app.tableResources))
+
+	return tea.Tick(highlightDuration, func(time.Time) tea.Msg {
+		return highlightExpiredMsg{}
+	})
+}
+
+// checkWatchHealth polls the watch health for the live-update indicator
+func (app *Application) checkWatchHealth() tea.Cmd {
+	resourceManager := app.resourceManager
+	return tea.Tick(watchHealthInterval, func(time.Time) tea.Msg {
+		return WatchHealthMsg{Error: resourceManager.WatchHealth()}
+	})
+}
+
+// handleWatchHealth updates the live-update indicator and schedules the next check
+func (app *Application) handleWatchHealth(msg WatchHealthMsg) tea.Cmd {
+	switch {
+	case app.watchedResourceType == "":
+		app.statusBar.SetLiveStatus("", false)
+	case msg.Error != nil:
+		app.statusBar.SetLiveStatus("⚠ live updates degraded: "+msg.Error.Error(), true)
+	default:
+		app.statusBar.SetLiveStatus("📡 live", false)
+	}
+	return app.checkWatchHealth()
+}
+
+// findTableResource returns the table resource with the given identifier
+func (app *Application) findTableResource(key string) *models.Resource {
+	for _, resource := range app.tableResources {
+		if resource.GetIdentifier() == key {
+			return resource
+		}
+	}
+	return nil
+}
+
+// expireHighlights drops stale highlights and removes deleted rows
+func (app *Application) expireHighlights() {
+	now := time.Now()
+	changed := false
+	for key, highlight := range app.rowHighlights {
+		if now.Before(highlight.expires) {
+			continue
+		}
+		delete(app.rowHighlights, key)
+		changed = true
+
+		if highlight.eventType == "DELETED" {
+			var remaining []*models.Resource
+			for _, resource := range app.tableResources {
+				if resource.GetIdentifier() != key {
+					remaining = append(remaining, resource)
+				}
+			}
+			app.tableResources = remaining
+		}
+	}
+
+	if changed {
+		app.refreshResourceRows()
+	}
+}
+
+// refreshResourceRows rebuilds the resource table rows, marking recent changes
+func (app *Application) refreshResourceRows() {
+	var rows []table.Row
+	for _, resource := range app.tableResources {
+		status := "Unknown"
+		if s, ok := resource.Status["phase"].(string); ok {
+			status = s
+		}
+
+		if highlight, ok := app.rowHighlights[resource.GetIdentifier()]; ok {
+			switch highlight.eventType {
+			case "ADDED":
+				status = "✚ " + status
+			case "MODIFIED":
+				status = "● " + status
+			case "DELETED":
+				status = "✖ Deleted"
+			}
+		}
+
+		rows = append(rows, table.Row{
+			resource.Metadata.Name,
+			status,
+			formatAgeFromTime(resource.Metadata.CreationTimestamp),
+		})
+	}
+
+	app.resourceTable.SetRows(rows)
+}
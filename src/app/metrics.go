@@ -0,0 +1,418 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	metricscollector "github.com/anindyar/kuber/src/libraries/metrics-collector"
+	tuicomponents "github.com/anindyar/kuber/src/libraries/tui-components"
+	"github.com/anindyar/kuber/src/models"
+)
+
+// metricsViewInterval is how often the metrics view reloads: the
+// collector's default collection interval
+const metricsViewInterval = 30 * time.Second
+
+// metricsSparklineWidth is the width of the history columns
+const metricsSparklineWidth = 24
+
+// metricsRange is a time range the metrics view can plot
+type metricsRange struct {
+	label      string
+	duration   time.Duration
+	resolution time.Duration // rollup asked of the store; 0 for raw samples
+}
+
+// metricsRanges are the time ranges the metrics view cycles through
+var metricsRanges = []metricsRange{
+	{"15m", 15 * time.Minute, 0},
+	{"1h", time.Hour, 0},
+	{"6h", 6 * time.Hour, time.Minute},
+	{"24h", 24 * time.Hour, time.Minute},
+	{"7d", 7 * 24 * time.Hour, 10 * time.Minute},
+	{"30d", 30 * 24 * time.Hour, time.Hour},
+}
+
+// metricsResource is the CPU and memory history of a node or pod
+type metricsResource struct {
+	name   string // node name, or namespace/name for pods
+	series map[models.MetricType]*models.MetricSeries
+}
+
+// usage returns the latest usage of a metric type, and whether there is one
+func (r metricsResource) usage(metricType models.MetricType) (float64, bool) {
+	series, ok := r.series[metricType]
+	if !ok {
+		return 0, false
+	}
+	latest, err := series.GetLatestValue()
+	if err != nil {
+		return 0, false
+	}
+	return latest.Value, true
+}
+
+// values returns the values of a metric type, oldest first
+func (r metricsResource) values(metricType models.MetricType) []float64 {
+	series, ok := r.series[metricType]
+	if !ok {
+		return nil
+	}
+	values := make([]float64, len(series.DataPoints))
+	for i, point := range series.DataPoints {
+		values[i] = point.Value
+	}
+	return values
+}
+
+// metricsState is the state of the metrics view
+type metricsState struct {
+	pods        bool   // plotting pods rather than nodes
+	namespace   string // pods of this namespace; empty for all
+	rangeIndex  int
+	resources   []metricsResource
+	loaded      bool
+	err         error
+	collectErr  error // the latest collection failed, e.g. without metrics-server
+	seq         int   // ignores ticks and results of an earlier metrics view
+	cpuChart    *tuicomponents.ChartComponent
+	memoryChart *tuicomponents.ChartComponent
+	returnView  ViewType
+}
+
+// MetricsHistoryMsg carries the history plotted by the metrics view
+type MetricsHistoryMsg struct {
+	Seq          int
+	Series       []*models.MetricSeries
+	CollectError error
+	Error        error
+}
+
+// metricsTickMsg asks the metrics view to reload
+type metricsTickMsg struct{ Seq int }
+
+// metricsColumns are the columns of the metrics view
+func metricsColumns(pods bool) []table.Column {
+	name := table.Column{Title: "Node", Width: 30}
+	if pods {
+		name = table.Column{Title: "Pod", Width: 45}
+	}
+	return []table.Column{
+		name,
+		{Title: "CPU", Width: 9},
+		{Title: "CPU History", Width: metricsSparklineWidth},
+		{Title: "Memory", Width: 9},
+		{Title: "Memory History", Width: metricsSparklineWidth},
+	}
+}
+
+// metricsRow is a resource's row in the metrics view
+func metricsRow(resource metricsResource) table.Row {
+	cpu, memory := "-", "-"
+	if value, ok := resource.usage(models.MetricTypeCPUUsage); ok {
+		cpu = formatCores(value)
+	}
+	if value, ok := resource.usage(models.MetricTypeMemoryUsage); ok {
+		memory = formatMebibytes(value)
+	}
+	return table.Row{
+		resource.name,
+		cpu,
+		tuicomponents.Sparkline(resource.values(models.MetricTypeCPUUsage), metricsSparklineWidth),
+		memory,
+		tuicomponents.Sparkline(resource.values(models.MetricTypeMemoryUsage), metricsSparklineWidth),
+	}
+}
+
+// openMetrics switches to the metrics view: nodes, or the pods of a
+// namespace when it is set
+func (app *Application) openMetrics(namespace string) tea.Cmd {
+	seq, returnView, rangeIndex := 0, app.currentView, 1
+	if app.metrics != nil {
+		seq, rangeIndex = app.metrics.seq+1, app.metrics.rangeIndex
+	}
+
+	app.metrics = &metricsState{
+		pods:        namespace != "",
+		namespace:   namespace,
+		rangeIndex:  rangeIndex,
+		seq:         seq,
+		cpuChart:    tuicomponents.NewChartComponent(80, 12),
+		memoryChart: tuicomponents.NewChartComponent(80, 12),
+		returnView:  returnView,
+	}
+	app.metrics.cpuChart.SetValueFormatter(formatCores)
+	app.metrics.memoryChart.SetValueFormatter(formatMebibytes)
+
+	app.metricsTable.SetRows(nil)
+	app.metricsTable.SetColumns(metricsColumns(app.metrics.pods))
+	app.currentView = ViewMetrics
+	app.switchActiveComponent()
+	return tea.Batch(app.loadMetricsHistory(), app.tickMetrics())
+}
+
+// toggleMetricsPods switches the metrics view between nodes and pods
+func (app *Application) toggleMetricsPods() tea.Cmd {
+	app.metrics.pods = !app.metrics.pods
+	app.metrics.resources = nil
+	app.metricsTable.SetRows(nil)
+	app.metricsTable.SetColumns(metricsColumns(app.metrics.pods))
+	return app.reloadMetrics()
+}
+
+// cycleMetricsRange plots the next time range
+func (app *Application) cycleMetricsRange() tea.Cmd {
+	app.metrics.rangeIndex = (app.metrics.rangeIndex + 1) % len(metricsRanges)
+	return app.reloadMetrics()
+}
+
+// reloadMetrics loads the history again after the query changed, ignoring
+// results and ticks of the old query
+func (app *Application) reloadMetrics() tea.Cmd {
+	app.metrics.seq++
+	app.metrics.loaded = false
+	return tea.Batch(app.loadMetricsHistory(), app.tickMetrics())
+}
+
+// metricsFilter is the query for the metrics view's resources and range
+func (app *Application) metricsFilter() *metricscollector.MetricsFilter {
+	timeRange := metricsRanges[app.metrics.rangeIndex]
+	filter := &metricscollector.MetricsFilter{
+		ResourceType: "Node",
+		MetricTypes: []string{
+			string(models.MetricTypeCPUUsage), string(models.MetricTypeCPUAllocatable),
+			string(models.MetricTypeMemoryUsage), string(models.MetricTypeMemoryAllocatable),
+		},
+		TimeRange:  metricscollector.NewTimeRange(timeRange.duration),
+		Resolution: timeRange.resolution,
+	}
+	if app.metrics.pods {
+		filter.ResourceType = "Pod"
+		filter.Namespace = app.metrics.namespace
+		filter.MetricTypes = []string{
+			string(models.MetricTypeCPUUsage), string(models.MetricTypeCPURequest), string(models.MetricTypeCPULimit),
+			string(models.MetricTypeMemoryUsage), string(models.MetricTypeMemoryRequest), string(models.MetricTypeMemoryLimit),
+		}
+	}
+	return filter
+}
+
+// loadMetricsHistory loads the history to plot. The collector runs in the
+// background, so the history runs up to its latest collection.
+func (app *Application) loadMetricsHistory() tea.Cmd {
+	collector, filter, seq := app.metricsCollector, app.metricsFilter(), app.metrics.seq
+	return func() tea.Msg {
+		if collector == nil {
+			return MetricsHistoryMsg{Seq: seq, Error: fmt.Errorf("metrics collection is unavailable")}
+		}
+
+		// A failed collection still leaves the history and what it did collect
+		_, collectErr := collector.LastCollection()
+		metrics, err := collector.GetMetrics(filter)
+		if err != nil {
+			return MetricsHistoryMsg{Seq: seq, Error: fmt.Errorf("failed to load metrics history: %w", err)}
+		}
+		return MetricsHistoryMsg{Seq: seq, Series: groupMetricSeries(metrics), CollectError: collectErr}
+	}
+}
+
+// groupMetricSeries splits metrics into one series per resource and type
+func groupMetricSeries(metrics []*models.MetricDataPoint) []*models.MetricSeries {
+	var series []*models.MetricSeries
+	byKey := make(map[string]*models.MetricSeries)
+	for _, metric := range metrics {
+		key := string(metric.MetricType) + ":" + metric.ResourceID
+		s, ok := byKey[key]
+		if !ok {
+			s = models.NewMetricSeries(metric.MetricType, metric.ResourceID, metric.Unit)
+			for name, value := range metric.Labels {
+				s.Labels[name] = value
+			}
+			byKey[key] = s
+			series = append(series, s)
+		}
+		s.AddDataPoint(metric)
+	}
+	return series
+}
+
+// tickMetrics schedules the next reload of the metrics view
+func (app *Application) tickMetrics() tea.Cmd {
+	seq := app.metrics.seq
+	return tea.Tick(metricsViewInterval, func(time.Time) tea.Msg {
+		return metricsTickMsg{Seq: seq}
+	})
+}
+
+// handleMetricsTick reloads while the metrics view is open
+func (app *Application) handleMetricsTick(msg metricsTickMsg) tea.Cmd {
+	if app.currentView != ViewMetrics || app.metrics == nil || app.metrics.seq != msg.Seq {
+		return nil
+	}
+	return tea.Batch(app.loadMetricsHistory(), app.tickMetrics())
+}
+
+// handleMetricsHistory shows freshly loaded history; a failed reload keeps
+// the last one. Nodes sort by name, pods by CPU usage, heaviest first.
+func (app *Application) handleMetricsHistory(msg MetricsHistoryMsg) {
+	if app.metrics == nil || app.metrics.seq != msg.Seq {
+		return
+	}
+
+	app.metrics.loaded = true
+	app.metrics.err = msg.Error
+	app.metrics.collectErr = msg.CollectError
+	if msg.Error != nil {
+		return
+	}
+
+	var resources []metricsResource
+	byID := make(map[string]int)
+	for _, series := range msg.Series {
+		index, ok := byID[series.ResourceID]
+		if !ok {
+			first := series.DataPoints[0]
+			name := first.GetResourceName()
+			if app.metrics.pods {
+				name = first.GetResourceNamespace() + "/" + name
+			}
+			index = len(resources)
+			byID[series.ResourceID] = index
+			resources = append(resources, metricsResource{name: name, series: make(map[models.MetricType]*models.MetricSeries)})
+		}
+		resources[index].series[series.MetricType] = series
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		if app.metrics.pods {
+			a, _ := resources[i].usage(models.MetricTypeCPUUsage)
+			b, _ := resources[j].usage(models.MetricTypeCPUUsage)
+			if a != b {
+				return a > b
+			}
+		}
+		return resources[i].name < resources[j].name
+	})
+	app.metrics.resources = resources
+
+	rows := make([]table.Row, len(resources))
+	for i, resource := range resources {
+		rows[i] = metricsRow(resource)
+	}
+	app.metricsTable.SetRows(rows)
+}
+
+// selectedMetricsResource returns the resource under the cursor, or nil
+func (app *Application) selectedMetricsResource() *metricsResource {
+	cursor := app.metricsTable.GetSelectedIndex()
+	if cursor < 0 || cursor >= len(app.metrics.resources) {
+		return nil
+	}
+	return &app.metrics.resources[cursor]
+}
+
+// metricsChartSeries returns the lines of a chart: usage, then the
+// request and limit of a pod or the allocatable of a node. The usage
+// legend carries its average and peak.
+func metricsChartSeries(resource *metricsResource, usage, request, limit, allocatable models.MetricType, format func(float64) string) []tuicomponents.ChartSeries {
+	lines := []struct {
+		name       string
+		metricType models.MetricType
+		color      lipgloss.Color
+	}{
+		{"usage", usage, "39"},
+		{"request", request, "214"},
+		{"limit", limit, "196"},
+		{"allocatable", allocatable, "244"},
+	}
+
+	var chartSeries []tuicomponents.ChartSeries
+	for _, line := range lines {
+		series, ok := resource.series[line.metricType]
+		if !ok {
+			continue
+		}
+		name := line.name
+		if line.metricType == usage {
+			name = fmt.Sprintf("usage (avg %s, max %s)", format(series.GetAverage()), format(series.GetMax()))
+		}
+		points := make([]tuicomponents.ChartPoint, len(series.DataPoints))
+		for i, point := range series.DataPoints {
+			points[i] = tuicomponents.ChartPoint{Time: point.Timestamp, Value: point.Value}
+		}
+		chartSeries = append(chartSeries, tuicomponents.ChartSeries{Name: name, Points: points, Color: line.color})
+	}
+	return chartSeries
+}
+
+// renderMetricsView renders the metrics view: a table of resources with
+// sparklines, and CPU and memory charts of the selected one
+func (app *Application) renderMetricsView(height int) string {
+	var content strings.Builder
+	metrics := app.metrics
+	timeRange := metricsRanges[metrics.rangeIndex]
+
+	scope := "nodes"
+	if metrics.pods {
+		scope = "pods in all namespaces"
+		if metrics.namespace != "" {
+			scope = "pods in " + metrics.namespace
+		}
+	}
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+	content.WriteString(headerStyle.Render(fmt.Sprintf("📉 Metrics history of %s, last %s", scope, timeRange.label)) + "\n")
+
+	// The charts take what the table leaves, but no less than 10 lines
+	chartHeight := max(10, (height-3)/2)
+	app.metricsTable.SetSize(app.width, height-chartHeight-3)
+	content.WriteString(app.metricsTable.View() + "\n")
+
+	statusStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214"))
+	selected := app.selectedMetricsResource()
+	switch {
+	case metrics.err != nil:
+		content.WriteString(statusStyle.Foreground(lipgloss.Color("196")).Render(fmt.Sprintf("❌ %v", metrics.err)) + "\n")
+	case !metrics.loaded:
+		content.WriteString(statusStyle.Render("Loading metrics history...") + "\n")
+	case selected == nil:
+		content.WriteString(statusStyle.Render("No history yet: samples are collected every "+metricsViewInterval.String()) + "\n")
+	default:
+		start, end := time.Now().Add(-timeRange.duration), time.Now()
+		chartWidth := (app.width - 1) / 2
+
+		metrics.cpuChart.SetTitle("CPU of " + selected.name)
+		metrics.cpuChart.SetTimeRange(start, end)
+		metrics.cpuChart.SetSize(chartWidth, chartHeight)
+		metrics.cpuChart.SetSeries(metricsChartSeries(selected, models.MetricTypeCPUUsage, models.MetricTypeCPURequest, models.MetricTypeCPULimit, models.MetricTypeCPUAllocatable, formatCores))
+
+		metrics.memoryChart.SetTitle("Memory of " + selected.name)
+		metrics.memoryChart.SetTimeRange(start, end)
+		metrics.memoryChart.SetSize(chartWidth, chartHeight)
+		metrics.memoryChart.SetSeries(metricsChartSeries(selected, models.MetricTypeMemoryUsage, models.MetricTypeMemoryRequest, models.MetricTypeMemoryLimit, models.MetricTypeMemoryAllocatable, formatMebibytes))
+
+		content.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, metrics.cpuChart.View(), " ", metrics.memoryChart.View()) + "\n")
+		if metrics.collectErr != nil {
+			content.WriteString(statusStyle.Render("⚠️  Usage is not being collected (is metrics-server installed?)") + "\n")
+		}
+	}
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8")).
+		Italic(true)
+	toggle := "p: Pods"
+	if metrics.pods {
+		toggle = "p: Nodes"
+	}
+	content.WriteString(hintStyle.Render(fmt.Sprintf("t: Time range (%s) | %s | r: Refresh | Esc: Back", timeRange.label, toggle)))
+
+	return content.String()
+}
@@ -0,0 +1,238 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	resourcemanager "github.com/anindyar/kuber/src/libraries/resource-manager"
+	tuicomponents "github.com/anindyar/kuber/src/libraries/tui-components"
+)
+
+// updateComponentSizes updates all component sizes based on current window size
+func (app *Application) updateComponentSizes() {
+	if app.statusBar != nil {
+		app.statusBar.SetSize(app.width, 1)
+	}
+	if app.breadcrumb != nil {
+		app.breadcrumb.SetSize(app.width, 1)
+	}
+	if app.detailViewport != nil {
+		app.detailViewport.SetSize(app.width, app.height-5)
+	}
+	if app.namespaceList != nil {
+		app.namespaceList.SetSize(app.width, app.height-5)
+	}
+	if app.contextList != nil {
+		app.contextList.SetSize(app.width, app.height-5)
+	}
+}
+
+// switchActiveComponent sets the appropriate component as active
+func (app *Application) switchActiveComponent() {
+	if app.activeComponent != nil {
+		app.activeComponent.Blur()
+	}
+
+	switch app.currentView {
+	case ViewOverview:
+		// Overview doesn't have a specific component
+		app.activeComponent = nil
+
+	case ViewNamespaces:
+		app.activeComponent = app.namespaceList
+		if app.namespaceList != nil {
+			app.namespaceList.Focus()
+		}
+
+	case ViewContexts:
+		app.activeComponent = app.contextList
+		if app.contextList != nil {
+			app.contextList.Focus()
+		}
+
+	case ViewFleet:
+		app.activeComponent = app.fleetTable
+		if app.fleetTable != nil {
+			app.fleetTable.Focus()
+		}
+
+	case ViewFiles:
+		app.activeComponent = app.fileTable
+		if app.fileTable != nil {
+			app.fileTable.Focus()
+		}
+
+	case ViewEvents:
+		app.activeComponent = app.eventTable
+		if app.eventTable != nil {
+			app.eventTable.Focus()
+		}
+
+	case ViewProblems:
+		app.activeComponent = app.problemTable
+		if app.problemTable != nil {
+			app.problemTable.Focus()
+		}
+
+	case ViewTop:
+		app.activeComponent = app.topTable
+		if app.topTable != nil {
+			app.topTable.Focus()
+		}
+
+	case ViewMetrics:
+		app.activeComponent = app.metricsTable
+		if app.metricsTable != nil {
+			app.metricsTable.Focus()
+		}
+
+	case ViewSearch:
+		app.activeComponent = app.searchTable
+		if app.searchTable != nil {
+			app.searchTable.Focus()
+		}
+
+	case ViewPortForwards:
+		app.activeComponent = app.portForwardTable
+		if app.portForwardTable != nil {
+			app.portForwardTable.Focus()
+		}
+
+	case ViewResources:
+		// Toggle between resource tabs and resource table
+		if app.activeComponent == app.resourceTabs {
+			app.activeComponent = app.resourceTable
+			if app.resourceTable != nil {
+				app.resourceTable.Focus()
+				app.resourceTabs.Blur()
+			}
+		} else {
+			app.activeComponent = app.resourceTabs
+			if app.resourceTabs != nil {
+				app.resourceTabs.Focus()
+				app.resourceTable.Blur()
+			}
+		}
+
+	case ViewDetails, ViewLogs, ViewClusterLogs, ViewOwners:
+		app.activeComponent = app.detailViewport
+		if app.detailViewport != nil {
+			app.detailViewport.Focus()
+		}
+	}
+}
+
+// selectNamespace handles namespace selection
+func (app *Application) selectNamespace() tea.Cmd {
+	if app.namespaceList == nil {
+		return nil
+	}
+	
+	selectedItem := app.namespaceList.GetSelectedItem()
+	if selectedItem == nil {
+		return nil
+	}
+	
+	// Extract namespace name from the list item
+	if listItem, ok := selectedItem.(tuicomponents.ListItem); ok {
+		namespaceName := listItem.Title()
+		app.selectedNamespace = namespaceName
+		
+		// Navigate to resource view with tabs active first
+		app.currentView = ViewResources
+		app.currentResourceType = "pods"
+		// Set resource tabs as active initially
+		app.activeComponent = app.resourceTabs
+		if app.resourceTabs != nil {
+			app.resourceTabs.Focus()
+		}
+		if app.resourceTable != nil {
+			app.resourceTable.Blur()
+		}
+		
+		return app.loadNamespaceResources(namespaceName)
+	}
+	
+	return nil
+}
+
+// jumpToResource opens the details of any object, switching to its
+// namespace and type so Esc lands on its resource table
+func (app *Application) jumpToResource(kind, namespace, name string) tea.Cmd {
+	resourceType := resourcemanager.ResourceTypeForKind(kind)
+
+	app.stopEventWatch()
+	if namespace != "" {
+		app.selectedNamespace = namespace
+	}
+	app.currentResourceType = resourceType
+	app.currentView = ViewDetails
+	app.switchActiveComponent()
+
+	cmds := []tea.Cmd{app.loadResourceDetails(namespace, resourceType, name)}
+	if app.selectedNamespace != "" {
+		cmds = append(cmds, app.loadNamespaceResources(app.selectedNamespace))
+	}
+	return tea.Batch(cmds...)
+}
+
+// navigateBack handles back navigation
+func (app *Application) navigateBack() tea.Cmd {
+	switch app.currentView {
+	case ViewDetails, ViewLogs:
+		app.stopLogFollow()
+		app.currentView = ViewResources
+	case ViewResources:
+		app.stopTableWatch()
+		app.currentView = ViewNamespaces
+		app.selectedNamespace = ""
+	case ViewClusterLogs:
+		app.currentView = ViewOverview
+	case ViewNamespaces, ViewContexts, ViewFleet:
+		app.currentView = ViewOverview
+	case ViewFiles:
+		app.currentView = ViewDetails
+	case ViewOwners:
+		app.currentView = app.ownersReturnView
+		if app.currentView == ViewResources {
+			app.activeComponent = app.resourceTabs
+		}
+	case ViewEvents:
+		app.stopEventWatch()
+		app.currentView = app.eventStream.returnView
+		if app.currentView == ViewResources {
+			app.activeComponent = app.resourceTabs
+		}
+	case ViewSearch:
+		app.closeSearchPalette()
+		return nil
+	case ViewProblems:
+		app.currentView = app.problems.returnView
+		if app.currentView == ViewResources {
+			app.activeComponent = app.resourceTabs
+		}
+	case ViewTop:
+		if app.leaveTopPod() {
+			return nil
+		}
+		app.currentView = app.top.returnView
+		if app.currentView == ViewResources {
+			app.activeComponent = app.resourceTabs
+		}
+	case ViewMetrics:
+		app.currentView = app.metrics.returnView
+		if app.currentView == ViewResources {
+			app.activeComponent = app.resourceTabs
+		}
+	case ViewPortForwards:
+		app.currentView = app.portForwardReturnView
+		if app.currentView == ViewResources {
+			// Come back to the table the forward was started from
+			app.activeComponent = app.resourceTabs
+		}
+	case ViewOverview:
+		// Already at root level
+		return tea.Quit
+	}
+	
+	app.switchActiveComponent()
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+	resourcemanager "github.com/anindyar/kuber/src/libraries/resource-manager"
+	"github.com/anindyar/kuber/src/models"
+)
+
+// OwnerTreeMsg carries a rendered ownership tree
+type OwnerTreeMsg struct {
+	Title   string
+	Content string
+}
+
+// WorkloadLogsMsg carries a log selection whose pods were resolved through
+// the ownership graph
+type WorkloadLogsMsg struct {
+	Selector kubernetesclient.LogSelector
+	Title    string
+}
+
+// kindIcons are the icons shown for kinds in ownership trees and related
+// object lists, matching the resource tabs
+var kindIcons = map[string]string{
+	"Pod":                   "🐳",
+	"Deployment":            "🚀",
+	"ReplicaSet":            "📦",
+	"StatefulSet":           "📊",
+	"DaemonSet":             "👾",
+	"Job":                   "🔨",
+	"CronJob":               "⏰",
+	"Service":               "🌐",
+	"EndpointSlice":         "🔌",
+	"Ingress":               "🌍",
+	"ConfigMap":             "⚙️",
+	"Secret":                "🔐",
+	"PersistentVolume":      "💾",
+	"PersistentVolumeClaim": "📀",
+	"Node":                  "🖥️",
+}
+
+// kindIcon returns the icon for a kind
+func kindIcon(kind string) string {
+	if icon, ok := kindIcons[kind]; ok {
+		return icon
+	}
+	return "📄"
+}
+
+// openOwnerTree shows the full ownership chain of a resource: its top-most
+// owner and everything that owner owns
+func (app *Application) openOwnerTree(resourceType, name string) tea.Cmd {
+	namespace, rm := app.selectedNamespace, app.resourceManager
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		resources, err := rm.GetResourcesByType(ctx, namespace, resourceType)
+		if err != nil {
+			return ErrorMsg{Error: fmt.Sprintf("Failed to load %s: %v", resourceType, err)}
+		}
+		var target *models.Resource
+		for _, resource := range resources {
+			if resource.Metadata.Name == name {
+				target = resource
+				break
+			}
+		}
+		if target == nil {
+			return ErrorMsg{Error: fmt.Sprintf("Resource %s not found", name)}
+		}
+
+		graph, err := rm.BuildOwnerGraph(ctx, namespace)
+		if err != nil {
+			return ErrorMsg{Error: fmt.Sprintf("Failed to load owners of %s: %v", name, err)}
+		}
+		// The graph only lists workload types; add the object itself in case it isn't one
+		graph.Add(target)
+
+		return OwnerTreeMsg{
+			Title:   fmt.Sprintf("🌳 Owners: %s/%s", strings.ToLower(target.Kind), name),
+			Content: formatOwnerTree(graph.Tree(target.Metadata.UID), target.Metadata.UID),
+		}
+	}
+}
+
+// handleOwnerTree shows an ownership tree
+func (app *Application) handleOwnerTree(msg OwnerTreeMsg) {
+	if app.currentView != ViewOwners {
+		app.ownersReturnView = app.currentView
+	}
+	app.currentView = ViewOwners
+	app.detailViewport.SetContent(msg.Content)
+	app.detailViewport.SetTitle(msg.Title)
+	app.switchActiveComponent()
+}
+
+// formatOwnerTree renders an ownership tree, marking the selected object
+func formatOwnerTree(root *resourcemanager.OwnerNode, selectedUID string) string {
+	var tree strings.Builder
+
+	if root == nil {
+		return "No ownership information available\n"
+	}
+	if len(root.Children) == 0 && root.UID == selectedUID {
+		tree.WriteString("This object has no owners and owns nothing.\n\n")
+	}
+
+	var write func(node *resourcemanager.OwnerNode, prefix, branch string)
+	write = func(node *resourcemanager.OwnerNode, prefix, branch string) {
+		line := fmt.Sprintf("%s%s%s %s/%s", prefix, branch, kindIcon(node.Kind), node.Kind, node.Name)
+		if node.Resource != nil {
+			line += fmt.Sprintf("  %s %s  %s", node.Resource.GetStatusIcon(), node.Resource.ComputeStatus(),
+				formatAgeFromTime(node.Resource.Metadata.CreationTimestamp))
+		} else {
+			line += "  (not loaded)"
+		}
+		if node.UID == selectedUID {
+			line += "  ◀"
+		}
+		tree.WriteString(line + "\n")
+
+		switch branch {
+		case "├─ ":
+			prefix += "│  "
+		case "└─ ":
+			prefix += "   "
+		}
+		for i, child := range node.Children {
+			childBranch := "├─ "
+			if i == len(node.Children)-1 {
+				childBranch = "└─ "
+			}
+			write(child, prefix, childBranch)
+		}
+	}
+	write(root, "", "")
+
+	tree.WriteString("\nPress 'Esc' to go back\n")
+	return tree.String()
+}
@@ -0,0 +1,235 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	tuicomponents "github.com/anindyar/kuber/src/libraries/tui-components"
+	"github.com/anindyar/kuber/src/models"
+)
+
+// searchDebounce is how long the palette waits after a keystroke before
+// searching, so typing a query doesn't search every prefix of it
+const searchDebounce = 300 * time.Millisecond
+
+// maxSearchResults is how many results the palette lists
+const maxSearchResults = 200
+
+// searchPalette is the state of the ctrl+k search palette
+type searchPalette struct {
+	query      string
+	results    []*models.Resource
+	err        error
+	seq        int // bumped on every edit; stale searches are dropped
+	searching  bool
+	returnView ViewType
+}
+
+// searchDueMsg fires when the query has been left alone long enough
+type searchDueMsg struct{ Seq int }
+
+// SearchResultsMsg carries the results of a palette search
+type SearchResultsMsg struct {
+	Seq     int
+	Results []*models.Resource
+	Error   error
+}
+
+// searchColumns are the columns of the palette's result table
+func searchColumns() []table.Column {
+	return []table.Column{
+		{Title: "Kind", Width: 14},
+		{Title: "Namespace", Width: 20},
+		{Title: "Name", Width: 45},
+		{Title: "Status", Width: 16},
+		{Title: "Age", Width: 10},
+	}
+}
+
+// openSearchPalette opens the search palette over the current view
+func (app *Application) openSearchPalette() tea.Cmd {
+	if app.currentView == ViewSearch {
+		return nil
+	}
+
+	app.searchPalette = &searchPalette{returnView: app.currentView}
+	app.searchTable.SetRows(nil)
+	app.currentView = ViewSearch
+	app.switchActiveComponent()
+	return nil
+}
+
+// closeSearchPalette returns to the view the palette was opened from
+func (app *Application) closeSearchPalette() {
+	app.currentView = app.searchPalette.returnView
+	if app.currentView == ViewResources {
+		app.activeComponent = app.resourceTabs
+	}
+	app.switchActiveComponent()
+}
+
+// handleSearchPaletteKey edits the query, moves through the results or
+// jumps to the selected one
+func (app *Application) handleSearchPaletteKey(msg tea.KeyMsg) tea.Cmd {
+	palette := app.searchPalette
+
+	switch msg.String() {
+	case "ctrl+c":
+		return tea.Quit
+	case "esc", "ctrl+k":
+		app.closeSearchPalette()
+		return nil
+	case "enter":
+		return app.jumpToSearchResult()
+	case "up", "down", "pgup", "pgdown", "ctrl+p", "ctrl+n":
+		switch msg.String() {
+		case "ctrl+p":
+			msg = tea.KeyMsg{Type: tea.KeyUp}
+		case "ctrl+n":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		}
+		updatedComponent, cmd := app.searchTable.Update(msg)
+		if table, ok := updatedComponent.(*tuicomponents.TableComponent); ok {
+			app.searchTable = table
+		}
+		return cmd
+	case "backspace":
+		if len(palette.query) == 0 {
+			return nil
+		}
+		runes := []rune(palette.query)
+		palette.query = string(runes[:len(runes)-1])
+	case "ctrl+u":
+		palette.query = ""
+	default:
+		if msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace {
+			return nil
+		}
+		palette.query += string(msg.Runes)
+	}
+
+	palette.seq++
+	seq := palette.seq
+	return tea.Tick(searchDebounce, func(time.Time) tea.Msg {
+		return searchDueMsg{Seq: seq}
+	})
+}
+
+// runSearch searches for the palette's query if it hasn't changed since the
+// search was scheduled
+func (app *Application) runSearch(msg searchDueMsg) tea.Cmd {
+	palette := app.searchPalette
+	if palette == nil || msg.Seq != palette.seq {
+		return nil
+	}
+	if strings.TrimSpace(palette.query) == "" {
+		palette.results, palette.err, palette.searching = nil, nil, false
+		app.refreshSearchRows()
+		return nil
+	}
+
+	palette.searching = true
+	query, rm := palette.query, app.resourceManager
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		results, err := rm.Search(ctx, query)
+		return SearchResultsMsg{Seq: msg.Seq, Results: results, Error: err}
+	}
+}
+
+// handleSearchResults shows the results of the latest search
+func (app *Application) handleSearchResults(msg SearchResultsMsg) {
+	palette := app.searchPalette
+	if palette == nil || msg.Seq != palette.seq {
+		return
+	}
+
+	palette.searching = false
+	palette.err = msg.Error
+	if msg.Error == nil {
+		palette.results = msg.Results
+	}
+	app.refreshSearchRows()
+}
+
+// refreshSearchRows rebuilds the result table
+func (app *Application) refreshSearchRows() {
+	var rows []table.Row
+	for i, resource := range app.searchPalette.results {
+		if i == maxSearchResults {
+			break
+		}
+		namespace := resource.Metadata.Namespace
+		if namespace == "" {
+			namespace = "-"
+		}
+		rows = append(rows, table.Row{
+			kindIcon(resource.Kind) + " " + resource.Kind,
+			namespace,
+			resource.Metadata.Name,
+			fmt.Sprintf("%s %s", resource.GetStatusIcon(), resource.ComputeStatus()),
+			formatAgeFromTime(resource.Metadata.CreationTimestamp),
+		})
+	}
+	app.searchTable.SetRows(rows)
+	app.searchTable.SetSelectedIndex(0)
+}
+
+// jumpToSearchResult opens the details of the selected result
+func (app *Application) jumpToSearchResult() tea.Cmd {
+	results := app.searchPalette.results
+	cursor := app.searchTable.GetSelectedIndex()
+	if cursor < 0 || cursor >= len(results) {
+		return nil
+	}
+
+	resource := results[cursor]
+	return app.jumpToResource(resource.Kind, resource.Metadata.Namespace, resource.Metadata.Name)
+}
+
+// renderSearchPalette renders the search palette
+func (app *Application) renderSearchPalette(height int) string {
+	var content strings.Builder
+	palette := app.searchPalette
+
+	inputStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("15")).
+		Background(lipgloss.Color("236")).
+		Padding(0, 1).
+		Width(app.width)
+	content.WriteString(inputStyle.Render("🔍 "+palette.query+"█") + "\n")
+
+	app.searchTable.SetSize(app.width, height-4)
+	content.WriteString(app.searchTable.View() + "\n")
+
+	statusStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214"))
+	switch {
+	case palette.err != nil:
+		content.WriteString(statusStyle.Foreground(lipgloss.Color("196")).Render(fmt.Sprintf("❌ %v", palette.err)) + "\n")
+	case palette.searching:
+		content.WriteString(statusStyle.Render("Searching all namespaces...") + "\n")
+	case len(palette.results) > maxSearchResults:
+		content.WriteString(statusStyle.Render(fmt.Sprintf("%d matches, showing the first %d; narrow the query", len(palette.results), maxSearchResults)) + "\n")
+	case palette.query != "":
+		content.WriteString(statusStyle.Render(fmt.Sprintf("%d matches", len(palette.results))) + "\n")
+	default:
+		content.WriteString(statusStyle.Render("Type to search all namespaces") + "\n")
+	}
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8")).
+		Italic(true)
+	content.WriteString(hintStyle.Render("kind:pod ns:default l:app=web status:running age<1h restarts>3 text | ↑/↓: Select | Enter: Open | Esc: Close"))
+
+	return content.String()
+}
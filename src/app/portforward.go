@@ -0,0 +1,236 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+)
+
+// portForwardRefreshInterval is how often the port forward panel updates its byte counters
+const portForwardRefreshInterval = time.Second
+
+// portForwardTickMsg refreshes the port forward panel
+type portForwardTickMsg struct{}
+
+// portForwardColumns are the columns of the port forward panel
+func portForwardColumns() []table.Column {
+	return []table.Column{
+		{Title: "Namespace", Width: 16},
+		{Title: "Target", Width: 28},
+		{Title: "Local", Width: 16},
+		{Title: "Pod", Width: 28},
+		{Title: "Status", Width: 13},
+		{Title: "In", Width: 10},
+		{Title: "Out", Width: 10},
+		{Title: "Age", Width: 8},
+		{Title: "Last Error", Width: 40},
+	}
+}
+
+// promptPortForward asks which ports to forward for the selected pod or service
+func (app *Application) promptPortForward(name string) tea.Cmd {
+	kind := kubernetesclient.PortForwardPod
+	if app.currentResourceType == "services" {
+		kind = kubernetesclient.PortForwardService
+	}
+	namespace := app.selectedNamespace
+
+	app.prompt = &inputPrompt{
+		label: fmt.Sprintf("Forward to %s/%s, [local:]remote port:", kind, name),
+		onSubmit: func(value string) tea.Cmd {
+			localPort, remotePort, err := parsePortSpec(value)
+			if err != nil {
+				return func() tea.Msg { return ErrorMsg{Error: err.Error()} }
+			}
+			return app.startPortForward(kubernetesclient.PortForwardOptions{
+				Namespace:  namespace,
+				Kind:       kind,
+				Name:       name,
+				LocalPort:  localPort,
+				RemotePort: remotePort,
+			})
+		},
+	}
+	return nil
+}
+
+// parsePortSpec parses "remote", "local:remote" or ":remote" (any free local
+// port), the same forms kubectl port-forward accepts
+func parsePortSpec(spec string) (int, int, error) {
+	spec = strings.TrimSpace(spec)
+	localPart, remotePart, hasLocal := strings.Cut(spec, ":")
+	if !hasLocal {
+		remotePart = localPart
+	}
+
+	remote, err := strconv.Atoi(remotePart)
+	if err != nil || remote <= 0 || remote > 65535 {
+		return 0, 0, fmt.Errorf("invalid remote port %q", remotePart)
+	}
+	if !hasLocal {
+		return remote, remote, nil
+	}
+	if localPart == "" {
+		return 0, remote, nil
+	}
+
+	local, err := strconv.Atoi(localPart)
+	if err != nil || local < 0 || local > 65535 {
+		return 0, 0, fmt.Errorf("invalid local port %q", localPart)
+	}
+	return local, remote, nil
+}
+
+// startPortForward starts a forward and reports where it is listening
+func (app *Application) startPortForward(opts kubernetesclient.PortForwardOptions) tea.Cmd {
+	client := app.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		pf, err := client.PortForward(ctx, opts)
+		if err != nil {
+			return ErrorMsg{Error: fmt.Sprintf("Failed to forward to %s: %v", opts.Target(), err)}
+		}
+
+		info := pf.Info()
+		return InfoMsg{Info: fmt.Sprintf("Forwarding localhost:%d → %s (pod %s, port %d)\n\nPress 'P' to see active port forwards",
+			info.LocalPort, opts.Target(), info.PodName, info.TargetPort)}
+	}
+}
+
+// openPortForwards switches to the port forward panel, returning to the
+// current view on Esc
+func (app *Application) openPortForwards() tea.Cmd {
+	if app.currentView != ViewPortForwards {
+		app.portForwardReturnView = app.currentView
+	}
+	app.currentView = ViewPortForwards
+	app.switchActiveComponent()
+	app.refreshPortForwards()
+	return app.tickPortForwards()
+}
+
+// tickPortForwards schedules the next panel refresh
+func (app *Application) tickPortForwards() tea.Cmd {
+	return tea.Tick(portForwardRefreshInterval, func(time.Time) tea.Msg {
+		return portForwardTickMsg{}
+	})
+}
+
+// handlePortForwardTick refreshes the panel while it is open
+func (app *Application) handlePortForwardTick() tea.Cmd {
+	if app.currentView != ViewPortForwards {
+		return nil
+	}
+	app.refreshPortForwards()
+	return app.tickPortForwards()
+}
+
+// refreshPortForwards rebuilds the panel rows from the running forwards
+func (app *Application) refreshPortForwards() {
+	var rows []table.Row
+	for _, pf := range app.client.PortForwards() {
+		info := pf.Info()
+
+		local := "-"
+		if info.LocalPort != 0 {
+			local = fmt.Sprintf("%s:%d", info.Options.Address, info.LocalPort)
+		}
+		pod := "-"
+		if info.PodName != "" {
+			pod = fmt.Sprintf("%s:%d", info.PodName, info.TargetPort)
+		}
+		lastError := ""
+		if info.LastError != nil {
+			lastError = info.LastError.Error()
+		}
+		status := string(info.Status)
+		if info.Reconnects > 0 {
+			status = fmt.Sprintf("%s (%d)", status, info.Reconnects)
+		}
+
+		rows = append(rows, table.Row{
+			info.Options.Namespace,
+			info.Options.Target(),
+			local,
+			pod,
+			status,
+			formatByteCount(info.BytesIn),
+			formatByteCount(info.BytesOut),
+			formatAgeFromTime(info.StartedAt),
+			lastError,
+		})
+	}
+
+	app.portForwardTable.SetRows(rows)
+}
+
+// stopSelectedPortForward stops the forward highlighted in the panel
+func (app *Application) stopSelectedPortForward() tea.Cmd {
+	selectedRow := app.portForwardTable.GetSelectedRow()
+	if len(selectedRow) < 2 {
+		return nil
+	}
+
+	client := app.client
+	id := selectedRow[0] + "/" + selectedRow[1]
+	return func() tea.Msg {
+		if err := client.StopPortForward(id); err != nil {
+			return ErrorMsg{Error: fmt.Sprintf("Failed to stop port forward: %v", err)}
+		}
+		return portForwardTickMsg{}
+	}
+}
+
+// renderPortForwardsView renders the port forward panel
+func (app *Application) renderPortForwardsView(height int) string {
+	var content strings.Builder
+
+	var in, out int64
+	forwards := app.client.PortForwards()
+	for _, pf := range forwards {
+		info := pf.Info()
+		in += info.BytesIn
+		out += info.BytesOut
+	}
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+	content.WriteString(headerStyle.Render(fmt.Sprintf("🔌 Port Forwards: %d active • %s in • %s out",
+		len(forwards), formatByteCount(in), formatByteCount(out))) + "\n")
+
+	app.portForwardTable.SetSize(app.width, height-3)
+	content.WriteString(app.portForwardTable.View() + "\n")
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8")).
+		Italic(true)
+	content.WriteString(hintStyle.Render("Press 'f' on a pod or service to start a forward • Press 'x' to stop the selected forward • Press Esc to go back"))
+
+	return content.String()
+}
+
+// formatByteCount formats a byte count with a binary unit, e.g. "1.5 MiB"
+func formatByteCount(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
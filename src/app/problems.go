@@ -0,0 +1,243 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/anindyar/kuber/src/models"
+)
+
+// problemCountInterval is how often the dashboard's problem count is
+// updated. Diagnosing lists probe failure events from the API server, so it
+// runs on its own ticker rather than with every dashboard refresh.
+const problemCountInterval = 30 * time.Second
+
+// problemsState is the state of the "What's broken" view
+type problemsState struct {
+	namespace  string // empty for the whole cluster
+	issues     []models.Issue
+	loaded     bool
+	err        error
+	returnView ViewType
+}
+
+// ProblemsMsg carries the issues found by a diagnosis
+type ProblemsMsg struct {
+	Namespace string
+	Issues    []models.Issue
+	Error     error
+}
+
+// ProblemCountMsg carries the problem count shown on the dashboard, and the
+// context it was counted in
+type ProblemCountMsg struct {
+	Context string
+	Counts  models.IssueCounts
+}
+
+// problemCountTickMsg asks for a new problem count
+type problemCountTickMsg struct{}
+
+// problemColumns are the columns of the problems view; the namespace column
+// is only shown for the whole cluster
+func problemColumns(withNamespace bool) []table.Column {
+	columns := []table.Column{
+		{Title: "Severity", Width: 11},
+		{Title: "Reason", Width: 22},
+		{Title: "Object", Width: 40},
+		{Title: "Container", Width: 16},
+		{Title: "Since", Width: 8},
+		{Title: "Message", Width: 70},
+	}
+	if withNamespace {
+		columns = append([]table.Column{{Title: "Namespace", Width: 18}}, columns...)
+	}
+	return columns
+}
+
+// openProblems switches to the problems view for a namespace, or the whole
+// cluster when namespace is empty
+func (app *Application) openProblems(namespace string) tea.Cmd {
+	app.problems = &problemsState{namespace: namespace, returnView: app.currentView}
+
+	app.problemTable.SetRows(nil)
+	app.problemTable.SetColumns(problemColumns(namespace == ""))
+	app.currentView = ViewProblems
+	app.switchActiveComponent()
+	return app.loadProblems()
+}
+
+// loadProblems diagnoses the problems view's namespace
+func (app *Application) loadProblems() tea.Cmd {
+	namespace, rm := app.problems.namespace, app.resourceManager
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+
+		issues, err := rm.Diagnose(ctx, namespace)
+		return ProblemsMsg{Namespace: namespace, Issues: issues, Error: err}
+	}
+}
+
+// countProblems diagnoses the whole cluster for the dashboard's problem
+// count. A failed diagnosis keeps the last count.
+func (app *Application) countProblems() tea.Cmd {
+	rm, contextName := app.resourceManager, app.config.Context
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+
+		issues, err := rm.Diagnose(ctx, "")
+		if err != nil {
+			return nil
+		}
+		return ProblemCountMsg{Context: contextName, Counts: models.CountIssues(issues)}
+	}
+}
+
+// tickProblemCount schedules the next problem count
+func tickProblemCount() tea.Cmd {
+	return tea.Tick(problemCountInterval, func(time.Time) tea.Msg {
+		return problemCountTickMsg{}
+	})
+}
+
+// handleProblemCountTick updates the problem count while the dashboard is
+// shown and schedules the next one
+func (app *Application) handleProblemCountTick() tea.Cmd {
+	if app.currentView != ViewOverview {
+		return tickProblemCount()
+	}
+	return tea.Batch(app.countProblems(), tickProblemCount())
+}
+
+// handleProblemCount shows a new problem count on the dashboard. Counts
+// that finish after a context switch are dropped.
+func (app *Application) handleProblemCount(msg ProblemCountMsg) {
+	if msg.Context != app.config.Context {
+		return
+	}
+	app.problemCounts = msg.Counts
+	app.clusterMetrics.Problems = msg.Counts
+}
+
+// handleProblems shows the result of a diagnosis
+func (app *Application) handleProblems(msg ProblemsMsg) {
+	if app.problems == nil || app.problems.namespace != msg.Namespace {
+		return
+	}
+
+	app.problems.loaded = true
+	app.problems.err = msg.Error
+	if msg.Error == nil {
+		app.problems.issues = msg.Issues
+	}
+
+	var rows []table.Row
+	for _, issue := range app.problems.issues {
+		since := "-"
+		if !issue.Since.IsZero() {
+			since = formatAgeFromTime(issue.Since)
+		}
+		container := issue.Container
+		if container == "" {
+			container = "-"
+		}
+
+		row := table.Row{
+			issue.Severity.Icon() + " " + string(issue.Severity),
+			issue.Reason,
+			issue.Object.String(),
+			container,
+			since,
+			strings.ReplaceAll(issue.Message, "\n", " "),
+		}
+		if app.problems.namespace == "" {
+			namespace := issue.Object.Namespace
+			if namespace == "" {
+				namespace = "-"
+			}
+			row = append(table.Row{namespace}, row...)
+		}
+		rows = append(rows, row)
+	}
+	app.problemTable.SetRows(rows)
+}
+
+// openSelectedProblem opens the details of the object behind the selected
+// issue
+func (app *Application) openSelectedProblem() tea.Cmd {
+	issues := app.problems.issues
+	cursor := app.problemTable.GetSelectedIndex()
+	if cursor < 0 || cursor >= len(issues) {
+		return nil
+	}
+
+	object := issues[cursor].Object
+	return app.jumpToResource(object.Kind, object.Namespace, object.Name)
+}
+
+// renderProblemsView renders the problems view
+func (app *Application) renderProblemsView(height int) string {
+	var content strings.Builder
+	problems := app.problems
+
+	scope := "the cluster"
+	if problems.namespace != "" {
+		scope = problems.namespace
+	}
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+	content.WriteString(headerStyle.Render(fmt.Sprintf("🩺 What's broken in %s", scope)) + "\n")
+
+	app.problemTable.SetSize(app.width, height-4)
+	content.WriteString(app.problemTable.View() + "\n")
+
+	counts := models.CountIssues(problems.issues)
+	statusStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214"))
+	switch {
+	case problems.err != nil:
+		content.WriteString(statusStyle.Foreground(lipgloss.Color("196")).Render(fmt.Sprintf("❌ %v", problems.err)) + "\n")
+	case !problems.loaded:
+		content.WriteString(statusStyle.Render("Diagnosing...") + "\n")
+	case counts.Total() == 0:
+		content.WriteString(statusStyle.Foreground(lipgloss.Color("46")).Render("✅ No problems found") + "\n")
+	default:
+		content.WriteString(statusStyle.Render(formatIssueCounts(counts)) + "\n")
+	}
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8")).
+		Italic(true)
+	content.WriteString(hintStyle.Render("Enter: Open object | r: Refresh | Esc: Back"))
+
+	return content.String()
+}
+
+// formatIssueCounts renders issue counts, e.g. "2 critical, 5 warnings"
+func formatIssueCounts(counts models.IssueCounts) string {
+	var parts []string
+	if counts.Critical > 0 {
+		parts = append(parts, fmt.Sprintf("%d critical", counts.Critical))
+	}
+	if counts.Warning == 1 {
+		parts = append(parts, "1 warning")
+	} else if counts.Warning > 1 {
+		parts = append(parts, fmt.Sprintf("%d warnings", counts.Warning))
+	}
+	if counts.Info > 0 {
+		parts = append(parts, fmt.Sprintf("%d info", counts.Info))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	resourcemanager "github.com/anindyar/kuber/src/libraries/resource-manager"
+)
+
+// maxNumberedRelations is how many related objects get a jump key
+const maxNumberedRelations = 9
+
+// openRelated opens the details of the related object with the given index
+func (app *Application) openRelated(index int) tea.Cmd {
+	if index < 0 || index >= len(app.relatedObjects) {
+		return nil
+	}
+
+	related := app.relatedObjects[index]
+	resourceType := related.ResourceType()
+
+	// Cluster-scoped objects keep the namespace Esc returns to
+	if related.Namespace != "" {
+		app.selectedNamespace = related.Namespace
+	}
+	app.currentResourceType = resourceType
+	app.currentView = ViewDetails
+	return app.loadResourceDetails(related.Namespace, resourceType, related.Name)
+}
+
+// formatRelated renders the related objects section of the details view
+func formatRelated(relations []resourcemanager.Relation) string {
+	if len(relations) == 0 {
+		return ""
+	}
+
+	var details strings.Builder
+	details.WriteString("🔗 Related:\n")
+	for i, related := range relations {
+		key := "   "
+		if i < maxNumberedRelations {
+			key = fmt.Sprintf("[%d]", i+1)
+		}
+
+		name := related.Name
+		if related.Namespace == "" {
+			name += " (cluster)"
+		}
+		line := fmt.Sprintf("  %s %-12s %s %s/%s", key, related.Type, kindIcon(related.Kind), related.Kind, name)
+		if related.Detail != "" {
+			line += "  (" + related.Detail + ")"
+		}
+		details.WriteString(line + "\n")
+	}
+	details.WriteString("\n")
+
+	return details.String()
+}
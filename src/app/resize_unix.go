@@ -0,0 +1,19 @@
+//go:build !windows
+
+package app
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyResize delivers SIGWINCH, sent when the terminal window is resized
+func notifyResize(ch chan<- os.Signal) {
+	signal.Notify(ch, syscall.SIGWINCH)
+}
+
+// stopResize stops delivering resize signals
+func stopResize(ch chan<- os.Signal) {
+	signal.Stop(ch)
+}
@@ -0,0 +1,12 @@
+//go:build windows
+
+package app
+
+import "os"
+
+// notifyResize is a no-op: Windows consoles have no SIGWINCH, so the remote
+// terminal keeps the size it had when the shell started
+func notifyResize(ch chan<- os.Signal) {}
+
+// stopResize is a no-op on Windows
+func stopResize(ch chan<- os.Signal) {}
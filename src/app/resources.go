@@ -1,4 +1,4 @@
-package main
+package app
 
 import (
 	"context"
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+	tuicomponents "github.com/anindyar/kuber/src/libraries/tui-components"
 	"github.com/anindyar/kuber/src/models"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
@@ -30,27 +32,33 @@ func (app *Application) loadNamespaceResources(namespace string) tea.Cmd {
 			return ErrorMsg{Error: fmt.Sprintf("Failed to load %s from namespace %s: %v", resourceType, namespace, err)}
 		}
 
-		// Convert to table rows
-		var rows []table.Row
-		for _, resource := range resources {
-			status := "Unknown"
-			if s, ok := resource.Status["phase"].(string); ok {
-				status = s
-			}
+		// Update the resource table (columns are set during initialization)
+		// and keep it live with a watch
+		app.watchTableResources(namespace, resourceType)
+		app.tableResources = resources
+		app.refreshResourceRows()
+		app.updateStatusBar(fmt.Sprintf("%s in %s", resourceType, namespace), len(resources))
 
-			age := formatAgeFromTime(resource.Metadata.CreationTimestamp)
-			
-			row := table.Row{
-				resource.Metadata.Name,
-				status,
-				age,
-			}
-			rows = append(rows, row)
+		return RefreshMsg{}
+	}
+}
+
+// loadCustomResourceTabs appends discovered custom resource types (CRDs) to
+// the resource tabs so they can be browsed like built-in types
+func (app *Application) loadCustomResourceTabs() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		customTypes, err := app.resourceManager.GetCustomResourceTypes(ctx)
+		if err != nil {
+			// Built-in tabs still work; custom types are best effort
+			return nil
 		}
 
-		// Update the resource table (columns are set during initialization)
-		app.resourceTable.SetRows(rows)
-		app.updateStatusBar(fmt.Sprintf("%s in %s", resourceType, namespace), len(resources))
+		for _, rt := range customTypes {
+			app.resourceTabs.AddItem(tuicomponents.NewListItem(rt, "Custom resource", "🧩", rt))
+		}
 
 		return RefreshMsg{}
 	}
@@ -69,6 +77,7 @@ func (app *Application) selectPodForLogs() tea.Cmd {
 	
 	podName := selectedRow[0] // First column is the pod name
 	app.currentPodName = podName
+	app.logSelector = nil
 	app.currentView = ViewLogs
 	app.switchActiveComponent()
 	
@@ -81,12 +90,14 @@ func (app *Application) loadPodLogs(podName string) tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
+		app.logBuffer = nil
+
 		var logContent strings.Builder
 		logContent.WriteString(fmt.Sprintf("=== Logs for Pod: %s (Read-Only) ===\n", podName))
 		logContent.WriteString(fmt.Sprintf("Namespace: %s\n\n", app.selectedNamespace))
 
 		// Get recent logs using kubectl
-		cmd := exec.CommandContext(ctx, "kubectl", "logs", "--tail=50", podName, "-n", app.selectedNamespace)
+		cmd := exec.CommandContext(ctx, "kubectl", app.client.KubectlArgs("logs", "--tail=50", podName, "-n", app.selectedNamespace)...)
 		output, err := cmd.CombinedOutput() // Use CombinedOutput to get stderr as well
 		if err != nil {
 			if exitErr, ok := err.(*exec.ExitError); ok {
@@ -112,10 +123,20 @@ func (app *Application) loadPodLogs(podName string) tea.Cmd {
 			} else {
 				logContent.WriteString(fmt.Sprintf("Error getting logs: %v\n", err))
 			}
-		} else if len(output) == 0 {
-			logContent.WriteString("No logs available (pod may have just started)\n")
 		} else {
-			logContent.WriteString(string(output))
+			// Parse the lines so the view can filter on fields and pretty-print
+			app.logBuffer = models.NewLogBuffer(logBufferSize)
+			source := models.LogSource{PodName: podName, Namespace: app.selectedNamespace}
+			for _, line := range strings.Split(strings.TrimRight(string(output), "\n"), "\n") {
+				if entry, err := models.NewLogEntry(time.Now(), source, line); err == nil {
+					app.logBuffer.Append(entry)
+				}
+			}
+
+			app.originalLogContent, _ = app.renderLogEntries(nil)
+			app.detailViewport.SetContent(app.originalLogContent)
+			app.detailViewport.SetTitle(fmt.Sprintf("📜 Logs: %s", podName))
+			return RefreshMsg{}
 		}
 
 		logContent.WriteString("\n=== Instructions ===\n")
@@ -165,7 +186,9 @@ func (app *Application) renderResourcesView() string {
 	// Build resource-specific actions hint
 	actions := "Enter: Select | d: Details"
 	if resourceType == "pods" {
-		actions = "Enter/l: Logs | s: Shell | d: Details"
+		actions = "Enter/l: Logs | L: Logs by label | s: Shell | f: Port-forward | d: Details"
+	} else if resourceType == "services" {
+		actions = "Enter: Select | f: Port-forward | d: Details"
 	} else if resourceType == "deployments" || resourceType == "statefulsets" {
 		actions = "Enter: Select | l: Logs | d: Details"
 	}
@@ -311,10 +334,14 @@ func getRowForResource(resource *models.Resource, resourceType string) table.Row
 		if s, ok := resource.Status["phase"].(string); ok {
 			status = s
 		}
-		restarts := "0"
-		if r, ok := resource.Status["restarts"].(string); ok {
-			restarts = r
+		// Like kubectl, show why a container is stuck rather than the phase
+		if reason, ok := resource.Status["reason"].(string); ok && reason != "" {
+			status = reason
+		}
+		if resource.IsDeleting() {
+			status = string(models.ResourceStatusTerminating)
 		}
+		restarts := fmt.Sprintf("%d", resource.Restarts)
 		return table.Row{resource.Metadata.Name, ready, status, restarts, age}
 		
 	case "deployments":
@@ -376,169 +403,66 @@ func getRowForResource(resource *models.Resource, resourceType string) table.Row
 	}
 }
 
-// selectWorkloadForLogs handles log selection for deployments and statefulsets
+// selectWorkloadForLogs follows the logs of every pod of a deployment or statefulset
 func (app *Application) selectWorkloadForLogs(resourceName string) tea.Cmd {
+	kind := "Deployment"
+	if app.currentResourceType == "statefulsets" {
+		kind = "StatefulSet"
+	}
+
+	namespace, rm := app.selectedNamespace, app.resourceManager
+	title := fmt.Sprintf("📜 Logs: %s/%s", strings.Title(app.currentResourceType), resourceName)
 	return func() tea.Msg {
-		// Find pods belonging to this workload resource
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
-		
-		// DEBUG: Add some debug info
-		var debugInfo strings.Builder
-		debugInfo.WriteString(fmt.Sprintf("=== DEBUG: Searching for pods for %s '%s' ===\n", app.currentResourceType, resourceName))
-		debugInfo.WriteString(fmt.Sprintf("Namespace: %s\n\n", app.selectedNamespace))
-		
-		// Get all pods in the namespace
-		allPods, err := app.resourceManager.GetResourcesByType(ctx, app.selectedNamespace, "pods")
+
+		// Pods are matched through their owner references, e.g. via the
+		// Deployment's ReplicaSets, so look-alike names don't get mixed in
+		ownedBy, err := rm.OwnerMatcher(ctx, namespace, kind, resourceName)
 		if err != nil {
-			return ErrorMsg{Error: fmt.Sprintf("Failed to get pods: %v", err)}
-		}
-		
-		debugInfo.WriteString(fmt.Sprintf("Found %d pods total in namespace:\n", len(allPods)))
-		for i, pod := range allPods {
-			debugInfo.WriteString(fmt.Sprintf("  %d. %s\n", i+1, pod.Metadata.Name))
+			return ErrorMsg{Error: fmt.Sprintf("Failed to find the pods of %s: %v", resourceName, err)}
 		}
-		debugInfo.WriteString("\n")
-		
-		// Find pods that belong to this workload
-		var targetPods []*models.Resource
-		for _, pod := range allPods {
-			belongs := podBelongsToResource(pod, resourceName, app.currentResourceType)
-			debugInfo.WriteString(fmt.Sprintf("Pod %s belongs to %s: %t\n", pod.Metadata.Name, resourceName, belongs))
-			if belongs {
-				targetPods = append(targetPods, pod)
-			}
-		}
-		
-		debugInfo.WriteString(fmt.Sprintf("\nMatched %d pods for %s '%s'\n\n", len(targetPods), app.currentResourceType, resourceName))
-		
-		if len(targetPods) == 0 {
-			// Show debug info when no pods found
-			app.currentView = ViewLogs
-			app.switchActiveComponent()
-			debugInfo.WriteString("=== No pods found - showing debug info ===\n")
-			debugInfo.WriteString("Press 'Esc' to go back\n")
-			app.originalLogContent = debugInfo.String()
-			app.detailViewport.SetContent(debugInfo.String())
-			app.detailViewport.SetTitle(fmt.Sprintf("🔍 Debug: %s/%s", app.currentResourceType, resourceName))
-			return RefreshMsg{}
+
+		tailLines := int64(20)
+		return WorkloadLogsMsg{
+			Selector: kubernetesclient.LogSelector{
+				Namespace: namespace,
+				OwnerKind: kind,
+				OwnerName: resourceName,
+				TailLines: &tailLines,
+				OwnedBy:   ownedBy,
+			},
+			Title: title,
 		}
-		
-		// Load aggregated logs from all pods
-		app.currentView = ViewLogs
-		app.switchActiveComponent()
-		
-		return app.loadWorkloadLogs(resourceName, targetPods)
 	}
 }
 
-// loadWorkloadLogs loads aggregated logs from multiple pods
-func (app *Application) loadWorkloadLogs(resourceName string, pods []*models.Resource) tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-		defer cancel()
-		
-		var logContent strings.Builder
-		logContent.WriteString(fmt.Sprintf("=== Aggregated Logs for %s: %s (Read-Only) ===\n", strings.Title(app.currentResourceType), resourceName))
-		logContent.WriteString(fmt.Sprintf("Namespace: %s\n", app.selectedNamespace))
-		logContent.WriteString(fmt.Sprintf("Found %d pod(s)\n\n", len(pods)))
-		
-		// Get logs from each pod
-		for i, pod := range pods {
-			podName := pod.Metadata.Name
-			logContent.WriteString(fmt.Sprintf("--- Pod %d/%d: %s ---\n", i+1, len(pods), podName))
-			
-			// Get recent logs from this pod
-			cmd := exec.CommandContext(ctx, "kubectl", "logs", "--tail=20", podName, "-n", app.selectedNamespace)
-			output, err := cmd.CombinedOutput()
-			if err != nil {
-				if exitErr, ok := err.(*exec.ExitError); ok {
-					errorMsg := string(output)
-					if strings.Contains(errorMsg, "not found") {
-						logContent.WriteString("Pod not found or has been deleted\n")
-					} else if strings.Contains(errorMsg, "is waiting to start") {
-						logContent.WriteString("Pod is waiting to start\n")
-					} else {
-						logContent.WriteString(fmt.Sprintf("Error (exit %d): %s\n", exitErr.ExitCode(), errorMsg))
-					}
-				} else {
-					logContent.WriteString(fmt.Sprintf("Error: %v\n", err))
-				}
-			} else if len(output) == 0 {
-				logContent.WriteString("No logs available\n")
-			} else {
-				// Add pod name prefix to each log line for clarity
-				lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-				for _, line := range lines {
-					if strings.TrimSpace(line) != "" {
-						logContent.WriteString(fmt.Sprintf("[%s] %s\n", podName, line))
-					}
-				}
-			}
-			logContent.WriteString("\n")
-		}
-		
-		// Add instructions
-		logContent.WriteString("=== Instructions ===\n")
-		logContent.WriteString("Press 'f' to toggle follow mode (not yet implemented for workloads)\n")
-		logContent.WriteString("Press 'r' to refresh logs\n")
-		logContent.WriteString("Press '/' to search logs\n")
-		logContent.WriteString("Press 'Esc' to go back to resources\n")
-		
-		// Store for search functionality
-		app.originalLogContent = logContent.String()
-		app.detailViewport.SetContent(logContent.String())
-		app.detailViewport.SetTitle(fmt.Sprintf("📜 Logs: %s/%s", strings.Title(app.currentResourceType), resourceName))
-		
-		return RefreshMsg{}
+// selectLabelSelectorForLogs follows the logs of every pod matching a label selector
+func (app *Application) selectLabelSelectorForLogs(labelSelector string) tea.Cmd {
+	if labelSelector == "" {
+		return nil
 	}
+
+	tailLines := int64(20)
+	return app.openMultiplexedLogs(kubernetesclient.LogSelector{
+		Namespace:     app.selectedNamespace,
+		LabelSelector: labelSelector,
+		TailLines:     &tailLines,
+	}, fmt.Sprintf("📜 Logs: %s", labelSelector))
 }
 
-// podBelongsToResource checks if a pod belongs to a specific workload resource
-func podBelongsToResource(pod *models.Resource, resourceName, resourceType string) bool {
-	// Primary method: check if pod name starts with resource name (most common pattern)
-	if strings.HasPrefix(pod.Metadata.Name, resourceName+"-") {
-		return true
-	}
-	
-	// Check owner references (for direct relationships)
-	if len(pod.Metadata.OwnerReferences) > 0 {
-		for _, owner := range pod.Metadata.OwnerReferences {
-			// Direct ownership
-			if strings.ToLower(owner.Kind) == resourceType && owner.Name == resourceName {
-				return true
-			}
-			// For deployments, check ReplicaSet ownership
-			if resourceType == "deployments" && strings.ToLower(owner.Kind) == "replicaset" {
-				// ReplicaSet name contains deployment name as prefix
-				if strings.HasPrefix(owner.Name, resourceName+"-") {
-					return true
-				}
-			}
-		}
-	}
-	
-	// Check labels for common patterns
-	if pod.Metadata.Labels != nil {
-		// Standard app label
-		if appLabel, ok := pod.Metadata.Labels["app"]; ok && appLabel == resourceName {
-			return true
-		}
-		// Kubernetes recommended labels
-		if nameLabel, ok := pod.Metadata.Labels["app.kubernetes.io/name"]; ok && nameLabel == resourceName {
-			return true
-		}
-		// Instance label (often used with Helm)
-		if instanceLabel, ok := pod.Metadata.Labels["app.kubernetes.io/instance"]; ok && instanceLabel == resourceName {
-			return true
-		}
-		// Check for any label containing the resource name
-		for _, value := range pod.Metadata.Labels {
-			if value == resourceName {
-				return true
-			}
-		}
-	}
-	
-	return false
-}
\ No newline at end of file
+// openMultiplexedLogs switches to the log view and follows the selected pods
+func (app *Application) openMultiplexedLogs(selector kubernetesclient.LogSelector, title string) tea.Cmd {
+	app.stopLogFollow()
+	app.logSelector = &selector
+	app.currentPodName = ""
+	app.searchMode = false
+	app.searchQuery = ""
+
+	app.currentView = ViewLogs
+	app.switchActiveComponent()
+	app.detailViewport.SetTitle(title)
+
+	app.followMode = true
+	return app.startLogFollow()
+}
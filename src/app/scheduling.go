@@ -0,0 +1,44 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	resourcemanager "github.com/anindyar/kuber/src/libraries/resource-manager"
+)
+
+// formatScheduling renders why a pending pod doesn't fit on each node as a
+// table for the details view
+func formatScheduling(fits []resourcemanager.NodeFit) string {
+	if len(fits) == 0 {
+		return ""
+	}
+
+	nodeWidth := len("NODE")
+	for _, fit := range fits {
+		if len(fit.Node) > nodeWidth {
+			nodeWidth = len(fit.Node)
+		}
+	}
+
+	var details strings.Builder
+	details.WriteString("🧭 Scheduling:\n")
+	details.WriteString(fmt.Sprintf("  %s\n\n", resourcemanager.SchedulingSummary(fits)))
+	details.WriteString(fmt.Sprintf("  %-*s  %-4s  %s\n", nodeWidth, "NODE", "FITS", "WHY NOT"))
+	for _, fit := range fits {
+		if fit.Fits() {
+			details.WriteString(fmt.Sprintf("  %-*s  %-4s  %s\n", nodeWidth, fit.Node, "yes", "-"))
+			continue
+		}
+		for i, reason := range fit.Reasons {
+			if i == 0 {
+				details.WriteString(fmt.Sprintf("  %-*s  %-4s  %s\n", nodeWidth, fit.Node, "no", reason))
+			} else {
+				details.WriteString(fmt.Sprintf("  %-*s  %-4s  %s\n", nodeWidth, "", "", reason))
+			}
+		}
+	}
+	details.WriteString("\n")
+
+	return details.String()
+}
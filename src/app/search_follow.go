@@ -0,0 +1,497 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+	"github.com/anindyar/kuber/src/models"
+)
+
+const (
+	// logBufferSize is how many lines follow mode keeps
+	logBufferSize = 5000
+	// logBatchInterval is how often streamed lines are pushed to the view
+	logBatchInterval = 100 * time.Millisecond
+)
+
+// LogEntriesMsg delivers newly streamed log lines for the followed target
+type LogEntriesMsg struct {
+	Target  string
+	Entries []*models.LogEntry
+}
+
+// LogFollowEndedMsg reports that following logs stopped on its own
+type LogFollowEndedMsg struct {
+	Target string
+	Error  error
+}
+
+// handleSearchInput processes keyboard input when in search mode
+func (app *Application) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "/":
+		// Exit search mode
+		app.searchMode = false
+		app.searchQuery = ""
+		if app.originalLogContent != "" {
+			app.detailViewport.SetContent(app.originalLogContent)
+		}
+		return app, nil
+
+	case "enter":
+		// Apply search filter
+		if app.searchQuery != "" {
+			app.filterLogs(app.searchQuery)
+		}
+		return app, nil
+
+	case "backspace":
+		// Remove last character
+		if len(app.searchQuery) > 0 {
+			app.searchQuery = app.searchQuery[:len(app.searchQuery)-1]
+			if app.searchQuery == "" {
+				// If query is empty, restore original content
+				if app.originalLogContent != "" {
+					app.detailViewport.SetContent(app.originalLogContent)
+				}
+			} else {
+				app.filterLogs(app.searchQuery)
+			}
+		}
+		return app, nil
+
+	default:
+		// Add character to search query (only printable characters)
+		if len(msg.String()) == 1 && msg.String()[0] >= 32 && msg.String()[0] <= 126 {
+			app.searchQuery += msg.String()
+			app.filterLogs(app.searchQuery)
+		}
+		return app, nil
+	}
+}
+
+// filterLogs filters log content based on search query
+func (app *Application) filterLogs(query string) {
+	if query == "" {
+		if app.originalLogContent != "" {
+			app.detailViewport.SetContent(app.originalLogContent)
+		}
+		return
+	}
+
+	// Parsed log lines support field filters such as level>=warn
+	if app.currentView == ViewLogs && app.logBuffer != nil {
+		app.filterLogEntries(query)
+		return
+	}
+
+	if app.originalLogContent == "" {
+		// Store original content if not already stored
+		app.originalLogContent = app.detailViewport.GetContent()
+	}
+
+	// Filter lines containing the query (case-insensitive)
+	lines := strings.Split(app.originalLogContent, "\n")
+	var filteredLines []string
+	queryLower := strings.ToLower(query)
+
+	for _, line := range lines {
+		if strings.Contains(strings.ToLower(line), queryLower) {
+			// Highlight the search term
+			highlightedLine := highlightSearchTerm(line, query)
+			filteredLines = append(filteredLines, highlightedLine)
+		}
+	}
+
+	// Update viewport with filtered content
+	filteredContent := strings.Join(filteredLines, "\n")
+	if len(filteredLines) == 0 {
+		filteredContent = fmt.Sprintf("No matches found for: %s\n\nPress Esc to clear search", query)
+	}
+
+	app.detailViewport.SetContent(filteredContent)
+}
+
+// highlightSearchTerm highlights search terms in log lines
+func highlightSearchTerm(line, term string) string {
+	if term == "" {
+		return line
+	}
+
+	highlightStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("226")). // Yellow background
+		Foreground(lipgloss.Color("0"))    // Black text
+
+	// Simple case-insensitive highlighting
+	lowerLine := strings.ToLower(line)
+	lowerTerm := strings.ToLower(term)
+	
+	if !strings.Contains(lowerLine, lowerTerm) {
+		return line
+	}
+
+	// Find all occurrences and highlight them
+	result := line
+	startIdx := 0
+	for {
+		idx := strings.Index(strings.ToLower(result[startIdx:]), lowerTerm)
+		if idx == -1 {
+			break
+		}
+		
+		actualIdx := startIdx + idx
+		// Extract the actual case from the original line
+		originalTerm := result[actualIdx : actualIdx+len(term)]
+		highlightedTerm := highlightStyle.Render(originalTerm)
+		
+		result = result[:actualIdx] + highlightedTerm + result[actualIdx+len(term):]
+		startIdx = actualIdx + len(highlightedTerm)
+	}
+
+	return result
+}
+
+// toggleFollowMode toggles live log streaming
+func (app *Application) toggleFollowMode() tea.Cmd {
+	if app.followMode {
+		// Stop following; the lines received so far stay on screen
+		app.stopLogFollow()
+		if app.logBuffer != nil {
+			app.refreshLogView()
+		}
+		return func() tea.Msg {
+			return InfoMsg{Info: "Follow mode disabled"}
+		}
+	} else {
+		// Start following
+		app.followMode = true
+		return app.startLogFollow()
+	}
+}
+
+// startLogFollow starts live log streaming for the current pod, or for every
+// pod of the current selector
+func (app *Application) startLogFollow() tea.Cmd {
+	if app.currentPodName == "" && app.logSelector == nil {
+		app.followMode = false
+		return func() tea.Msg {
+			return ErrorMsg{Error: "No pod selected for following"}
+		}
+	}
+
+	if app.logStreamCancel != nil {
+		app.logStreamCancel()
+	}
+
+	// Create a cancellable context
+	ctx, cancel := context.WithCancel(context.Background())
+	app.logStreamCancel = cancel
+	app.logBuffer = models.NewLogBuffer(logBufferSize)
+	app.logFilter = nil
+
+	client := app.client
+	target := app.logTarget()
+
+	var follow func(context.Context, chan<- *models.LogEntry) error
+	if app.logSelector != nil {
+		multiplexer := kubernetesclient.NewLogMultiplexer(client, *app.logSelector)
+		follow = multiplexer.Run
+	} else {
+		opts := kubernetesclient.LogOptions{
+			Namespace: app.selectedNamespace,
+			PodName:   app.currentPodName,
+		}
+		follow = func(ctx context.Context, entries chan<- *models.LogEntry) error {
+			return client.FollowLogs(ctx, opts, entries)
+		}
+	}
+
+	app.originalLogContent, _ = app.renderLogEntries(nil)
+	app.detailViewport.SetContent(app.originalLogContent)
+
+	// Start streaming logs in a goroutine
+	go streamLogs(ctx, app.program, target, follow)
+
+	return func() tea.Msg {
+		return InfoMsg{Info: fmt.Sprintf("📡 Following logs for %s (press 'f' to stop)", target)}
+	}
+}
+
+// logTarget names what the log view shows: a pod or a multi-pod selection
+func (app *Application) logTarget() string {
+	if app.logSelector != nil {
+		return app.logSelector.Describe()
+	}
+	return app.currentPodName
+}
+
+// stopLogFollow stops live log streaming, if running
+func (app *Application) stopLogFollow() {
+	if app.logStreamCancel != nil {
+		app.logStreamCancel()
+		app.logStreamCancel = nil
+	}
+	app.followMode = false
+}
+
+// streamLogs runs a log follower and sends its lines to the program in small
+// batches, so a chatty pod doesn't trigger a render per line
+func streamLogs(ctx context.Context, program *tea.Program, target string, follow func(context.Context, chan<- *models.LogEntry) error) {
+	if program == nil {
+		return
+	}
+
+	entries := make(chan *models.LogEntry, 256)
+	done := make(chan error, 1)
+	go func() {
+		done <- follow(ctx, entries)
+	}()
+
+	ticker := time.NewTicker(logBatchInterval)
+	defer ticker.Stop()
+
+	var batch []*models.LogEntry
+	flush := func() {
+		if len(batch) > 0 {
+			program.Send(LogEntriesMsg{Target: target, Entries: batch})
+			batch = nil
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case entry := <-entries:
+			batch = append(batch, entry)
+		case <-ticker.C:
+			flush()
+		case err := <-done:
+			// Drain what was delivered before the stream ended
+			for len(entries) > 0 {
+				batch = append(batch, <-entries)
+			}
+			flush()
+			if ctx.Err() == nil {
+				program.Send(LogFollowEndedMsg{Target: target, Error: err})
+			}
+			return
+		}
+	}
+}
+
+// handleLogEntries appends streamed lines to the log view, keeping the
+// scroll position and any active search
+func (app *Application) handleLogEntries(msg LogEntriesMsg) {
+	if !app.followMode || app.currentView != ViewLogs || msg.Target != app.logTarget() || app.logBuffer == nil {
+		return
+	}
+
+	wasAtBottom := app.detailViewport.AtBottom()
+	offset := app.detailViewport.YOffset()
+
+	hadEntries := app.logBuffer.Len() > 0
+	evicted := app.logBuffer.Append(msg.Entries...)
+
+	// Only the new lines are rendered, unless old ones fell off the top or
+	// the first lines replace the waiting message
+	rebuild := evicted > 0 || !hadEntries
+	if rebuild {
+		app.originalLogContent, _ = app.renderLogEntries(nil)
+	} else {
+		var lines strings.Builder
+		app.renderLogLines(&lines, msg.Entries, nil)
+		app.originalLogContent += lines.String()
+	}
+
+	if app.searchMode && app.searchQuery != "" {
+		if rebuild || app.logFilter == nil {
+			app.filterLogs(app.searchQuery)
+		} else {
+			app.appendFilteredLogEntries(msg.Entries)
+		}
+	} else {
+		app.detailViewport.SetContent(app.originalLogContent)
+		if !wasAtBottom && evicted > 0 {
+			// Keep the same lines on screen as old ones fall off the top
+			if offset -= evicted; offset < 0 {
+				offset = 0
+			}
+			app.detailViewport.SetYOffset(offset)
+		}
+	}
+
+	if wasAtBottom {
+		app.detailViewport.ScrollToBottom()
+	}
+}
+
+// handleLogFollowEnded reports a follow stream that gave up
+func (app *Application) handleLogFollowEnded(msg LogFollowEndedMsg) tea.Cmd {
+	if !app.followMode || msg.Target != app.logTarget() {
+		return nil
+	}
+
+	app.stopLogFollow()
+	return func() tea.Msg {
+		if msg.Error != nil {
+			return InfoMsg{Info: fmt.Sprintf("Stopped following %s: %v", msg.Target, msg.Error)}
+		}
+		return InfoMsg{Info: fmt.Sprintf("Stopped following %s", msg.Target)}
+	}
+}
+
+// renderLogEntries renders the buffered log lines that match the filter
+// under a header, returning the content and the number of matching lines.
+// Lines from several pods get a colored [pod/container] prefix.
+func (app *Application) renderLogEntries(filter *models.LogFilter) (string, int) {
+	var logContent strings.Builder
+	if app.followMode {
+		if app.logSelector != nil {
+			logContent.WriteString(fmt.Sprintf("=== Live Logs for %s ===\n", app.logSelector.Describe()))
+		} else {
+			logContent.WriteString(fmt.Sprintf("=== Live Logs for Pod: %s ===\n", app.currentPodName))
+		}
+		logContent.WriteString(fmt.Sprintf("Namespace: %s | 📡 FOLLOWING (last %d lines)\n\n", app.selectedNamespace, app.logBuffer.Capacity()))
+	} else {
+		logContent.WriteString(fmt.Sprintf("=== Logs for Pod: %s (Read-Only) ===\n", app.logTarget()))
+		logContent.WriteString(fmt.Sprintf("Namespace: %s\n\n", app.selectedNamespace))
+	}
+
+	entries := app.logBuffer.Entries()
+	if len(entries) == 0 {
+		if app.followMode {
+			logContent.WriteString("Waiting for logs...\n")
+		} else {
+			logContent.WriteString("No logs available (pod may have just started)\n")
+		}
+	}
+
+	matched := app.renderLogLines(&logContent, entries, filter)
+
+	if !app.followMode {
+		logContent.WriteString("\n=== Instructions ===\n")
+		logContent.WriteString("Press 'f' to toggle follow mode\n")
+		logContent.WriteString("Press 'p' to toggle pretty output\n")
+		logContent.WriteString("Press '/' to filter logs (e.g. level>=warn user_id=42)\n")
+		logContent.WriteString("Press 'Esc' to go back to pods\n")
+	}
+
+	return logContent.String(), matched
+}
+
+// renderLogLines writes the entries that match the filter, one per line,
+// and returns how many matched
+func (app *Application) renderLogLines(logContent *strings.Builder, entries []*models.LogEntry, filter *models.LogFilter) int {
+	matched := 0
+	for _, entry := range entries {
+		if !filter.Matches(entry) {
+			continue
+		}
+		matched++
+
+		if app.logSelector != nil {
+			prefixStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(entry.Source.Color()))
+			logContent.WriteString(prefixStyle.Render(entry.Source.Prefix()) + " ")
+		}
+
+		line := entry.Content
+		if app.logPretty {
+			line = prettyLogLine(entry)
+		}
+		if filter != nil {
+			for _, text := range filter.Text {
+				line = highlightSearchTerm(line, text)
+			}
+		}
+		logContent.WriteString(line)
+		logContent.WriteString("\n")
+	}
+	return matched
+}
+
+// prettyLogLine formats a structured log line as time, colored level,
+// message and sorted fields
+func prettyLogLine(entry *models.LogEntry) string {
+	if entry.Format == "" {
+		return entry.Content
+	}
+
+	levelColors := map[models.LogLevel]string{
+		models.LogLevelDebug:   "8",
+		models.LogLevelInfo:    "39",
+		models.LogLevelWarning: "214",
+		models.LogLevelError:   "196",
+		models.LogLevelFatal:   "201",
+	}
+
+	timeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	line := timeStyle.Render(entry.Timestamp.Format("15:04:05.000")) + " "
+	if color, ok := levelColors[entry.Level]; ok {
+		levelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(true)
+		line += levelStyle.Render(fmt.Sprintf("%-5s", entry.Level)) + " "
+	}
+	return line + entry.PrettyLog()
+}
+
+// filterLogEntries shows the buffered log lines matching a field filter
+func (app *Application) filterLogEntries(query string) {
+	filter, err := models.ParseLogFilter(query)
+	if err != nil {
+		app.logFilter = nil
+		app.detailViewport.SetContent(fmt.Sprintf("Invalid filter: %v\n\nPress Esc to clear search", err))
+		return
+	}
+
+	content, matched := app.renderLogEntries(filter)
+	if matched == 0 {
+		content = fmt.Sprintf("No matches found for: %s\n\nPress Esc to clear search", query)
+	}
+	app.logFilter = filter
+	app.logFilterContent = content
+	app.logFilterMatched = matched
+	app.detailViewport.SetContent(content)
+}
+
+// appendFilteredLogEntries adds the streamed lines matching the active
+// filter to the filtered log view
+func (app *Application) appendFilteredLogEntries(entries []*models.LogEntry) {
+	var lines strings.Builder
+	matched := app.renderLogLines(&lines, entries, app.logFilter)
+	if matched == 0 {
+		return
+	}
+	if app.logFilterMatched == 0 {
+		// The first match replaces the no-matches message
+		app.filterLogEntries(app.searchQuery)
+		return
+	}
+
+	app.logFilterContent += lines.String()
+	app.logFilterMatched += matched
+	app.detailViewport.SetContent(app.logFilterContent)
+}
+
+// toggleLogPretty switches the log view between raw and pretty-printed lines
+func (app *Application) toggleLogPretty() {
+	app.logPretty = !app.logPretty
+	if app.logBuffer != nil {
+		app.refreshLogView()
+	}
+}
+
+// refreshLogView re-renders the buffered log lines, re-applying any active filter
+func (app *Application) refreshLogView() {
+	app.originalLogContent, _ = app.renderLogEntries(nil)
+	if app.searchMode && app.searchQuery != "" {
+		app.filterLogs(app.searchQuery)
+	} else {
+		app.detailViewport.SetContent(app.originalLogContent)
+	}
+}
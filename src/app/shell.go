@@ -0,0 +1,267 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/cancelreader"
+	"golang.org/x/term"
+	"k8s.io/client-go/tools/remotecommand"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+)
+
+// ContainersMsg carries the containers of a pod, to pick the one an action runs in
+type ContainersMsg struct {
+	PodName    string
+	Namespace  string
+	Containers []string
+	Purpose    string // what the container is for, e.g. "shell"
+	Next       func(podName, namespace, container string) tea.Cmd
+}
+
+// NoShellMsg reports a container without a shell, e.g. a distroless image
+type NoShellMsg struct {
+	PodName   string
+	Namespace string
+	Container string
+}
+
+// ShellReadyMsg carries everything needed to open a shell in a container
+type ShellReadyMsg struct {
+	PodName   string
+	Namespace string
+	Container string
+	Shells    []string
+}
+
+// execShell starts opening a shell in a pod
+func (app *Application) execShell(podName string) tea.Cmd {
+	return app.pickContainer(podName, "shell", app.detectShells)
+}
+
+// pickContainer looks up a pod's containers and continues with next once
+// one is chosen
+func (app *Application) pickContainer(podName, purpose string, next func(podName, namespace, container string) tea.Cmd) tea.Cmd {
+	client, namespace := app.client, app.selectedNamespace
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		containers, err := client.GetContainers(ctx, namespace, podName)
+		if err != nil {
+			return ErrorMsg{Error: fmt.Sprintf("Failed to open %s in %s: %v", purpose, podName, err)}
+		}
+
+		// Init containers have finished by the time anything can run in them
+		var running []string
+		for _, container := range containers {
+			if !strings.HasSuffix(container, " (init)") {
+				running = append(running, container)
+			}
+		}
+		if len(running) == 0 {
+			return ErrorMsg{Error: fmt.Sprintf("Pod %s has no containers", podName)}
+		}
+
+		return ContainersMsg{PodName: podName, Namespace: namespace, Containers: running, Purpose: purpose, Next: next}
+	}
+}
+
+// handleContainers asks which container to use when there is more than one
+func (app *Application) handleContainers(msg ContainersMsg) tea.Cmd {
+	if len(msg.Containers) == 1 {
+		return msg.Next(msg.PodName, msg.Namespace, msg.Containers[0])
+	}
+
+	app.prompt = &inputPrompt{
+		label: fmt.Sprintf("Container for %s in %s (%s) [%s]:", msg.Purpose, msg.PodName, strings.Join(msg.Containers, ", "), msg.Containers[0]),
+		onSubmit: func(value string) tea.Cmd {
+			if value == "" {
+				value = msg.Containers[0]
+			}
+			for _, container := range msg.Containers {
+				if container == value {
+					return msg.Next(msg.PodName, msg.Namespace, container)
+				}
+			}
+			return func() tea.Msg {
+				return ErrorMsg{Error: fmt.Sprintf("Pod %s has no container %q", msg.PodName, value)}
+			}
+		},
+	}
+	return nil
+}
+
+// detectShells finds the shells installed in a container
+func (app *Application) detectShells(podName, namespace, container string) tea.Cmd {
+	client := app.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		shells, err := client.GetPodShells(ctx, namespace, podName, container)
+		if errors.Is(err, kubernetesclient.ErrNoShell) {
+			return NoShellMsg{PodName: podName, Namespace: namespace, Container: container}
+		}
+		if err != nil {
+			return ErrorMsg{Error: fmt.Sprintf("Failed to detect shells in %s/%s: %v", podName, container, err)}
+		}
+
+		return ShellReadyMsg{PodName: podName, Namespace: namespace, Container: container, Shells: shells}
+	}
+}
+
+// handleShellReady hands the terminal to an interactive shell in the pod
+func (app *Application) handleShellReady(msg ShellReadyMsg) tea.Cmd {
+	shell := &podShell{
+		client:    app.client,
+		namespace: msg.Namespace,
+		podName:   msg.PodName,
+		container: msg.Container,
+		shell:     msg.Shells[0],
+	}
+
+	return tea.Exec(shell, func(err error) tea.Msg {
+		if err != nil {
+			return ErrorMsg{Error: fmt.Sprintf(`Shell session to %s/%s failed: %v
+
+Tried shells: %v`, msg.PodName, msg.Container, err, msg.Shells)}
+		}
+		return InfoMsg{Info: fmt.Sprintf("Shell session to %s ended", msg.PodName)}
+	})
+}
+
+// podShell is an interactive shell in a pod, streamed through the API server.
+// It implements tea.ExecCommand so Bubble Tea releases the terminal while it runs.
+type podShell struct {
+	client    *kubernetesclient.KubernetesClient
+	namespace string
+	podName   string
+	container string
+	shell     string
+	attach    bool // attach to the container's main process instead of starting shell
+
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (s *podShell) SetStdin(r io.Reader)  { s.stdin = r }
+func (s *podShell) SetStdout(w io.Writer) { s.stdout = w }
+func (s *podShell) SetStderr(w io.Writer) { s.stderr = w }
+
+// Run puts the terminal in raw mode and streams the shell until it exits
+func (s *podShell) Run() error {
+	if s.stdin == nil {
+		s.stdin = os.Stdin
+	}
+	if s.stdout == nil {
+		s.stdout = os.Stdout
+	}
+
+	// The executor keeps reading stdin after the shell exits; cancelling the
+	// read hands the next key back to Bubble Tea instead of swallowing it
+	stdin, err := cancelreader.NewReader(s.stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read terminal input: %w", err)
+	}
+	defer stdin.Cancel()
+
+	if f, ok := s.stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		state, err := term.MakeRaw(int(f.Fd()))
+		if err != nil {
+			return fmt.Errorf("failed to set terminal to raw mode: %w", err)
+		}
+		defer term.Restore(int(f.Fd()), state)
+	}
+
+	sizes := newTerminalSizeQueue(s.stdout)
+	defer sizes.stop()
+
+	if s.attach {
+		fmt.Fprintf(s.stdout, "Attaching to %s/%s. If you don't see a command prompt, try pressing enter.\r\n", s.podName, s.container)
+		return s.client.AttachToPod(context.Background(), kubernetesclient.AttachOptions{
+			Namespace:         s.namespace,
+			PodName:           s.podName,
+			ContainerName:     s.container,
+			Stdin:             stdin,
+			Stdout:            s.stdout,
+			TTY:               true,
+			TerminalSizeQueue: sizes,
+		})
+	}
+
+	fmt.Fprintf(s.stdout, "Connecting to %s/%s (%s)...\r\n", s.podName, s.container, s.shell)
+
+	// With a TTY, stderr is merged into stdout by the container runtime
+	return s.client.CreateShell(context.Background(), kubernetesclient.ShellOptions{
+		Namespace:         s.namespace,
+		PodName:           s.podName,
+		ContainerName:     s.container,
+		Shell:             s.shell,
+		Stdin:             stdin,
+		Stdout:            s.stdout,
+		TTY:               true,
+		TerminalSizeQueue: sizes,
+	})
+}
+
+// terminalSizeQueue reports the local terminal size to the remote TTY: once
+// at start and again whenever the window is resized
+type terminalSizeQueue struct {
+	fd          int
+	resize      chan os.Signal
+	done        chan struct{}
+	sentInitial bool
+}
+
+// newTerminalSizeQueue watches the size of the terminal behind out
+func newTerminalSizeQueue(out io.Writer) *terminalSizeQueue {
+	q := &terminalSizeQueue{
+		fd:     -1,
+		resize: make(chan os.Signal, 1),
+		done:   make(chan struct{}),
+	}
+	if f, ok := out.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		q.fd = int(f.Fd())
+	}
+	notifyResize(q.resize)
+	return q
+}
+
+// Next blocks until the terminal size changes. It returns nil once the
+// session is over, which ends the resize stream.
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	if q.fd < 0 {
+		return nil
+	}
+
+	for {
+		if !q.sentInitial {
+			q.sentInitial = true
+		} else {
+			select {
+			case <-q.resize:
+			case <-q.done:
+				return nil
+			}
+		}
+
+		if width, height, err := term.GetSize(q.fd); err == nil {
+			return &remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}
+		}
+	}
+}
+
+// stop ends resize notifications
+func (q *terminalSizeQueue) stop() {
+	stopResize(q.resize)
+	close(q.done)
+}
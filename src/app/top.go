@@ -0,0 +1,349 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	metricscollector "github.com/anindyar/kuber/src/libraries/metrics-collector"
+)
+
+// TopOptions configures the top view and the printed top table
+type TopOptions struct {
+	Namespace  string // empty for all namespaces
+	SortBy     metricscollector.TopSortKey
+	Limit      int  // pods printed by PrintTop; 0 for all
+	Containers bool // PrintTop lists each pod's containers under it
+}
+
+// topState is the state of the top view
+type topState struct {
+	options    TopOptions
+	pods       []metricscollector.PodTop
+	pod        string // namespace/name of the pod drilled into, if any
+	loaded     bool
+	err        error
+	seq        int // ignores ticks and results of an earlier top view
+	returnView ViewType
+}
+
+// TopMsg carries the pods' usage for the top view
+type TopMsg struct {
+	Seq   int
+	Pods  []metricscollector.PodTop
+	Error error
+}
+
+// topTickMsg asks the top view to reload
+type topTickMsg struct{ Seq int }
+
+// topUsageTitles are the usage columns shared by pods and containers
+var topUsageTitles = []string{"CPU", "%CPU/R", "%CPU/L", "MEMORY", "%MEM/R", "%MEM/L", "RESTARTS"}
+
+// topColumns are the columns of the top view, for pods or for the
+// containers of one pod
+func topColumns(containers bool) []table.Column {
+	var columns []table.Column
+	if containers {
+		columns = append(columns, table.Column{Title: "Container", Width: 30})
+	} else {
+		columns = append(columns, table.Column{Title: "Namespace", Width: 18}, table.Column{Title: "Pod", Width: 40})
+	}
+	for _, title := range topUsageTitles {
+		columns = append(columns, table.Column{Title: title, Width: 9})
+	}
+	if !containers {
+		columns = append(columns, table.Column{Title: "Node", Width: 24})
+	}
+	return columns
+}
+
+// topUsageCells renders usage against requests and limits, e.g. 250m 50% -
+func topUsageCells(usage metricscollector.TopUsage, restarts int32) []string {
+	return []string{
+		formatCores(usage.CPU),
+		formatPercent(usage.CPURequestPercent()),
+		formatPercent(usage.CPULimitPercent()),
+		formatMebibytes(usage.Memory),
+		formatPercent(usage.MemoryRequestPercent()),
+		formatPercent(usage.MemoryLimitPercent()),
+		fmt.Sprintf("%d", restarts),
+	}
+}
+
+// topPodRow is a pod's row in the top view and printed table
+func topPodRow(pod metricscollector.PodTop) []string {
+	row := append([]string{pod.Namespace, pod.Name}, topUsageCells(pod.TopUsage, pod.Restarts)...)
+	return append(row, pod.Node)
+}
+
+// topContainerRow is a container's row in the top view
+func topContainerRow(container metricscollector.ContainerTop) []string {
+	return append([]string{container.Name}, topUsageCells(container.TopUsage, container.Restarts)...)
+}
+
+// formatCores renders CPU like kubectl top, e.g. 250m
+func formatCores(cores float64) string {
+	return fmt.Sprintf("%dm", int64(cores*1000+0.5))
+}
+
+// formatMebibytes renders memory like kubectl top, e.g. 128Mi
+func formatMebibytes(bytes float64) string {
+	return fmt.Sprintf("%dMi", int64(bytes/(1024*1024)+0.5))
+}
+
+// formatPercent renders a percentage of a request or limit; "-" when unset
+func formatPercent(percent float64) string {
+	if percent < 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", percent)
+}
+
+// StartInTop makes the application open on the top view, as ktop top does
+func (app *Application) StartInTop(options TopOptions) {
+	app.showTop(options)
+}
+
+// openTop switches to the top view for a namespace, or all namespaces when
+// it is empty
+func (app *Application) openTop(namespace string) tea.Cmd {
+	options := TopOptions{Namespace: namespace, SortBy: metricscollector.TopSortCPU}
+	if app.top != nil {
+		options.SortBy = app.top.options.SortBy
+	}
+	app.showTop(options)
+	return tea.Batch(app.loadTop(), app.tickTop())
+}
+
+// showTop sets up the top view
+func (app *Application) showTop(options TopOptions) {
+	if options.SortBy == "" {
+		options.SortBy = metricscollector.TopSortCPU
+	}
+	seq, returnView := 0, app.currentView
+	if app.top != nil {
+		seq = app.top.seq + 1
+		if app.currentView == ViewTop {
+			returnView = app.top.returnView
+		}
+	}
+	app.top = &topState{options: options, seq: seq, returnView: returnView}
+
+	app.topTable.SetRows(nil)
+	app.topTable.SetColumns(topColumns(false))
+	app.currentView = ViewTop
+	app.switchActiveComponent()
+}
+
+// topInterval is how often the top view reloads: the configured refresh
+// interval
+func (app *Application) topInterval() time.Duration {
+	if app.config.RefreshInterval > 0 {
+		return app.config.RefreshInterval
+	}
+	return 30 * time.Second
+}
+
+// loadTop fetches the pods' usage for the top view
+func (app *Application) loadTop() tea.Cmd {
+	client, namespace, seq := app.client, app.top.options.Namespace, app.top.seq
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		pods, err := metricscollector.TopPods(ctx, client, namespace)
+		return TopMsg{Seq: seq, Pods: pods, Error: err}
+	}
+}
+
+// tickTop schedules the next reload of the top view
+func (app *Application) tickTop() tea.Cmd {
+	seq := app.top.seq
+	return tea.Tick(app.topInterval(), func(time.Time) tea.Msg {
+		return topTickMsg{Seq: seq}
+	})
+}
+
+// handleTopTick reloads the top view while it is open
+func (app *Application) handleTopTick(msg topTickMsg) tea.Cmd {
+	if app.currentView != ViewTop || app.top == nil || app.top.seq != msg.Seq {
+		return nil
+	}
+	return tea.Batch(app.loadTop(), app.tickTop())
+}
+
+// handleTop shows freshly loaded usage; a failed reload keeps the last one
+func (app *Application) handleTop(msg TopMsg) {
+	if app.top == nil || app.top.seq != msg.Seq {
+		return
+	}
+
+	app.top.loaded = true
+	app.top.err = msg.Error
+	if msg.Error == nil {
+		app.top.pods = msg.Pods
+	}
+	app.refreshTopRows()
+}
+
+// cycleTopSort sorts the top view by the next sort key
+func (app *Application) cycleTopSort() {
+	keys := metricscollector.TopSortKeys
+	next := keys[0]
+	for i, key := range keys {
+		if key == app.top.options.SortBy {
+			next = keys[(i+1)%len(keys)]
+		}
+	}
+	app.top.options.SortBy = next
+	app.refreshTopRows()
+}
+
+// drillIntoTopPod shows the containers of the selected pod
+func (app *Application) drillIntoTopPod() {
+	if app.top.pod != "" {
+		return
+	}
+	cursor := app.topTable.GetSelectedIndex()
+	if cursor < 0 || cursor >= len(app.top.pods) {
+		return
+	}
+
+	pod := app.top.pods[cursor]
+	app.top.pod = pod.Namespace + "/" + pod.Name
+	app.topTable.SetRows(nil)
+	app.topTable.SetColumns(topColumns(true))
+	app.refreshTopRows()
+}
+
+// leaveTopPod goes back from a pod's containers to the pod list, reporting
+// whether there was a pod to leave
+func (app *Application) leaveTopPod() bool {
+	if app.top == nil || app.top.pod == "" {
+		return false
+	}
+	app.top.pod = ""
+	app.topTable.SetRows(nil)
+	app.topTable.SetColumns(topColumns(false))
+	app.refreshTopRows()
+	return true
+}
+
+// selectedTopPod returns the pod drilled into, or nil
+func (app *Application) selectedTopPod() *metricscollector.PodTop {
+	for i, pod := range app.top.pods {
+		if pod.Namespace+"/"+pod.Name == app.top.pod {
+			return &app.top.pods[i]
+		}
+	}
+	return nil
+}
+
+// refreshTopRows sorts the loaded usage and rebuilds the table rows
+func (app *Application) refreshTopRows() {
+	metricscollector.SortTop(app.top.pods, app.top.options.SortBy)
+
+	var rows []table.Row
+	if app.top.pod != "" {
+		if pod := app.selectedTopPod(); pod != nil {
+			for _, container := range pod.Containers {
+				rows = append(rows, topContainerRow(container))
+			}
+		}
+	} else {
+		for _, pod := range app.top.pods {
+			rows = append(rows, topPodRow(pod))
+		}
+	}
+	app.topTable.SetRows(rows)
+}
+
+// renderTopView renders the top view
+func (app *Application) renderTopView(height int) string {
+	var content strings.Builder
+	top := app.top
+
+	scope := "all namespaces"
+	if top.options.Namespace != "" {
+		scope = top.options.Namespace
+	}
+	title := fmt.Sprintf("📈 Top pods in %s", scope)
+	if top.pod != "" {
+		title = fmt.Sprintf("📈 Containers of %s", top.pod)
+	}
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+	content.WriteString(headerStyle.Render(fmt.Sprintf("%s, sorted by %s", title, top.options.SortBy)) + "\n")
+
+	app.topTable.SetSize(app.width, height-4)
+	content.WriteString(app.topTable.View() + "\n")
+
+	statusStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214"))
+	switch {
+	case top.err != nil:
+		content.WriteString(statusStyle.Foreground(lipgloss.Color("196")).Render(fmt.Sprintf("❌ %v (is metrics-server installed?)", top.err)) + "\n")
+	case !top.loaded:
+		content.WriteString(statusStyle.Render("Loading usage from metrics-server...") + "\n")
+	default:
+		content.WriteString(statusStyle.Render(fmt.Sprintf("%d pods, refreshed every %s", len(top.pods), app.topInterval())) + "\n")
+	}
+
+	hint := "Enter: Containers | s: Sort | r: Refresh | Esc: Back"
+	if top.pod != "" {
+		hint = "s: Sort | r: Refresh | Esc: Pods"
+	}
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8")).
+		Italic(true)
+	content.WriteString(hintStyle.Render(hint))
+
+	return content.String()
+}
+
+// PrintTop prints the top consumers once as a table, for ktop top --once
+func PrintTop(config *Config, w io.Writer, options TopOptions) error {
+	client, err := connectClient(config.KubeConfig, config.Context)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pods, err := metricscollector.TopPods(ctx, client, options.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get pod metrics: %w", err)
+	}
+	if options.SortBy == "" {
+		options.SortBy = metricscollector.TopSortCPU
+	}
+	metricscollector.SortTop(pods, options.SortBy)
+	if options.Limit > 0 && len(pods) > options.Limit {
+		pods = pods[:options.Limit]
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := append(append([]string{"NAMESPACE", "POD"}, topUsageTitles...), "NODE")
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, pod := range pods {
+		fmt.Fprintln(tw, strings.Join(topPodRow(pod), "\t"))
+		if !options.Containers {
+			continue
+		}
+		for _, container := range pod.Containers {
+			fmt.Fprintln(tw, "\t  └ "+strings.Join(topContainerRow(container), "\t")+"\t")
+		}
+	}
+	return tw.Flush()
+}
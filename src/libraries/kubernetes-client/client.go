@@ -4,21 +4,30 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
+	"sync"
 
 	"github.com/anindyar/kuber/src/models"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
 )
 
 // KubernetesClient provides access to Kubernetes cluster operations
 type KubernetesClient struct {
-	clientset *kubernetes.Clientset
-	config    *rest.Config
-	cluster   *models.Cluster
+	clientset     *kubernetes.Clientset
+	dynamicClient dynamic.Interface
+	resolver      ResourceResolver
+	config        *rest.Config
+	cluster       *models.Cluster
+
+	// Running port forwards by ID
+	forwards   map[string]*PortForward
+	forwardsMu sync.Mutex
+
+	// Whether the server has events.k8s.io/v1, detected on first use
+	eventsV1      bool
+	eventsAPIOnce sync.Once
 }
 
 // NewKubernetesClient creates a new Kubernetes client
@@ -37,10 +46,16 @@ func NewKubernetesClient(cluster *models.Cluster) (*KubernetesClient, error) {
 		return nil, fmt.Errorf("failed to create clientset: %w", err)
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
 	return &KubernetesClient{
-		clientset: clientset,
-		config:    config,
-		cluster:   cluster,
+		clientset:     clientset,
+		dynamicClient: dynamicClient,
+		config:        config,
+		cluster:       cluster,
 	}, nil
 }
 
@@ -52,30 +67,41 @@ func buildConfig(cluster *models.Cluster) (*rest.Config, error) {
 	}
 
 	// Build config from kubeconfig
-	var kubeconfigPath string
-	if cluster.Auth.Kubeconfig != "" {
-		kubeconfigPath = cluster.Auth.Kubeconfig
-	} else {
-		// Use default kubeconfig location
-		if home := homedir.HomeDir(); home != "" {
-			kubeconfigPath = filepath.Join(home, ".kube", "config")
+	kubeconfigPath := cluster.Auth.Kubeconfig
+	if kubeconfigPath != "" {
+		// Check if kubeconfig file exists
+		if _, err := os.Stat(kubeconfigPath); os.IsNotExist(err) {
+			return nil, fmt.Errorf("kubeconfig file not found at %s", kubeconfigPath)
 		}
 	}
 
-	// Check if kubeconfig file exists
-	if _, err := os.Stat(kubeconfigPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("kubeconfig file not found at %s", kubeconfigPath)
+	// Honor the requested context instead of the kubeconfig's current-context
+	contextName := cluster.Auth.Context
+	if contextName == "" {
+		contextName = cluster.Context
 	}
 
-	// Build config from kubeconfig
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	resolvedContext, kubeCluster, err := resolveContext(kubeconfigPath, contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := newClientConfig(kubeconfigPath, resolvedContext).ClientConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build config from kubeconfig: %w", err)
 	}
 
+	// Record which context and cluster we actually connected to
+	cluster.Context = resolvedContext
+	if cluster.Name == "" || cluster.Name == "default" {
+		cluster.Name = kubeCluster
+	}
+
 	// Override server URL if specified
 	if cluster.Endpoint != "" {
 		config.Host = cluster.Endpoint
+	} else {
+		cluster.Endpoint = config.Host
 	}
 
 	// Configure authentication
@@ -199,7 +225,8 @@ func (kc *KubernetesClient) GetResources(ctx context.Context, resourceType, name
 	case "persistentvolumeclaims":
 		return kc.getPersistentVolumeClaims(ctx, namespace)
 	default:
-		return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
+		// Fall back to the dynamic client for everything else, including CRDs
+		return kc.getDynamicResources(ctx, resourceType, namespace)
 	}
 }
 
@@ -207,6 +234,7 @@ func (kc *KubernetesClient) GetResources(ctx context.Context, resourceType, name
 func (kc *KubernetesClient) Close() error {
 	// Kubernetes client-go doesn't require explicit cleanup
 	// but we can nil out our references
+	kc.stopPortForwards()
 	kc.clientset = nil
 	kc.config = nil
 	return nil
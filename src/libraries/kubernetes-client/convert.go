@@ -0,0 +1,148 @@
+package kubernetesclient
+
+import (
+	"fmt"
+
+	"github.com/anindyar/kuber/src/models"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ConvertObject converts a typed or unstructured Kubernetes object, such as
+// one delivered by a watch, to our resource model
+func ConvertObject(obj runtime.Object) (*models.Resource, error) {
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		return convertKubernetesPod(o)
+	case *corev1.Service:
+		return convertKubernetesService(o)
+	case *corev1.ConfigMap:
+		return convertKubernetesConfigMap(o)
+	case *corev1.Secret:
+		return convertKubernetesSecret(o)
+	case *corev1.PersistentVolume:
+		return convertKubernetesPersistentVolume(o)
+	case *corev1.PersistentVolumeClaim:
+		return convertKubernetesPersistentVolumeClaim(o)
+	case *corev1.Node:
+		return convertKubernetesNode(o)
+	case *corev1.Namespace:
+		return convertNamespaceResource(o)
+	case *appsv1.Deployment:
+		return convertKubernetesDeployment(o)
+	case *appsv1.StatefulSet:
+		return convertKubernetesStatefulSet(o)
+	case *networkingv1.Ingress:
+		return convertKubernetesIngress(o)
+	case *unstructured.Unstructured:
+		return convertUnstructured(o)
+	default:
+		return nil, fmt.Errorf("unsupported object type %T", obj)
+	}
+}
+
+// convertNamespaceResource converts a namespace to the generic resource model
+// so namespace watch events can be handled like any other resource
+func convertNamespaceResource(ns *corev1.Namespace) (*models.Resource, error) {
+	namespace, err := convertKubernetesNamespace(ns)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := models.Metadata{
+		Name:              ns.Name,
+		UID:               string(ns.UID),
+		ResourceVersion:   ns.ResourceVersion,
+		Generation:        ns.Generation,
+		CreationTimestamp: ns.CreationTimestamp.Time,
+		Labels:            namespace.Labels,
+		Annotations:       namespace.Annotations,
+		DeletionTimestamp: namespace.DeletionTime,
+	}
+
+	resource, err := models.NewResource("Namespace", "v1", metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	resource.Status["phase"] = string(namespace.Status)
+	resource.UpdateAge()
+	resource.ComputeStatus()
+
+	return resource, nil
+}
+
+// convertOwnerReferences converts owner references to our model
+func convertOwnerReferences(refs []metav1.OwnerReference) []models.OwnerReference {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	owners := make([]models.OwnerReference, 0, len(refs))
+	for _, ref := range refs {
+		owners = append(owners, models.OwnerReference{
+			APIVersion:         ref.APIVersion,
+			Kind:               ref.Kind,
+			Name:               ref.Name,
+			UID:                string(ref.UID),
+			Controller:         ref.Controller,
+			BlockOwnerDeletion: ref.BlockOwnerDeletion,
+		})
+	}
+	return owners
+}
+
+// convertContainerStatuses converts container statuses, including how each
+// container's previous run ended
+func convertContainerStatuses(statuses []corev1.ContainerStatus, init bool) []models.ContainerState {
+	containers := make([]models.ContainerState, 0, len(statuses))
+	for _, status := range statuses {
+		container := models.ContainerState{
+			Name:         status.Name,
+			Init:         init,
+			Ready:        status.Ready,
+			RestartCount: status.RestartCount,
+		}
+
+		switch state := status.State; {
+		case state.Waiting != nil:
+			container.State = "waiting"
+			container.Reason = state.Waiting.Reason
+			container.Message = state.Waiting.Message
+		case state.Running != nil:
+			container.State = "running"
+			container.StartedAt = state.Running.StartedAt.Time
+		case state.Terminated != nil:
+			container.State = "terminated"
+			container.Reason = state.Terminated.Reason
+			container.Message = state.Terminated.Message
+			container.ExitCode = state.Terminated.ExitCode
+			container.StartedAt = state.Terminated.StartedAt.Time
+		}
+
+		if last := status.LastTerminationState.Terminated; last != nil {
+			container.LastReason = last.Reason
+			container.LastExitCode = last.ExitCode
+			container.LastFinishedAt = last.FinishedAt.Time
+		}
+
+		containers = append(containers, container)
+	}
+	return containers
+}
+
+// convertCondition builds a condition from the fields every condition type
+// shares
+func convertCondition(conditionType, status, reason, message string, lastTransition metav1.Time) models.Condition {
+	return models.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: lastTransition.Time,
+	}
+}
@@ -0,0 +1,512 @@
+package kubernetesclient
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// progressInterval limits how often copy progress is reported
+const progressInterval = 100 * time.Millisecond
+
+// CopyOptions selects the container to copy to or from
+type CopyOptions struct {
+	Namespace     string
+	PodName       string
+	ContainerName string
+	// Progress, if set, is called as data is copied and once at the end
+	Progress func(CopyProgress)
+}
+
+// CopyProgress reports how far a copy has got
+type CopyProgress struct {
+	File      string // file being copied, relative to the copy root
+	FileBytes int64  // bytes of File copied so far
+	FileSize  int64
+	Bytes     int64 // bytes copied in total
+	Files     int   // files finished
+	Done      bool
+}
+
+// PodFile is an entry of a directory listing in a container
+type PodFile struct {
+	Name       string
+	Mode       string // as printed by ls, e.g. "drwxr-xr-x"
+	Size       int64
+	Modified   string
+	IsDir      bool
+	LinkTarget string // set for symlinks
+}
+
+// IsLink reports whether the entry is a symbolic link
+func (f PodFile) IsLink() bool {
+	return strings.HasPrefix(f.Mode, "l")
+}
+
+// CopyFromPod copies a file or directory out of a container, like
+// `kubectl cp pod:remotePath localPath`. The container must have tar. If
+// localPath is an existing directory the copy is placed inside it.
+//
+// Archive entries that would land outside localPath, through "..", absolute
+// names or symlinks, are rejected.
+func (kc *KubernetesClient) CopyFromPod(ctx context.Context, opts CopyOptions, remotePath, localPath string) error {
+	remotePath = path.Clean(remotePath)
+	if remotePath == "/" || remotePath == "." {
+		return fmt.Errorf("refusing to copy %q: choose a file or directory", remotePath)
+	}
+
+	dest := localPath
+	if info, err := os.Stat(localPath); err == nil && info.IsDir() {
+		dest = filepath.Join(localPath, path.Base(remotePath))
+	}
+
+	reader, writer := io.Pipe()
+	var stderr bytes.Buffer
+
+	execErr := make(chan error, 1)
+	go func() {
+		err := kc.ExecInPod(ctx, ExecOptions{
+			Namespace:     opts.Namespace,
+			PodName:       opts.PodName,
+			ContainerName: opts.ContainerName,
+			Command:       []string{"tar", "cf", "-", "-C", path.Dir(remotePath), path.Base(remotePath)},
+			Stdout:        writer,
+			Stderr:        &stderr,
+		})
+		writer.CloseWithError(err)
+		execErr <- err
+	}()
+
+	progress := newProgressReporter(opts.Progress)
+	err := untarTo(reader, path.Base(remotePath), dest, progress)
+	if err != nil {
+		// Stop the remote tar instead of streaming the rest of the archive
+		reader.CloseWithError(err)
+	} else {
+		_, _ = io.Copy(io.Discard, reader)
+	}
+
+	if exitErr := <-execErr; err == nil {
+		err = exitErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy %s from pod %s: %w", remotePath, opts.PodName, withStderr(err, &stderr))
+	}
+
+	progress.finish()
+	return nil
+}
+
+// CopyToPod copies a local file or directory into a container, like
+// `kubectl cp localPath pod:remotePath`. The container must have tar. A
+// remotePath ending in "/" is a directory the copy is placed inside.
+func (kc *KubernetesClient) CopyToPod(ctx context.Context, opts CopyOptions, localPath, remotePath string) error {
+	info, err := os.Lstat(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", localPath, err)
+	}
+
+	remoteDir, remoteName := path.Split(remotePath)
+	if remoteName == "" {
+		remoteName = info.Name()
+	}
+	if remoteDir == "" {
+		remoteDir = "."
+	}
+	if remoteName == "." || remoteName == ".." {
+		return fmt.Errorf("invalid destination %q", remotePath)
+	}
+
+	progress := newProgressReporter(opts.Progress)
+	reader, writer := io.Pipe()
+	tarErr := make(chan error, 1)
+	go func() {
+		err := tarFrom(writer, localPath, remoteName, progress)
+		writer.CloseWithError(err)
+		tarErr <- err
+	}()
+
+	var stderr bytes.Buffer
+	err = kc.ExecInPod(ctx, ExecOptions{
+		Namespace:     opts.Namespace,
+		PodName:       opts.PodName,
+		ContainerName: opts.ContainerName,
+		Command:       []string{"tar", "xmf", "-", "-C", remoteDir},
+		Stdin:         reader,
+		Stdout:        io.Discard,
+		Stderr:        &stderr,
+	})
+	reader.Close()
+
+	// A local read error is the cause of whatever the remote tar reported
+	if localErr := <-tarErr; localErr != nil {
+		return fmt.Errorf("failed to copy %s to pod %s: %w", localPath, opts.PodName, localErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy %s to pod %s: %w", localPath, opts.PodName, withStderr(err, &stderr))
+	}
+
+	progress.finish()
+	return nil
+}
+
+// ListPodFiles lists a directory in a container with ls
+func (kc *KubernetesClient) ListPodFiles(ctx context.Context, namespace, podName, containerName, dir string) ([]PodFile, error) {
+	var stdout, stderr bytes.Buffer
+	err := kc.ExecInPod(ctx, ExecOptions{
+		Namespace:     namespace,
+		PodName:       podName,
+		ContainerName: containerName,
+		Command:       []string{"ls", "-lA", dir},
+		Stdout:        &stdout,
+		Stderr:        &stderr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", dir, withStderr(err, &stderr))
+	}
+
+	var files []PodFile
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if file, ok := parseLsLine(line); ok {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// parseLsLine parses a line of `ls -l` output from GNU coreutils or busybox:
+// mode links owner group size month day time|year name
+func parseLsLine(line string) (PodFile, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 9 || len(fields[0]) < 10 {
+		return PodFile{}, false
+	}
+
+	// Device files show "major, minor" instead of a size
+	sizeField := 4
+	if strings.HasSuffix(fields[4], ",") {
+		sizeField = 5
+		if len(fields) < 10 {
+			return PodFile{}, false
+		}
+	}
+	size, _ := strconv.ParseInt(fields[sizeField], 10, 64)
+
+	// The name is everything after the date, and may contain spaces
+	nameStart := 0
+	rest := line
+	for i := 0; i <= sizeField+3; i++ {
+		idx := strings.Index(rest, fields[i])
+		nameStart += idx + len(fields[i])
+		rest = line[nameStart:]
+	}
+	name := strings.TrimPrefix(rest, " ")
+
+	file := PodFile{
+		Mode:     fields[0],
+		Size:     size,
+		Modified: strings.Join(fields[sizeField+1:sizeField+4], " "),
+		IsDir:    strings.HasPrefix(fields[0], "d"),
+	}
+	if file.IsLink() {
+		name, file.LinkTarget, _ = strings.Cut(name, " -> ")
+	}
+	file.Name = name
+
+	return file, name != ""
+}
+
+// untarTo extracts a tar stream whose entries are all under root into dest,
+// renaming root to dest
+func untarTo(r io.Reader, root, dest string, progress *progressReporter) error {
+	dest, err := filepath.Abs(dest)
+	if err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		target, err := extractPath(dest, root, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := checkNoSymlinks(dest, target); err != nil {
+				return err
+			}
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := checkNoSymlinks(dest, filepath.Dir(target)); err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			if err := extractFile(tr, target, header, progress); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := checkLinkTarget(dest, target, header.Linkname); err != nil {
+				return err
+			}
+			if err := checkNoSymlinks(dest, filepath.Dir(target)); err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		default:
+			// Hard links, devices and fifos are not copied, as with kubectl cp
+		}
+	}
+
+	return nil
+}
+
+// extractPath maps an archive entry under root to a path under dest,
+// rejecting entries that would escape it
+func extractPath(dest, root, name string) (string, error) {
+	if filepath.IsAbs(name) || strings.HasPrefix(name, "/") {
+		return "", fmt.Errorf("archive entry %q has an absolute path", name)
+	}
+
+	clean := path.Clean(name)
+	rel := strings.TrimPrefix(strings.TrimPrefix(clean, root), "/")
+	if clean != root && !strings.HasPrefix(clean, root+"/") {
+		return "", fmt.Errorf("archive entry %q is outside %s", name, root)
+	}
+
+	target := filepath.Join(dest, filepath.FromSlash(rel))
+	if !withinDir(dest, target) {
+		return "", fmt.Errorf("archive entry %q escapes the destination", name)
+	}
+	return target, nil
+}
+
+// checkLinkTarget rejects symlinks that point outside dest
+func checkLinkTarget(dest, link, linkname string) error {
+	if filepath.IsAbs(linkname) {
+		return fmt.Errorf("symlink %s points to absolute path %s", link, linkname)
+	}
+	if !withinDir(dest, filepath.Join(filepath.Dir(link), linkname)) {
+		return fmt.Errorf("symlink %s points outside the destination", link)
+	}
+	return nil
+}
+
+// checkNoSymlinks rejects writing through a symlink extracted earlier: the
+// paths checked above are lexical, so a chain like x -> . then x/y -> ..
+// would otherwise lead out of dest. Every existing component of dir below
+// dest must be a real directory.
+func checkNoSymlinks(dest, dir string) error {
+	rel, err := filepath.Rel(dest, dir)
+	if err != nil {
+		return err
+	}
+	if rel == "." {
+		return nil
+	}
+
+	current := dest
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("archive entry under %s would be written through a symlink", current)
+		}
+	}
+	return nil
+}
+
+// withinDir reports whether target is dir or inside it
+func withinDir(dir, target string) bool {
+	rel, err := filepath.Rel(dir, target)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// extractFile writes one regular file from the archive. Existing symlinks
+// are removed first so a link planted in dest can't redirect the write.
+func extractFile(r io.Reader, target string, header *tar.Header, progress *progressReporter) error {
+	if info, err := os.Lstat(target); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode).Perm())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	progress.startFile(header.Name, header.Size)
+	if _, err := io.Copy(file, progress.reader(r)); err != nil {
+		return fmt.Errorf("failed to write %s: %w", target, err)
+	}
+	progress.endFile()
+	return nil
+}
+
+// tarFrom writes localPath as a tar stream whose root entry is named root
+func tarFrom(w io.Writer, localPath, root string, progress *progressReporter) error {
+	tw := tar.NewWriter(w)
+
+	err := filepath.Walk(localPath, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(localPath, file)
+		if err != nil {
+			return err
+		}
+		name := path.Join(root, filepath.ToSlash(rel))
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		progress.startFile(name, info.Size())
+		if _, err := io.Copy(tw, progress.reader(f)); err != nil {
+			return err
+		}
+		progress.endFile()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// withStderr adds what the command printed to stderr to an exec error
+func withStderr(err error, stderr *bytes.Buffer) error {
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return fmt.Errorf("%w: %s", err, msg)
+	}
+	return err
+}
+
+// progressReporter tracks copy progress and reports it at most every
+// progressInterval. A nil reporter does nothing.
+type progressReporter struct {
+	report     func(CopyProgress)
+	progress   CopyProgress
+	lastReport time.Time
+}
+
+// newProgressReporter returns a reporter calling report, or nil if report is nil
+func newProgressReporter(report func(CopyProgress)) *progressReporter {
+	if report == nil {
+		return nil
+	}
+	return &progressReporter{report: report}
+}
+
+func (p *progressReporter) startFile(name string, size int64) {
+	if p == nil {
+		return
+	}
+	p.progress.File = name
+	p.progress.FileBytes = 0
+	p.progress.FileSize = size
+}
+
+func (p *progressReporter) endFile() {
+	if p == nil {
+		return
+	}
+	p.progress.Files++
+}
+
+func (p *progressReporter) finish() {
+	if p == nil {
+		return
+	}
+	p.progress.Done = true
+	p.report(p.progress)
+}
+
+// reader counts the bytes read from r
+func (p *progressReporter) reader(r io.Reader) io.Reader {
+	if p == nil {
+		return r
+	}
+	return &progressReader{Reader: r, progress: p}
+}
+
+func (p *progressReporter) add(n int) {
+	p.progress.FileBytes += int64(n)
+	p.progress.Bytes += int64(n)
+	if now := time.Now(); now.Sub(p.lastReport) >= progressInterval {
+		p.lastReport = now
+		p.report(p.progress)
+	}
+}
+
+// progressReader reports the bytes read through it
+type progressReader struct {
+	io.Reader
+	progress *progressReporter
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.Reader.Read(b)
+	r.progress.add(n)
+	return n, err
+}
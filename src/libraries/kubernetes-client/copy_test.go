@@ -0,0 +1,104 @@
+package kubernetesclient
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// tarEntry is one entry of a test archive
+type tarEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+	body     string
+}
+
+// buildTar returns an archive of the given entries
+func buildTar(t *testing.T, entries []tarEntry) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, entry := range entries {
+		header := &tar.Header{
+			Name:     entry.name,
+			Typeflag: entry.typeflag,
+			Linkname: entry.linkname,
+			Mode:     0o644,
+			Size:     int64(len(entry.body)),
+		}
+		if entry.typeflag == tar.TypeDir {
+			header.Mode = 0o755
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(entry.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestUntarToRejectsSymlinkChains(t *testing.T) {
+	parent := t.TempDir()
+	dest := filepath.Join(parent, "dest")
+
+	archive := buildTar(t, []tarEntry{
+		{name: "root/", typeflag: tar.TypeDir},
+		{name: "root/x", typeflag: tar.TypeSymlink, linkname: "."},
+		{name: "root/x/y", typeflag: tar.TypeSymlink, linkname: ".."},
+		{name: "root/x/y/evil", typeflag: tar.TypeReg, body: "evil"},
+	})
+
+	if err := untarTo(archive, "root", dest, nil); err == nil {
+		t.Fatal("expected an error extracting through a symlink chain")
+	}
+	if _, err := os.Lstat(filepath.Join(parent, "evil")); !os.IsNotExist(err) {
+		t.Fatalf("file was written outside the destination: %v", err)
+	}
+}
+
+func TestUntarToRejectsDirectoryThroughSymlink(t *testing.T) {
+	parent := t.TempDir()
+	dest := filepath.Join(parent, "dest")
+
+	archive := buildTar(t, []tarEntry{
+		{name: "root/", typeflag: tar.TypeDir},
+		{name: "root/x", typeflag: tar.TypeSymlink, linkname: "."},
+		{name: "root/x/sub/", typeflag: tar.TypeDir},
+	})
+
+	if err := untarTo(archive, "root", dest, nil); err == nil {
+		t.Fatal("expected an error creating a directory through a symlink")
+	}
+}
+
+func TestUntarToExtractsLinksWithinDestination(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "dest")
+
+	archive := buildTar(t, []tarEntry{
+		{name: "root/", typeflag: tar.TypeDir},
+		{name: "root/data/", typeflag: tar.TypeDir},
+		{name: "root/data/file.txt", typeflag: tar.TypeReg, body: "hello"},
+		{name: "root/current", typeflag: tar.TypeSymlink, linkname: "data"},
+	})
+
+	if err := untarTo(archive, "root", dest, nil); err != nil {
+		t.Fatalf("untarTo: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dest, "current", "file.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "hello" {
+		t.Errorf("content = %q, want %q", content, "hello")
+	}
+}
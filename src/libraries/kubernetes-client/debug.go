@@ -0,0 +1,124 @@
+package kubernetesclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/util/retry"
+)
+
+// DefaultDebugImage is the image used for debug containers when none is given
+const DefaultDebugImage = "busybox:1.36"
+
+// debugStartPollInterval is how often a starting debug container is checked
+const debugStartPollInterval = time.Second
+
+// DebugOptions describes an ephemeral debug container to add to a pod
+type DebugOptions struct {
+	Namespace       string
+	PodName         string
+	Image           string // defaults to DefaultDebugImage
+	TargetContainer string // container whose process namespace is shared, if any
+	Name            string // defaults to a generated debugger-xxxxx name
+}
+
+// CreateDebugContainer adds an interactive ephemeral container to a running
+// pod through the pods/ephemeralcontainers subresource, like `kubectl debug`.
+// It returns the container's name; use WaitForDebugContainer before attaching.
+// Ephemeral containers can't be removed again; they stay until the pod is deleted.
+func (kc *KubernetesClient) CreateDebugContainer(ctx context.Context, opts DebugOptions) (string, error) {
+	if kc.clientset == nil {
+		return "", fmt.Errorf("client not initialized")
+	}
+	if opts.Image == "" {
+		opts.Image = DefaultDebugImage
+	}
+	if opts.Name == "" {
+		opts.Name = "debugger-" + utilrand.String(5)
+	}
+
+	pods := kc.clientset.CoreV1().Pods(opts.Namespace)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pod, err := pods.Get(ctx, opts.PodName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if pod.Status.Phase != corev1.PodRunning {
+			return fmt.Errorf("pod %s is %s, debug containers need a running pod", opts.PodName, pod.Status.Phase)
+		}
+
+		pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+			EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+				Name:                     opts.Name,
+				Image:                    opts.Image,
+				ImagePullPolicy:          corev1.PullIfNotPresent,
+				Stdin:                    true,
+				TTY:                      true,
+				TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+			},
+			TargetContainerName: opts.TargetContainer,
+		})
+
+		_, err = pods.UpdateEphemeralContainers(ctx, opts.PodName, pod, metav1.UpdateOptions{})
+		return err
+	})
+	if errors.IsNotFound(err) {
+		return "", fmt.Errorf("failed to add debug container to %s: pod not found, or the cluster does not support ephemeral containers (Kubernetes 1.23+): %w", opts.PodName, err)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to add debug container to %s: %w", opts.PodName, err)
+	}
+
+	return opts.Name, nil
+}
+
+// WaitForDebugContainer waits until an ephemeral container is running. It
+// fails early when the container can't start, e.g. because its image can't
+// be pulled.
+func (kc *KubernetesClient) WaitForDebugContainer(ctx context.Context, namespace, podName, containerName string) error {
+	ticker := time.NewTicker(debugStartPollInterval)
+	defer ticker.Stop()
+
+	for {
+		pod, err := kc.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get pod %s: %w", podName, err)
+		}
+
+		for _, status := range pod.Status.EphemeralContainerStatuses {
+			if status.Name != containerName {
+				continue
+			}
+			switch {
+			case status.State.Running != nil:
+				return nil
+			case status.State.Terminated != nil:
+				return fmt.Errorf("debug container %s exited: %s", containerName, status.State.Terminated.Reason)
+			case status.State.Waiting != nil && isStartFailure(status.State.Waiting.Reason):
+				return fmt.Errorf("debug container %s can't start: %s: %s", containerName, status.State.Waiting.Reason, status.State.Waiting.Message)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("debug container %s did not start: %w", containerName, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// isStartFailure reports whether a waiting reason means the container won't
+// start without intervention
+func isStartFailure(reason string) bool {
+	switch reason {
+	case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "ErrImageNeverPull",
+		"CreateContainerConfigError", "CreateContainerError", "RunContainerError":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package kubernetesclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/anindyar/kuber/src/models"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ResourceResolver maps a resource type name as typed by the user (plural,
+// short name or plural.group) to its GroupVersionResource and reports
+// whether the resource is namespaced
+type ResourceResolver func(ctx context.Context, resourceType string) (schema.GroupVersionResource, bool, error)
+
+// SetResourceResolver registers the resolver used by GetResources and
+// DeleteResource for types that have no typed client, such as CRDs
+func (kc *KubernetesClient) SetResourceResolver(resolver ResourceResolver) {
+	kc.resolver = resolver
+}
+
+// getDynamicResources resolves a resource type and lists it through the dynamic client
+func (kc *KubernetesClient) getDynamicResources(ctx context.Context, resourceType, namespace string) ([]*models.Resource, error) {
+	if kc.resolver == nil {
+		return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+
+	gvr, namespaced, err := kc.resolver(ctx, resourceType)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported resource type %s: %w", resourceType, err)
+	}
+
+	return kc.GetDynamicResources(ctx, gvr, namespaced, namespace)
+}
+
+// GetDynamicResources lists any resource by GroupVersionResource using the
+// dynamic client. Cluster-scoped resources ignore the namespace.
+func (kc *KubernetesClient) GetDynamicResources(ctx context.Context, gvr schema.GroupVersionResource, namespaced bool, namespace string) ([]*models.Resource, error) {
+	if kc.dynamicClient == nil {
+		return nil, fmt.Errorf("dynamic client not initialized")
+	}
+
+	var list *unstructured.UnstructuredList
+	var err error
+	if namespaced {
+		list, err = kc.dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	} else {
+		list, err = kc.dynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", gvr.String(), err)
+	}
+
+	var resources []*models.Resource
+	for i := range list.Items {
+		resource, err := convertUnstructured(&list.Items[i])
+		if err != nil {
+			continue // Skip invalid resources
+		}
+		resources = append(resources, resource)
+	}
+
+	return resources, nil
+}
+
+// deleteDynamicResource resolves a resource type and deletes a resource of
+// it through the dynamic client
+func (kc *KubernetesClient) deleteDynamicResource(ctx context.Context, resourceType, namespace, name string) error {
+	if kc.resolver == nil {
+		return fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+
+	gvr, namespaced, err := kc.resolver(ctx, resourceType)
+	if err != nil {
+		return fmt.Errorf("unsupported resource type %s: %w", resourceType, err)
+	}
+
+	return kc.DeleteDynamicResource(ctx, gvr, namespaced, namespace, name)
+}
+
+// DeleteDynamicResource deletes any resource by GroupVersionResource using
+// the dynamic client. Cluster-scoped resources ignore the namespace.
+func (kc *KubernetesClient) DeleteDynamicResource(ctx context.Context, gvr schema.GroupVersionResource, namespaced bool, namespace, name string) error {
+	if kc.dynamicClient == nil {
+		return fmt.Errorf("dynamic client not initialized")
+	}
+
+	var err error
+	if namespaced {
+		err = kc.dynamicClient.Resource(gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	} else {
+		err = kc.dynamicClient.Resource(gvr).Delete(ctx, name, metav1.DeleteOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete %s %s: %w", gvr.String(), name, err)
+	}
+	return nil
+}
+
+// convertUnstructured converts an unstructured object to our Resource model
+func convertUnstructured(obj *unstructured.Unstructured) (*models.Resource, error) {
+	metadata := models.Metadata{
+		Name:              obj.GetName(),
+		Namespace:         obj.GetNamespace(),
+		UID:               string(obj.GetUID()),
+		OwnerReferences:   convertOwnerReferences(obj.GetOwnerReferences()),
+		ResourceVersion:   obj.GetResourceVersion(),
+		Generation:        obj.GetGeneration(),
+		CreationTimestamp: obj.GetCreationTimestamp().Time,
+		Labels:            make(map[string]string),
+		Annotations:       make(map[string]string),
+		Finalizers:        obj.GetFinalizers(),
+	}
+
+	if deletion := obj.GetDeletionTimestamp(); deletion != nil {
+		t := deletion.Time
+		metadata.DeletionTimestamp = &t
+	}
+
+	resource, err := models.NewResource(obj.GetKind(), obj.GetAPIVersion(), metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range obj.GetLabels() {
+		resource.Metadata.Labels[k] = v
+	}
+	for k, v := range obj.GetAnnotations() {
+		resource.Metadata.Annotations[k] = v
+	}
+
+	if spec, ok := obj.Object["spec"].(map[string]interface{}); ok {
+		resource.Spec = spec
+	}
+
+	// Keep the raw status so conditions can be evaluated generically
+	if status, ok := obj.Object["status"].(map[string]interface{}); ok {
+		for k, v := range status {
+			resource.Status[k] = v
+		}
+	}
+
+	if ready, ok := conditionStatus(resource.Status, "Ready"); ok {
+		resource.Status["ready"] = ready
+	}
+
+	// Resources without a status.phase get one derived from their conditions,
+	// so tables can show a meaningful status column
+	if _, ok := resource.Status["phase"].(string); !ok {
+		resource.Status["phase"] = string(resource.ComputeStatus())
+	}
+
+	// Update computed fields
+	resource.UpdateAge()
+	resource.ComputeStatus()
+
+	return resource, nil
+}
+
+// conditionStatus returns the status of the named condition in status.conditions
+func conditionStatus(status map[string]interface{}, conditionType string) (string, bool) {
+	conditions, ok := status["conditions"].([]interface{})
+	if !ok {
+		return "", false
+	}
+
+	for _, cond := range conditions {
+		condMap, ok := cond.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if t, ok := condMap["type"].(string); ok && strings.EqualFold(t, conditionType) {
+			s, _ := condMap["status"].(string)
+			return s, true
+		}
+	}
+	return "", false
+}
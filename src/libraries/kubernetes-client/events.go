@@ -0,0 +1,253 @@
+package kubernetesclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anindyar/kuber/src/models"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+// EventQuery selects events. Empty fields match everything, so a zero query
+// returns every event in the cluster.
+type EventQuery struct {
+	Namespace string // namespace the events are in
+	Kind      string // kind of the object the events are about, e.g. Pod
+	Name      string
+	UID       string
+	Reason    string // e.g. Unhealthy
+}
+
+// GetEvents lists the events matching the query, oldest first. It uses the
+// events.k8s.io/v1 API when the server has it and core/v1 otherwise.
+func (kc *KubernetesClient) GetEvents(ctx context.Context, query EventQuery) ([]models.Event, error) {
+	if kc.clientset == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	options := metav1.ListOptions{FieldSelector: kc.eventFieldSelector(query)}
+
+	var events []models.Event
+	if kc.hasEventsV1() {
+		list, err := kc.clientset.EventsV1().Events(query.Namespace).List(ctx, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list events: %w", err)
+		}
+		for i := range list.Items {
+			events = append(events, convertEventsV1Event(&list.Items[i]))
+		}
+	} else {
+		list, err := kc.clientset.CoreV1().Events(query.Namespace).List(ctx, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list events: %w", err)
+		}
+		for i := range list.Items {
+			events = append(events, convertCoreEvent(&list.Items[i]))
+		}
+	}
+
+	sortEventsByTime(events)
+	return events, nil
+}
+
+// WatchEvents sends the events matching the query to eventChan as they are
+// created or updated, starting with the existing ones, until ctx is
+// cancelled. Updates of a repeated event carry the same UID with a higher
+// count.
+func (kc *KubernetesClient) WatchEvents(ctx context.Context, query EventQuery, eventChan chan<- *models.Event) error {
+	if kc.clientset == nil {
+		return fmt.Errorf("client not initialized")
+	}
+
+	var restClient rest.Interface = kc.clientset.CoreV1().RESTClient()
+	var objType runtime.Object = &corev1.Event{}
+	if kc.hasEventsV1() {
+		restClient, objType = kc.clientset.EventsV1().RESTClient(), &eventsv1.Event{}
+	}
+
+	fieldSelector := kc.eventFieldSelector(query)
+	lw := cache.NewFilteredListWatchFromClient(restClient, "events", query.Namespace,
+		func(options *metav1.ListOptions) {
+			options.FieldSelector = fieldSelector
+		})
+	informer := cache.NewSharedIndexInformer(lw, objType, 0, cache.Indexers{})
+
+	// The default handler logs to stderr; the informer retries on its own
+	if err := informer.SetWatchErrorHandler(func(*cache.Reflector, error) {}); err != nil {
+		return fmt.Errorf("failed to set event watch error handler: %w", err)
+	}
+
+	send := func(obj interface{}) {
+		var event models.Event
+		switch e := obj.(type) {
+		case *eventsv1.Event:
+			event = convertEventsV1Event(e)
+		case *corev1.Event:
+			event = convertCoreEvent(e)
+		default:
+			return
+		}
+
+		select {
+		case eventChan <- &event:
+		case <-ctx.Done():
+		}
+	}
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    send,
+		UpdateFunc: func(_, obj interface{}) { send(obj) },
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch events: %w", err)
+	}
+
+	informer.Run(ctx.Done())
+	return ctx.Err()
+}
+
+// hasEventsV1 reports whether the server serves events.k8s.io/v1 (Kubernetes
+// 1.19+). The answer is cached for the life of the client.
+func (kc *KubernetesClient) hasEventsV1() bool {
+	kc.eventsAPIOnce.Do(func() {
+		_, err := kc.clientset.Discovery().ServerResourcesForGroupVersion(eventsv1.SchemeGroupVersion.String())
+		kc.eventsV1 = err == nil
+	})
+	return kc.eventsV1
+}
+
+// eventFieldSelector builds the field selector for a query. events.k8s.io/v1
+// calls the object "regarding" where core/v1 calls it "involvedObject".
+func (kc *KubernetesClient) eventFieldSelector(query EventQuery) string {
+	prefix := "involvedObject."
+	if kc.hasEventsV1() {
+		prefix = "regarding."
+	}
+
+	var selectors []fields.Selector
+	for _, field := range [][2]string{{"kind", query.Kind}, {"name", query.Name}, {"uid", query.UID}} {
+		if field[1] != "" {
+			selectors = append(selectors, fields.OneTermEqualSelector(prefix+field[0], field[1]))
+		}
+	}
+	if query.Reason != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("reason", query.Reason))
+	}
+	if len(selectors) == 0 {
+		return ""
+	}
+	return fields.AndSelectors(selectors...).String()
+}
+
+// convertEventsV1Event converts an events.k8s.io/v1 event. Events written
+// through core/v1 come back with only the deprecated fields set.
+func convertEventsV1Event(e *eventsv1.Event) models.Event {
+	event := models.Event{
+		UID:       string(e.UID),
+		Namespace: e.Namespace,
+		Object: models.EventObject{
+			Kind:      e.Regarding.Kind,
+			Name:      e.Regarding.Name,
+			Namespace: e.Regarding.Namespace,
+			UID:       string(e.Regarding.UID),
+		},
+		Type:               e.Type,
+		Reason:             e.Reason,
+		Message:            strings.TrimSpace(e.Note),
+		Source:             e.ReportingController,
+		FirstTimestamp:     e.DeprecatedFirstTimestamp.Time,
+		LastTimestamp:      e.DeprecatedLastTimestamp.Time,
+		Count:              e.DeprecatedCount,
+		ReportingComponent: e.ReportingController,
+		ReportingInstance:  e.ReportingInstance,
+	}
+
+	if event.Source == "" {
+		event.Source = e.DeprecatedSource.Component
+	}
+	if event.FirstTimestamp.IsZero() {
+		event.FirstTimestamp = eventTime(e.EventTime, e.CreationTimestamp)
+	}
+	if e.Series != nil {
+		event.Count = e.Series.Count
+		if !e.Series.LastObservedTime.IsZero() {
+			event.LastTimestamp = e.Series.LastObservedTime.Time
+		}
+	}
+	if event.LastTimestamp.IsZero() {
+		event.LastTimestamp = event.FirstTimestamp
+	}
+	if event.Count < 1 {
+		event.Count = 1
+	}
+
+	return event
+}
+
+// convertCoreEvent converts a core/v1 event
+func convertCoreEvent(e *corev1.Event) models.Event {
+	event := models.Event{
+		UID:       string(e.UID),
+		Namespace: e.Namespace,
+		Object: models.EventObject{
+			Kind:      e.InvolvedObject.Kind,
+			Name:      e.InvolvedObject.Name,
+			Namespace: e.InvolvedObject.Namespace,
+			UID:       string(e.InvolvedObject.UID),
+		},
+		Type:               e.Type,
+		Reason:             e.Reason,
+		Message:            strings.TrimSpace(e.Message),
+		Source:             e.Source.Component,
+		FirstTimestamp:     e.FirstTimestamp.Time,
+		LastTimestamp:      e.LastTimestamp.Time,
+		Count:              e.Count,
+		ReportingComponent: e.ReportingController,
+		ReportingInstance:  e.ReportingInstance,
+	}
+
+	if event.Source == "" {
+		event.Source = e.ReportingController
+	}
+	if event.FirstTimestamp.IsZero() {
+		event.FirstTimestamp = eventTime(e.EventTime, e.CreationTimestamp)
+	}
+	if e.Series != nil {
+		event.Count = e.Series.Count
+		if !e.Series.LastObservedTime.IsZero() {
+			event.LastTimestamp = e.Series.LastObservedTime.Time
+		}
+	}
+	if event.LastTimestamp.IsZero() {
+		event.LastTimestamp = event.FirstTimestamp
+	}
+	if event.Count < 1 {
+		event.Count = 1
+	}
+
+	return event
+}
+
+// eventTime returns when an event happened, falling back to when it was stored
+func eventTime(eventTime metav1.MicroTime, created metav1.Time) time.Time {
+	if !eventTime.IsZero() {
+		return eventTime.Time
+	}
+	return created.Time
+}
+
+// sortEventsByTime sorts events oldest first by when they were last seen
+func sortEventsByTime(events []models.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastTimestamp.Before(events[j].LastTimestamp)
+	})
+}
@@ -1,13 +1,18 @@
 package kubernetesclient
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/kubectl/pkg/scheme"
 )
@@ -22,6 +27,8 @@ type ExecOptions struct {
 	Stdout        io.Writer
 	Stderr        io.Writer
 	TTY           bool
+	// TerminalSizeQueue reports terminal resizes to the remote TTY
+	TerminalSizeQueue remotecommand.TerminalSizeQueue
 }
 
 // ExecInPod executes a command in a pod
@@ -60,17 +67,18 @@ func (kc *KubernetesClient) ExecInPod(ctx context.Context, opts ExecOptions) err
 	}, scheme.ParameterCodec)
 
 	// Create executor
-	exec, err := remotecommand.NewSPDYExecutor(kc.config, http.MethodPost, req.URL())
+	exec, err := kc.newExecutor(req.URL())
 	if err != nil {
 		return fmt.Errorf("failed to create executor: %w", err)
 	}
 
 	// Execute command
 	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdin:  opts.Stdin,
-		Stdout: opts.Stdout,
-		Stderr: opts.Stderr,
-		Tty:    opts.TTY,
+		Stdin:             opts.Stdin,
+		Stdout:            opts.Stdout,
+		Stderr:            opts.Stderr,
+		Tty:               opts.TTY,
+		TerminalSizeQueue: opts.TerminalSizeQueue,
 	})
 
 	if err != nil {
@@ -80,6 +88,77 @@ func (kc *KubernetesClient) ExecInPod(ctx context.Context, opts ExecOptions) err
 	return nil
 }
 
+// newExecutor returns an executor that speaks WebSocket, falling back to
+// SPDY for API servers that don't support it yet (as kubectl does)
+func (kc *KubernetesClient) newExecutor(execURL *url.URL) (remotecommand.Executor, error) {
+	spdyExec, err := remotecommand.NewSPDYExecutor(kc.config, http.MethodPost, execURL)
+	if err != nil {
+		return nil, err
+	}
+
+	websocketExec, err := remotecommand.NewWebSocketExecutor(kc.config, http.MethodGet, execURL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return remotecommand.NewFallbackExecutor(websocketExec, spdyExec, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+}
+
+// AttachOptions represents options for attaching to a running container
+type AttachOptions struct {
+	Namespace     string
+	PodName       string
+	ContainerName string
+	Stdin         io.Reader
+	Stdout        io.Writer
+	Stderr        io.Writer
+	TTY           bool
+	// TerminalSizeQueue reports terminal resizes to the remote TTY
+	TerminalSizeQueue remotecommand.TerminalSizeQueue
+}
+
+// AttachToPod attaches to the main process of a running container, like
+// `kubectl attach -it`
+func (kc *KubernetesClient) AttachToPod(ctx context.Context, opts AttachOptions) error {
+	if kc.clientset == nil {
+		return fmt.Errorf("client not initialized")
+	}
+
+	req := kc.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(opts.PodName).
+		Namespace(opts.Namespace).
+		SubResource("attach")
+
+	req.VersionedParams(&corev1.PodAttachOptions{
+		Container: opts.ContainerName,
+		Stdin:     opts.Stdin != nil,
+		Stdout:    opts.Stdout != nil,
+		Stderr:    opts.Stderr != nil,
+		TTY:       opts.TTY,
+	}, scheme.ParameterCodec)
+
+	attach, err := kc.newExecutor(req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create attach executor: %w", err)
+	}
+
+	err = attach.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:             opts.Stdin,
+		Stdout:            opts.Stdout,
+		Stderr:            opts.Stderr,
+		Tty:               opts.TTY,
+		TerminalSizeQueue: opts.TerminalSizeQueue,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to attach to %s/%s: %w", opts.PodName, opts.ContainerName, err)
+	}
+
+	return nil
+}
+
 // ShellOptions represents options for creating a shell session
 type ShellOptions struct {
 	Namespace     string
@@ -90,6 +169,8 @@ type ShellOptions struct {
 	Stdout        io.Writer
 	Stderr        io.Writer
 	TTY           bool
+	// TerminalSizeQueue reports terminal resizes to the remote TTY
+	TerminalSizeQueue remotecommand.TerminalSizeQueue
 }
 
 // CreateShell creates an interactive shell session with a pod
@@ -103,14 +184,15 @@ func (kc *KubernetesClient) CreateShell(ctx context.Context, opts ShellOptions)
 
 	for _, shell := range shells {
 		execOpts := ExecOptions{
-			Namespace:     opts.Namespace,
-			PodName:       opts.PodName,
-			ContainerName: opts.ContainerName,
-			Command:       []string{shell},
-			Stdin:         opts.Stdin,
-			Stdout:        opts.Stdout,
-			Stderr:        opts.Stderr,
-			TTY:           opts.TTY,
+			Namespace:         opts.Namespace,
+			PodName:           opts.PodName,
+			ContainerName:     opts.ContainerName,
+			Command:           []string{shell},
+			Stdin:             opts.Stdin,
+			Stdout:            opts.Stdout,
+			Stderr:            opts.Stderr,
+			TTY:               opts.TTY,
+			TerminalSizeQueue: opts.TerminalSizeQueue,
 		}
 
 		err := kc.ExecInPod(ctx, execOpts)
@@ -187,11 +269,35 @@ func isNotFoundError(err error) bool {
 	return contains(errStr, "not found") || contains(errStr, "NotFound")
 }
 
+// commonShells are the shells GetPodShells looks for, in order of preference
+var commonShells = []string{"/bin/bash", "/bin/sh", "/bin/ash", "/bin/zsh", "/bin/dash"}
+
+// ErrNoShell is returned by GetPodShells when a container has no shell
+var ErrNoShell = errors.New("no shell found in container")
+
 // GetPodShells returns available shells for a pod
 func (kc *KubernetesClient) GetPodShells(ctx context.Context, namespace, podName, containerName string) ([]string, error) {
-	availableShells := []string{}
-	commonShells := []string{"/bin/bash", "/bin/sh", "/bin/ash", "/bin/zsh", "/bin/dash"}
+	// Probe every shell in one round trip when the container has a POSIX sh
+	var stdout bytes.Buffer
+	probe := "for s in " + strings.Join(commonShells, " ") + "; do [ -x \"$s\" ] && echo \"$s\"; done; true"
+	err := kc.ExecInPod(ctx, ExecOptions{
+		Namespace:     namespace,
+		PodName:       podName,
+		ContainerName: containerName,
+		Command:       []string{"/bin/sh", "-c", probe},
+		Stdout:        &stdout,
+	})
+	if err == nil {
+		if shells := strings.Fields(stdout.String()); len(shells) > 0 {
+			return shells, nil
+		}
+	} else if ctx.Err() != nil {
+		return nil, ctx.Err()
+	} else if !isShellNotFoundError(err) {
+		return nil, fmt.Errorf("failed to probe shells: %w", err)
+	}
 
+	availableShells := []string{}
 	for _, shell := range commonShells {
 		// Test if shell exists by running a simple command
 		testOpts := ExecOptions{
@@ -208,9 +314,9 @@ func (kc *KubernetesClient) GetPodShells(ctx context.Context, namespace, podName
 		}
 	}
 
-	// If no shells found, assume /bin/sh exists
+	// Distroless images have no shell at all; a debug container is the way in
 	if len(availableShells) == 0 {
-		availableShells = append(availableShells, "/bin/sh")
+		return nil, ErrNoShell
 	}
 
 	return availableShells, nil
@@ -0,0 +1,89 @@
+package kubernetesclient
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// KubeContext describes a context entry in a kubeconfig file
+type KubeContext struct {
+	Name      string
+	Cluster   string
+	User      string
+	Namespace string
+	Current   bool
+}
+
+// ListContexts returns all contexts defined in the kubeconfig, sorted by name.
+// An empty path uses the default loading rules ($KUBECONFIG, ~/.kube/config).
+func ListContexts(kubeconfigPath string) ([]KubeContext, error) {
+	rawConfig, err := loadRawConfig(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var contexts []KubeContext
+	for name, ctx := range rawConfig.Contexts {
+		contexts = append(contexts, KubeContext{
+			Name:      name,
+			Cluster:   ctx.Cluster,
+			User:      ctx.AuthInfo,
+			Namespace: ctx.Namespace,
+			Current:   name == rawConfig.CurrentContext,
+		})
+	}
+
+	sort.Slice(contexts, func(i, j int) bool {
+		return contexts[i].Name < contexts[j].Name
+	})
+
+	return contexts, nil
+}
+
+// newClientConfig builds a deferred kubeconfig loader honoring an optional
+// context override
+func newClientConfig(kubeconfigPath, contextName string) clientcmd.ClientConfig {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfigPath != "" {
+		loadingRules.ExplicitPath = kubeconfigPath
+	}
+
+	overrides := &clientcmd.ConfigOverrides{}
+	if contextName != "" {
+		overrides.CurrentContext = contextName
+	}
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+}
+
+// loadRawConfig loads the merged kubeconfig without applying overrides
+func loadRawConfig(kubeconfigPath string) (clientcmdapi.Config, error) {
+	rawConfig, err := newClientConfig(kubeconfigPath, "").RawConfig()
+	if err != nil {
+		return clientcmdapi.Config{}, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	return rawConfig, nil
+}
+
+// resolveContext returns the context that will be used for the given
+// override, along with the kubeconfig cluster entry it points to
+func resolveContext(kubeconfigPath, contextName string) (string, string, error) {
+	rawConfig, err := loadRawConfig(kubeconfigPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	if contextName == "" {
+		contextName = rawConfig.CurrentContext
+	}
+
+	ctx, exists := rawConfig.Contexts[contextName]
+	if !exists {
+		return "", "", fmt.Errorf("context %q not found in kubeconfig", contextName)
+	}
+
+	return contextName, ctx.Cluster, nil
+}
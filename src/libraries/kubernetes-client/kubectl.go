@@ -17,4 +17,19 @@ func NewKubectlCommandWithStdin(ctx context.Context, stdin string, args ...strin
 	cmd := exec.CommandContext(ctx, "kubectl", args...)
 	cmd.Stdin = strings.NewReader(stdin)
 	return cmd
-}
\ No newline at end of file
+}
+
+// KubectlArgs prefixes kubectl arguments with the kubeconfig and context this
+// client was built from, so shelled-out commands talk to the same cluster
+func (kc *KubernetesClient) KubectlArgs(args ...string) []string {
+	var prefixed []string
+	if kc.cluster != nil {
+		if kc.cluster.Auth.Kubeconfig != "" {
+			prefixed = append(prefixed, "--kubeconfig", kc.cluster.Auth.Kubeconfig)
+		}
+		if kc.cluster.Context != "" {
+			prefixed = append(prefixed, "--context", kc.cluster.Context)
+		}
+	}
+	return append(prefixed, args...)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/anindyar/kuber/src/models"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -74,38 +75,104 @@ func (kc *KubernetesClient) GetLogs(ctx context.Context, opts LogOptions) ([]*mo
 	return kc.parseLogs(podLogs, opts)
 }
 
-// StreamLogs streams logs from a pod/container
+// StreamLogs streams logs from a pod/container until the stream ends or ctx
+// is cancelled. Entries carry the server-side timestamp of each line.
 func (kc *KubernetesClient) StreamLogs(ctx context.Context, opts LogOptions, logChan chan<- *models.LogEntry) error {
+	_, err := kc.streamOnce(ctx, opts, &logCursor{}, logChan)
+	return err
+}
+
+// FollowLogs streams logs like StreamLogs but keeps following across
+// container restarts and dropped connections, resuming after the last line
+// delivered. It returns when ctx is cancelled or the pod can no longer be
+// followed (deleted, or the request is invalid).
+func (kc *KubernetesClient) FollowLogs(ctx context.Context, opts LogOptions, logChan chan<- *models.LogEntry) error {
+	cursor := &logCursor{}
+	backoff := time.Second
+	const maxBackoff = 30 * time.Second
+
+	for {
+		received, err := kc.streamOnce(ctx, opts, cursor, logChan)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && (apierrors.IsNotFound(err) || apierrors.IsBadRequest(err)) {
+			return err
+		}
+
+		// Resume from the last line we delivered
+		if !cursor.last.IsZero() {
+			since := cursor.last
+			opts.SinceTime = &since
+			opts.TailLines = nil
+		}
+
+		if received > 0 {
+			backoff = time.Second
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// logCursor tracks the position of a log stream across reconnects
+type logCursor struct {
+	last       time.Time // timestamp of the last delivered line
+	atLast     int       // lines delivered with that timestamp
+	lineNumber int64
+}
+
+// streamOnce opens one follow stream and delivers lines newer than the
+// cursor. It returns the number of lines delivered.
+func (kc *KubernetesClient) streamOnce(ctx context.Context, opts LogOptions, cursor *logCursor, logChan chan<- *models.LogEntry) (int, error) {
 	if kc.clientset == nil {
-		return fmt.Errorf("client not initialized")
+		return 0, fmt.Errorf("client not initialized")
 	}
 
 	if opts.PodName == "" {
-		return fmt.Errorf("pod name is required")
+		return 0, fmt.Errorf("pod name is required")
 	}
 
 	if opts.Namespace == "" {
 		opts.Namespace = "default"
 	}
 
-	// For streaming, use TailLines to get recent logs AND follow for new ones
 	kubeLogOpts := &corev1.PodLogOptions{
-		Container: opts.ContainerName,
-		Follow:    true,
-		Previous:  opts.Previous,
-		TailLines: func() *int64 { n := int64(100); return &n }(), // Always get last 100 lines when following
+		Container:  opts.ContainerName,
+		Follow:     true,
+		Previous:   opts.Previous,
+		Timestamps: true, // Needed to resume without gaps or duplicates
+	}
+
+	if opts.SinceTime != nil {
+		sinceTime := metav1.NewTime(*opts.SinceTime)
+		kubeLogOpts.SinceTime = &sinceTime
+	} else if opts.TailLines != nil {
+		kubeLogOpts.TailLines = opts.TailLines
+	} else {
+		tailLines := int64(100) // Start with recent history
+		kubeLogOpts.TailLines = &tailLines
 	}
 
 	// Get log stream
 	req := kc.clientset.CoreV1().Pods(opts.Namespace).GetLogs(opts.PodName, kubeLogOpts)
 	podLogs, err := req.Stream(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get log stream: %w", err)
+		return 0, fmt.Errorf("failed to get log stream: %w", err)
 	}
 	defer podLogs.Close()
 
 	// Stream logs
-	return kc.streamLogs(ctx, podLogs, opts, logChan)
+	return kc.streamLogs(ctx, podLogs, opts, cursor, logChan)
 }
 
 // parseLogs parses log content and returns log entries
@@ -159,11 +226,18 @@ func (kc *KubernetesClient) parseLogs(reader io.Reader, opts LogOptions) ([]*mod
 	return logEntries, nil
 }
 
-// streamLogs streams log content and sends log entries to channel
-func (kc *KubernetesClient) streamLogs(ctx context.Context, reader io.Reader, opts LogOptions, logChan chan<- *models.LogEntry) error {
+// streamLogs streams timestamped log content and sends log entries newer
+// than the cursor to the channel
+func (kc *KubernetesClient) streamLogs(ctx context.Context, reader io.Reader, opts LogOptions, cursor *logCursor, logChan chan<- *models.LogEntry) (int, error) {
 	// Channel closing is handled by the caller
 
-	lineNumber := int64(1)
+	// SinceTime has second precision, so a resumed stream replays lines
+	// already delivered: everything before the cursor, and the lines seen
+	// at its timestamp. Lines sharing a timestamp are otherwise distinct.
+	resumed := opts.SinceTime != nil
+	replayed := cursor.atLast
+
+	delivered := 0
 	scanner := bufio.NewScanner(reader)
 	// Increase scanner buffer size for large log lines
 	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024) // 1MB max line
@@ -171,15 +245,33 @@ func (kc *KubernetesClient) streamLogs(ctx context.Context, reader io.Reader, op
 	for scanner.Scan() {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return delivered, ctx.Err()
 		default:
 		}
 
-		line := scanner.Text()
+		timestamp, line := splitLogTimestamp(scanner.Text())
 		if line == "" {
 			continue
 		}
 
+		if !timestamp.IsZero() {
+			if resumed && timestamp.Before(cursor.last) {
+				continue
+			}
+			if timestamp.Equal(cursor.last) {
+				if resumed && replayed > 0 {
+					replayed--
+					continue
+				}
+				cursor.atLast++
+			} else {
+				cursor.last = timestamp
+				cursor.atLast = 1
+			}
+		} else {
+			timestamp = time.Now()
+		}
+
 		// Create log source
 		source := models.LogSource{
 			PodName:       opts.PodName,
@@ -188,19 +280,15 @@ func (kc *KubernetesClient) streamLogs(ctx context.Context, reader io.Reader, op
 		}
 
 		// Create log entry
-		logEntry, err := models.NewLogEntry(time.Now(), source, line)
+		logEntry, err := models.NewLogEntry(timestamp, source, line)
 		if err != nil {
 			continue // Skip invalid log entries
 		}
 
-		logEntry.LineNumber = lineNumber
+		cursor.lineNumber++
+		logEntry.LineNumber = cursor.lineNumber
 		logEntry.Raw = line
 
-		// Try to parse timestamp from log line
-		if timestamp := parseTimestampFromLog(line); !timestamp.IsZero() {
-			logEntry.Timestamp = timestamp
-		}
-
 		// Detect stream type from content
 		if strings.Contains(strings.ToLower(line), "error") ||
 			strings.Contains(strings.ToLower(line), "fatal") ||
@@ -212,16 +300,34 @@ func (kc *KubernetesClient) streamLogs(ctx context.Context, reader io.Reader, op
 		select {
 		case logChan <- logEntry:
 		case <-ctx.Done():
-			return ctx.Err()
+			return delivered, ctx.Err()
 		}
 
-		lineNumber++
+		delivered++
 	}
 
 	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading logs: %w", err)
+		return delivered, fmt.Errorf("error reading logs: %w", err)
+	}
+	return delivered, nil
+}
+
+// splitLogTimestamp splits the RFC3339Nano prefix the API server adds when
+// timestamps are requested from the log line
+func splitLogTimestamp(line string) (time.Time, string) {
+	space := strings.IndexByte(line, ' ')
+	if space == -1 {
+		if timestamp, err := time.Parse(time.RFC3339Nano, line); err == nil {
+			return timestamp, ""
+		}
+		return time.Time{}, line
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, line[:space])
+	if err != nil {
+		return time.Time{}, line
 	}
-	return nil
+	return timestamp, line[space+1:]
 }
 
 // parseTimestampFromLog attempts to extract timestamp from log line
@@ -0,0 +1,276 @@
+package kubernetesclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anindyar/kuber/src/models"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// mergeWindow is how long entries are held so lines from different
+	// streams can be put in timestamp order
+	mergeWindow = 300 * time.Millisecond
+	// ownerRecheckInterval is how often pods are offered to an OwnedBy
+	// matcher again, so pods it couldn't place yet are picked up
+	ownerRecheckInterval = 10 * time.Second
+)
+
+// LogSelector picks the pods whose logs are multiplexed: every pod in the
+// namespace matching the label selector and, when set, owned by the workload
+type LogSelector struct {
+	Namespace     string
+	LabelSelector string
+	OwnerKind     string // Deployment, StatefulSet, DaemonSet, Job, ReplicaSet
+	OwnerName     string
+	TailLines     *int64 // per container when a stream starts
+
+	// OwnedBy decides from a pod's owner references whether it belongs to
+	// the workload, e.g. by walking an ownership graph. It is called from
+	// the pod watch with the context of the multiplexer and must not block.
+	// Without it only pods the workload owns directly are selected.
+	OwnedBy func(ctx context.Context, owners []models.OwnerReference) bool
+}
+
+// Describe returns a short description of the selection, e.g. "deployment/web"
+func (s LogSelector) Describe() string {
+	if s.OwnerKind != "" {
+		return strings.ToLower(s.OwnerKind) + "/" + s.OwnerName
+	}
+	if s.LabelSelector != "" {
+		return s.LabelSelector
+	}
+	return "all pods"
+}
+
+// ownsPod reports whether the pod belongs to the selected owner
+func (s LogSelector) ownsPod(ctx context.Context, pod *corev1.Pod) bool {
+	if s.OwnerKind == "" {
+		return true
+	}
+	if s.OwnedBy != nil {
+		return s.OwnedBy(ctx, convertOwnerReferences(pod.OwnerReferences))
+	}
+
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == s.OwnerKind && ref.Name == s.OwnerName {
+			return true
+		}
+	}
+	return false
+}
+
+// LogMultiplexer follows the logs of every container in a changing set of
+// pods and merges them into one stream ordered by timestamp. Pods are
+// tracked with a watch, so new replicas are picked up and deleted ones
+// dropped automatically.
+type LogMultiplexer struct {
+	client   *KubernetesClient
+	selector LogSelector
+	streams  map[string]*streamHandle // namespace/pod/container
+	mu       sync.Mutex
+}
+
+// streamHandle is one followed container. A pod recreated under the same
+// name gets a new handle, so an exiting old stream can tell it was replaced.
+type streamHandle struct {
+	cancel context.CancelFunc
+}
+
+// NewLogMultiplexer creates a multiplexer for the selected pods
+func NewLogMultiplexer(client *KubernetesClient, selector LogSelector) *LogMultiplexer {
+	return &LogMultiplexer{
+		client:   client,
+		selector: selector,
+		streams:  make(map[string]*streamHandle),
+	}
+}
+
+// Run follows the selected logs until ctx is cancelled, sending merged
+// entries to logChan
+func (m *LogMultiplexer) Run(ctx context.Context, logChan chan<- *models.LogEntry) error {
+	if m.client.clientset == nil {
+		return fmt.Errorf("client not initialized")
+	}
+
+	raw := make(chan *models.LogEntry, 1024)
+
+	lw := cache.NewFilteredListWatchFromClient(m.client.clientset.CoreV1().RESTClient(), "pods", m.selector.Namespace,
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = m.selector.LabelSelector
+		})
+	var resync time.Duration
+	if m.selector.OwnedBy != nil {
+		resync = ownerRecheckInterval
+	}
+	informer := cache.NewSharedIndexInformer(lw, &corev1.Pod{}, resync, cache.Indexers{})
+
+	// The default handler logs to stderr; the informer retries on its own
+	if err := informer.SetWatchErrorHandler(func(*cache.Reflector, error) {}); err != nil {
+		return fmt.Errorf("failed to set pod watch error handler: %w", err)
+	}
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { m.syncPod(ctx, obj, raw) },
+		UpdateFunc: func(_, obj interface{}) { m.syncPod(ctx, obj, raw) },
+		DeleteFunc: m.removePod,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch pods: %w", err)
+	}
+
+	go informer.Run(ctx.Done())
+
+	m.merge(ctx, raw, logChan)
+	return ctx.Err()
+}
+
+// Streams returns the pod/container streams currently followed
+func (m *LogMultiplexer) Streams() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	streams := make([]string, 0, len(m.streams))
+	for key := range m.streams {
+		streams = append(streams, key)
+	}
+	sort.Strings(streams)
+	return streams
+}
+
+// syncPod starts a stream for every running container of a selected pod
+func (m *LogMultiplexer) syncPod(ctx context.Context, obj interface{}, raw chan<- *models.LogEntry) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || !m.selector.ownsPod(ctx, pod) {
+		return
+	}
+	if pod.DeletionTimestamp != nil {
+		m.removePod(pod)
+		return
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		// Waiting containers have no logs yet; a later update starts them
+		if status.State.Running == nil && status.State.Terminated == nil {
+			continue
+		}
+		m.startStream(ctx, pod.Namespace, pod.Name, status.Name, raw)
+	}
+}
+
+// startStream follows one container unless it is already followed
+func (m *LogMultiplexer) startStream(ctx context.Context, namespace, podName, container string, raw chan<- *models.LogEntry) {
+	key := namespace + "/" + podName + "/" + container
+
+	m.mu.Lock()
+	if _, exists := m.streams[key]; exists {
+		m.mu.Unlock()
+		return
+	}
+	streamCtx, cancel := context.WithCancel(ctx)
+	handle := &streamHandle{cancel: cancel}
+	m.streams[key] = handle
+	m.mu.Unlock()
+
+	go func() {
+		defer cancel()
+
+		// FollowLogs resumes across container restarts by itself; when it
+		// gives up, forget the stream so the next pod update can retry.
+		// A stream removed with its pod may have been replaced by one for
+		// a new pod of the same name, which must be left alone.
+		_ = m.client.FollowLogs(streamCtx, LogOptions{
+			Namespace:     namespace,
+			PodName:       podName,
+			ContainerName: container,
+			TailLines:     m.selector.TailLines,
+		}, raw)
+
+		m.mu.Lock()
+		if m.streams[key] == handle {
+			delete(m.streams, key)
+		}
+		m.mu.Unlock()
+	}()
+}
+
+// removePod stops the streams of a deleted pod
+func (m *LogMultiplexer) removePod(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return
+	}
+
+	prefix := pod.Namespace + "/" + pod.Name + "/"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, handle := range m.streams {
+		if strings.HasPrefix(key, prefix) {
+			handle.cancel()
+			delete(m.streams, key)
+		}
+	}
+}
+
+// pendingEntry is a log entry held back for ordering
+type pendingEntry struct {
+	entry   *models.LogEntry
+	arrived time.Time
+}
+
+// merge reorders entries from all streams by timestamp. Entries are held for
+// mergeWindow after arriving; once released, anything older that is still
+// held goes out with them, so the output stays in timestamp order.
+func (m *LogMultiplexer) merge(ctx context.Context, raw <-chan *models.LogEntry, logChan chan<- *models.LogEntry) {
+	ticker := time.NewTicker(mergeWindow / 3)
+	defer ticker.Stop()
+
+	var pending []pendingEntry
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case entry := <-raw:
+			pending = append(pending, pendingEntry{entry: entry, arrived: time.Now()})
+		case now := <-ticker.C:
+			cutoff := now.Add(-mergeWindow)
+
+			var watermark time.Time
+			for _, p := range pending {
+				if p.arrived.Before(cutoff) && p.entry.Timestamp.After(watermark) {
+					watermark = p.entry.Timestamp
+				}
+			}
+			if watermark.IsZero() {
+				continue
+			}
+
+			sort.SliceStable(pending, func(i, j int) bool {
+				return pending[i].entry.Timestamp.Before(pending[j].entry.Timestamp)
+			})
+
+			released := 0
+			for released < len(pending) && !pending[released].entry.Timestamp.After(watermark) {
+				select {
+				case logChan <- pending[released].entry:
+				case <-ctx.Done():
+					return
+				}
+				released++
+			}
+			pending = append(pending[:0], pending[released:]...)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package kubernetesclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DeleteResource deletes a single named resource of the given type
+func (kc *KubernetesClient) DeleteResource(ctx context.Context, resourceType, namespace, name string) error {
+	if kc.clientset == nil {
+		return fmt.Errorf("client not initialized")
+	}
+
+	opts := metav1.DeleteOptions{}
+	var err error
+
+	switch resourceType {
+	case "pods":
+		err = kc.clientset.CoreV1().Pods(namespace).Delete(ctx, name, opts)
+	case "services":
+		err = kc.clientset.CoreV1().Services(namespace).Delete(ctx, name, opts)
+	case "deployments":
+		err = kc.clientset.AppsV1().Deployments(namespace).Delete(ctx, name, opts)
+	case "statefulsets":
+		err = kc.clientset.AppsV1().StatefulSets(namespace).Delete(ctx, name, opts)
+	case "configmaps":
+		err = kc.clientset.CoreV1().ConfigMaps(namespace).Delete(ctx, name, opts)
+	case "secrets":
+		err = kc.clientset.CoreV1().Secrets(namespace).Delete(ctx, name, opts)
+	case "ingress":
+		err = kc.clientset.NetworkingV1().Ingresses(namespace).Delete(ctx, name, opts)
+	case "persistentvolumes":
+		err = kc.clientset.CoreV1().PersistentVolumes().Delete(ctx, name, opts)
+	case "persistentvolumeclaims":
+		err = kc.clientset.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, name, opts)
+	default:
+		// Fall back to the dynamic client for everything else, including CRDs
+		return kc.deleteDynamicResource(ctx, resourceType, namespace, name)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to delete %s %s: %w", resourceType, name, err)
+	}
+	return nil
+}
+
+// ScaleResource sets the replica count of a deployment or statefulset
+func (kc *KubernetesClient) ScaleResource(ctx context.Context, resourceType, namespace, name string, replicas int32) error {
+	if replicas < 0 {
+		return fmt.Errorf("replicas cannot be negative: %d", replicas)
+	}
+
+	scale, err := kc.getScale(ctx, resourceType, namespace, name)
+	if err != nil {
+		return err
+	}
+	scale.Spec.Replicas = replicas
+
+	switch resourceType {
+	case "deployments":
+		_, err = kc.clientset.AppsV1().Deployments(namespace).UpdateScale(ctx, name, scale, metav1.UpdateOptions{})
+	case "statefulsets":
+		_, err = kc.clientset.AppsV1().StatefulSets(namespace).UpdateScale(ctx, name, scale, metav1.UpdateOptions{})
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to scale %s %s: %w", resourceType, name, err)
+	}
+	return nil
+}
+
+// RestartResource triggers a rolling restart of a deployment or statefulset,
+// the same way `kubectl rollout restart` does
+func (kc *KubernetesClient) RestartResource(ctx context.Context, resourceType, namespace, name string) error {
+	if kc.clientset == nil {
+		return fmt.Errorf("client not initialized")
+	}
+
+	patch := []byte(fmt.Sprintf(
+		`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":%q}}}}}`,
+		time.Now().Format(time.RFC3339),
+	))
+
+	var err error
+	switch resourceType {
+	case "deployments":
+		_, err = kc.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "statefulsets":
+		_, err = kc.clientset.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	default:
+		return fmt.Errorf("restart is not supported for resource type: %s", resourceType)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to restart %s %s: %w", resourceType, name, err)
+	}
+	return nil
+}
+
+// getScale fetches the scale subresource of a deployment or statefulset
+func (kc *KubernetesClient) getScale(ctx context.Context, resourceType, namespace, name string) (*autoscalingv1.Scale, error) {
+	if kc.clientset == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	var scale *autoscalingv1.Scale
+	var err error
+
+	switch resourceType {
+	case "deployments":
+		scale, err = kc.clientset.AppsV1().Deployments(namespace).GetScale(ctx, name, metav1.GetOptions{})
+	case "statefulsets":
+		scale, err = kc.clientset.AppsV1().StatefulSets(namespace).GetScale(ctx, name, metav1.GetOptions{})
+	default:
+		return nil, fmt.Errorf("scaling is not supported for resource type: %s", resourceType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scale for %s %s: %w", resourceType, name, err)
+	}
+	return scale, nil
+}
@@ -0,0 +1,64 @@
+package kubernetesclient
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodRequests returns what a pod asks the scheduler for: its containers and
+// sidecars together, or its largest init container with the sidecars
+// started before it if that is more, plus overhead
+func PodRequests(pod *corev1.Pod) corev1.ResourceList {
+	return podResources(pod, func(resources corev1.ResourceRequirements) corev1.ResourceList {
+		return resources.Requests
+	})
+}
+
+// PodLimits returns a pod's limits, added up the same way as its requests.
+// Containers without a limit add nothing, as in kubectl describe node.
+func PodLimits(pod *corev1.Pod) corev1.ResourceList {
+	return podResources(pod, func(resources corev1.ResourceRequirements) corev1.ResourceList {
+		return resources.Limits
+	})
+}
+
+// podResources adds up the requests or limits picked from each container
+func podResources(pod *corev1.Pod, pick func(corev1.ResourceRequirements) corev1.ResourceList) corev1.ResourceList {
+	total := make(corev1.ResourceList)
+	sidecars := make(corev1.ResourceList)
+	initPeak := make(corev1.ResourceList)
+
+	for _, container := range pod.Spec.Containers {
+		AddResources(total, pick(container.Resources))
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			AddResources(total, pick(container.Resources))
+			AddResources(sidecars, pick(container.Resources))
+			continue
+		}
+		for name, quantity := range pick(container.Resources) {
+			need := quantity.DeepCopy()
+			need.Add(sidecars[name])
+			if peak := initPeak[name]; need.Cmp(peak) > 0 {
+				initPeak[name] = need
+			}
+		}
+	}
+
+	for name, peak := range initPeak {
+		if current := total[name]; peak.Cmp(current) > 0 {
+			total[name] = peak
+		}
+	}
+	AddResources(total, pod.Spec.Overhead)
+	return total
+}
+
+// AddResources adds quantities to a resource list
+func AddResources(list, add corev1.ResourceList) {
+	for name, quantity := range add {
+		total := list[name]
+		total.Add(quantity)
+		list[name] = total
+	}
+}
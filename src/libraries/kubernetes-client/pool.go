@@ -0,0 +1,147 @@
+package kubernetesclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/anindyar/kuber/src/models"
+)
+
+// ClientPool holds one KubernetesClient per kubeconfig context so several
+// clusters can be queried side by side
+type ClientPool struct {
+	kubeconfig string
+	clients    map[string]*KubernetesClient
+	clusters   map[string]*models.Cluster
+	mutex      sync.RWMutex
+}
+
+// NewClientPool creates an empty pool for contexts of the given kubeconfig
+func NewClientPool(kubeconfigPath string) *ClientPool {
+	return &ClientPool{
+		kubeconfig: kubeconfigPath,
+		clients:    make(map[string]*KubernetesClient),
+		clusters:   make(map[string]*models.Cluster),
+	}
+}
+
+// Connect makes sure every context has a live client, connecting to them
+// concurrently. Contexts that fail are recorded with an error status rather
+// than aborting the others. Clusters are returned in the order requested.
+func (p *ClientPool) Connect(ctx context.Context, contexts []string) []*models.Cluster {
+	var wg sync.WaitGroup
+	for _, contextName := range contexts {
+		wg.Add(1)
+		go func(contextName string) {
+			defer wg.Done()
+			p.connect(ctx, contextName)
+		}(contextName)
+	}
+	wg.Wait()
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	clusters := make([]*models.Cluster, 0, len(contexts))
+	for _, contextName := range contexts {
+		if cluster, exists := p.clusters[contextName]; exists {
+			clusters = append(clusters, cluster)
+		}
+	}
+	return clusters
+}
+
+// connect creates or re-checks the client for a single context
+func (p *ClientPool) connect(ctx context.Context, contextName string) {
+	p.mutex.RLock()
+	client, exists := p.clients[contextName]
+	p.mutex.RUnlock()
+
+	if !exists {
+		// Name is filled in from the kubeconfig cluster entry once connected
+		cluster := &models.Cluster{
+			Context: contextName,
+			Auth: models.AuthConfig{
+				Type:       "kubeconfig",
+				Kubeconfig: p.kubeconfig,
+				Context:    contextName,
+			},
+			Status: models.ClusterStatusUnknown,
+		}
+
+		var err error
+		client, err = NewKubernetesClient(cluster)
+		if err != nil {
+			cluster.SetError(err)
+			p.mutex.Lock()
+			p.clusters[contextName] = cluster
+			p.mutex.Unlock()
+			return
+		}
+
+		p.mutex.Lock()
+		p.clients[contextName] = client
+		p.clusters[contextName] = cluster
+		p.mutex.Unlock()
+	}
+
+	cluster := client.GetCluster()
+	version, err := serverVersion(ctx, client)
+	if err != nil {
+		cluster.SetError(err)
+		return
+	}
+	cluster.SetStatus(models.ClusterStatusConnected)
+	cluster.SetVersion(version)
+}
+
+// serverVersion asks the API server for its version, giving up when ctx is
+// done since discovery requests do not take a context
+func serverVersion(ctx context.Context, client *KubernetesClient) (string, error) {
+	type result struct {
+		version string
+		err     error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		version, err := client.clientset.Discovery().ServerVersion()
+		if err != nil {
+			done <- result{err: fmt.Errorf("failed to connect to cluster: %w", err)}
+			return
+		}
+		done <- result{version: version.GitVersion}
+	}()
+
+	select {
+	case r := <-done:
+		return r.version, r.err
+	case <-ctx.Done():
+		return "", fmt.Errorf("failed to connect to cluster: %w", ctx.Err())
+	}
+}
+
+// Get returns the connected client for a context
+func (p *ClientPool) Get(contextName string) (*KubernetesClient, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	client, exists := p.clients[contextName]
+	if !exists || !client.GetCluster().IsConnected() {
+		return nil, false
+	}
+	return client, true
+}
+
+// Close releases all pooled clients
+func (p *ClientPool) Close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for name, client := range p.clients {
+		client.Close()
+		delete(p.clients, name)
+	}
+	p.clusters = make(map[string]*models.Cluster)
+}
@@ -0,0 +1,617 @@
+package kubernetesclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+const (
+	// portForwardCheckInterval is how often the target pod is checked for replacement
+	portForwardCheckInterval = 5 * time.Second
+	portForwardMinBackoff    = time.Second
+	portForwardMaxBackoff    = 30 * time.Second
+)
+
+// Port forward target kinds
+const (
+	PortForwardPod     = "pod"
+	PortForwardService = "service"
+)
+
+// PortForwardStatus is the state of a port forward
+type PortForwardStatus string
+
+const (
+	PortForwardStarting     PortForwardStatus = "Starting"
+	PortForwardActive       PortForwardStatus = "Active"
+	PortForwardReconnecting PortForwardStatus = "Reconnecting"
+	PortForwardStopped      PortForwardStatus = "Stopped"
+)
+
+// replicaLabels differ between replicas of one workload, so they are left
+// out when looking for a pod that replaced another
+var replicaLabels = []string{
+	"pod-template-hash",
+	"controller-revision-hash",
+	"statefulset.kubernetes.io/pod-name",
+	"apps.kubernetes.io/pod-index",
+}
+
+// PortForwardOptions describes a port forward to a pod, or to a service
+// through one of its ready pods
+type PortForwardOptions struct {
+	Namespace  string
+	Kind       string // PortForwardPod or PortForwardService
+	Name       string
+	LocalPort  int    // 0 picks a free port
+	RemotePort int    // container port, or the service port for services
+	Address    string // local listen address, defaults to localhost
+}
+
+// Target returns the forward target, e.g. "service/web:80"
+func (o PortForwardOptions) Target() string {
+	return fmt.Sprintf("%s/%s:%d", o.Kind, o.Name, o.RemotePort)
+}
+
+// PortForwardInfo is a point-in-time view of a port forward
+type PortForwardInfo struct {
+	ID         string
+	Options    PortForwardOptions
+	Status     PortForwardStatus
+	PodName    string // pod currently carrying the traffic
+	LocalPort  int
+	TargetPort int // container port on PodName
+	BytesIn    int64
+	BytesOut   int64
+	Reconnects int
+	StartedAt  time.Time
+	LastError  error
+}
+
+// PortForward is a running port forward. It re-establishes itself when the
+// connection drops or the target pod is replaced, until Stop is called.
+type PortForward struct {
+	id      string
+	options PortForwardOptions
+	client  *KubernetesClient
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	bytesIn  atomic.Int64
+	bytesOut atomic.Int64
+
+	mu         sync.RWMutex
+	status     PortForwardStatus
+	podName    string
+	replicas   labels.Selector // finds a replacement for a deleted pod
+	localPort  int
+	targetPort int
+	reconnects int
+	startedAt  time.Time
+	lastError  error
+}
+
+// PortForward starts forwarding a local port to a pod or service. It returns
+// once the local port is listening, or with an error if the first connection
+// fails. The forward keeps running in the background until stopped with
+// StopPortForward, Stop or Close.
+func (kc *KubernetesClient) PortForward(ctx context.Context, opts PortForwardOptions) (*PortForward, error) {
+	if kc.clientset == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+	if opts.Kind != PortForwardPod && opts.Kind != PortForwardService {
+		return nil, fmt.Errorf("cannot port forward to %q, only pods and services", opts.Kind)
+	}
+	if opts.Name == "" || opts.RemotePort <= 0 {
+		return nil, fmt.Errorf("port forward needs a target name and remote port")
+	}
+	if opts.Address == "" {
+		opts.Address = "localhost"
+	}
+
+	id := fmt.Sprintf("%s/%s", opts.Namespace, opts.Target())
+
+	kc.forwardsMu.Lock()
+	if existing, ok := kc.forwards[id]; ok {
+		kc.forwardsMu.Unlock()
+		return nil, fmt.Errorf("%s is already forwarded on %s:%d", opts.Target(), opts.Address, existing.Info().LocalPort)
+	}
+	forwardCtx, cancel := context.WithCancel(context.Background())
+	pf := &PortForward{
+		id:        id,
+		options:   opts,
+		client:    kc,
+		ctx:       forwardCtx,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+		status:    PortForwardStarting,
+		localPort: opts.LocalPort,
+		startedAt: time.Now(),
+	}
+	if kc.forwards == nil {
+		kc.forwards = make(map[string]*PortForward)
+	}
+	kc.forwards[id] = pf
+	kc.forwardsMu.Unlock()
+
+	ready := make(chan error, 1)
+	go pf.run(ready)
+
+	select {
+	case err := <-ready:
+		if err != nil {
+			pf.Stop()
+			return nil, err
+		}
+		return pf, nil
+	case <-ctx.Done():
+		pf.Stop()
+		return nil, ctx.Err()
+	}
+}
+
+// PortForwards returns the running port forwards, sorted by ID
+func (kc *KubernetesClient) PortForwards() []*PortForward {
+	kc.forwardsMu.Lock()
+	defer kc.forwardsMu.Unlock()
+
+	forwards := make([]*PortForward, 0, len(kc.forwards))
+	for _, pf := range kc.forwards {
+		forwards = append(forwards, pf)
+	}
+	sort.Slice(forwards, func(i, j int) bool {
+		return forwards[i].id < forwards[j].id
+	})
+	return forwards
+}
+
+// StopPortForward stops the port forward with the given ID
+func (kc *KubernetesClient) StopPortForward(id string) error {
+	kc.forwardsMu.Lock()
+	pf, ok := kc.forwards[id]
+	kc.forwardsMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("port forward %s not found", id)
+	}
+	pf.Stop()
+	return nil
+}
+
+// stopPortForwards stops every port forward of the client
+func (kc *KubernetesClient) stopPortForwards() {
+	for _, pf := range kc.PortForwards() {
+		pf.Stop()
+	}
+}
+
+// forgetPortForward removes a stopped forward from the client
+func (kc *KubernetesClient) forgetPortForward(id string) {
+	kc.forwardsMu.Lock()
+	defer kc.forwardsMu.Unlock()
+	delete(kc.forwards, id)
+}
+
+// ID returns the forward's identifier, namespace/kind/name:port
+func (pf *PortForward) ID() string {
+	return pf.id
+}
+
+// Info returns the current state and counters of the forward
+func (pf *PortForward) Info() PortForwardInfo {
+	pf.mu.RLock()
+	defer pf.mu.RUnlock()
+
+	return PortForwardInfo{
+		ID:         pf.id,
+		Options:    pf.options,
+		Status:     pf.status,
+		PodName:    pf.podName,
+		LocalPort:  pf.localPort,
+		TargetPort: pf.targetPort,
+		BytesIn:    pf.bytesIn.Load(),
+		BytesOut:   pf.bytesOut.Load(),
+		Reconnects: pf.reconnects,
+		StartedAt:  pf.startedAt,
+		LastError:  pf.lastError,
+	}
+}
+
+// Stop closes the local listener and waits for the forward to end
+func (pf *PortForward) Stop() {
+	pf.cancel()
+	<-pf.done
+}
+
+// Done returns a channel that is closed when the forward has stopped
+func (pf *PortForward) Done() <-chan struct{} {
+	return pf.done
+}
+
+// run keeps the forward up until it is stopped. The first result of
+// establishing it is sent on ready; after that, failures are retried with
+// backoff and reported through Info.
+func (pf *PortForward) run(ready chan<- error) {
+	defer close(pf.done)
+	defer pf.client.forgetPortForward(pf.id)
+	defer pf.setStatus(PortForwardStopped, nil)
+
+	established := false
+	delay := portForwardMinBackoff
+
+	for {
+		err := pf.forwardOnce(func() {
+			delay = portForwardMinBackoff
+			if !established {
+				established = true
+				ready <- nil
+			}
+		})
+		if pf.ctx.Err() != nil {
+			return
+		}
+		if !established {
+			ready <- err
+			return
+		}
+
+		pf.mu.Lock()
+		pf.status = PortForwardReconnecting
+		pf.lastError = err
+		pf.reconnects++
+		pf.mu.Unlock()
+
+		select {
+		case <-pf.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > portForwardMaxBackoff {
+			delay = portForwardMaxBackoff
+		}
+	}
+}
+
+// forwardOnce connects to a ready pod of the target and forwards until the
+// connection is lost, the pod is replaced or the forward is stopped. onReady
+// is called once the local port is listening.
+func (pf *PortForward) forwardOnce(onReady func()) error {
+	ctx, cancel := context.WithTimeout(pf.ctx, 15*time.Second)
+	pod, targetPort, err := pf.resolveTarget(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	dialer, err := pf.client.newPortForwardDialer(pod.Namespace, pod.Name)
+	if err != nil {
+		return fmt.Errorf("failed to create port forward dialer: %w", err)
+	}
+
+	pf.mu.RLock()
+	localPort := pf.localPort
+	pf.mu.RUnlock()
+
+	stopChan := make(chan struct{})
+	readyChan := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(&countingDialer{Dialer: dialer, forward: pf},
+		[]string{pf.options.Address}, []string{fmt.Sprintf("%d:%d", localPort, targetPort)},
+		stopChan, readyChan, io.Discard, io.Discard)
+	if err != nil {
+		return fmt.Errorf("failed to create port forward: %w", err)
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- forwarder.ForwardPorts()
+	}()
+
+	stop := func() {
+		close(stopChan)
+		<-errChan
+	}
+
+	select {
+	case <-readyChan:
+	case err := <-errChan:
+		return fmt.Errorf("failed to forward to pod %s: %w", pod.Name, err)
+	case <-pf.ctx.Done():
+		stop()
+		return nil
+	}
+
+	// Keep the same local port across reconnects so clients can reconnect
+	if ports, err := forwarder.GetPorts(); err == nil && len(ports) > 0 {
+		localPort = int(ports[0].Local)
+	}
+
+	pf.mu.Lock()
+	pf.status = PortForwardActive
+	pf.lastError = nil
+	pf.podName = pod.Name
+	pf.localPort = localPort
+	pf.targetPort = targetPort
+	pf.mu.Unlock()
+
+	onReady()
+
+	ticker := time.NewTicker(portForwardCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-errChan:
+			if err == nil {
+				err = portforward.ErrLostConnectionToPod
+			}
+			return err
+		case <-pf.ctx.Done():
+			stop()
+			return nil
+		case <-ticker.C:
+			if reason := pf.checkPod(pod.Namespace, pod.Name, pod.UID); reason != "" {
+				stop()
+				return fmt.Errorf("pod %s %s", pod.Name, reason)
+			}
+		}
+	}
+}
+
+// checkPod returns why the pod can no longer carry the forward, or "" if it
+// still can. Errors reaching the API server are not a reason to reconnect.
+func (pf *PortForward) checkPod(namespace, name string, uid types.UID) string {
+	ctx, cancel := context.WithTimeout(pf.ctx, 10*time.Second)
+	defer cancel()
+
+	pod, err := pf.client.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		return "was deleted"
+	case err != nil:
+		return ""
+	case pod.UID != uid || pod.DeletionTimestamp != nil:
+		return "was replaced"
+	case pf.options.Kind == PortForwardService && !isPodReady(pod):
+		return "is no longer ready"
+	}
+	return ""
+}
+
+// resolveTarget finds the pod to forward to and the container port on it
+func (pf *PortForward) resolveTarget(ctx context.Context) (*corev1.Pod, int, error) {
+	if pf.options.Kind == PortForwardService {
+		return pf.client.resolveServicePod(ctx, pf.options.Namespace, pf.options.Name, pf.options.RemotePort)
+	}
+
+	pods := pf.client.clientset.CoreV1().Pods(pf.options.Namespace)
+	pod, err := pods.Get(ctx, pf.options.Name, metav1.GetOptions{})
+	if err == nil && pod.DeletionTimestamp == nil {
+		pf.rememberReplicas(pod)
+		return pod, pf.options.RemotePort, nil
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, 0, fmt.Errorf("failed to get pod %s: %w", pf.options.Name, err)
+	}
+
+	// The pod is gone; follow its workload to a replacement
+	pf.mu.RLock()
+	replicas := pf.replicas
+	pf.mu.RUnlock()
+	if replicas == nil {
+		return nil, 0, fmt.Errorf("pod %s not found", pf.options.Name)
+	}
+
+	replacement, err := pf.client.findReadyPod(ctx, pf.options.Namespace, replicas.String())
+	if err != nil {
+		return nil, 0, fmt.Errorf("pod %s is gone and no replacement is ready: %w", pf.options.Name, err)
+	}
+	return replacement, pf.options.RemotePort, nil
+}
+
+// rememberReplicas records a selector for the pod's sibling replicas, so a
+// replacement can be found when the pod is deleted
+func (pf *PortForward) rememberReplicas(pod *corev1.Pod) {
+	if metav1.GetControllerOf(pod) == nil || len(pod.Labels) == 0 {
+		return
+	}
+
+	set := labels.Set{}
+	for key, value := range pod.Labels {
+		set[key] = value
+	}
+	for _, key := range replicaLabels {
+		delete(set, key)
+	}
+	if len(set) == 0 {
+		return
+	}
+
+	pf.mu.Lock()
+	pf.replicas = labels.SelectorFromSet(set)
+	pf.mu.Unlock()
+}
+
+// resolveServicePod picks a ready pod behind a service and maps the service
+// port to its container port
+func (kc *KubernetesClient) resolveServicePod(ctx context.Context, namespace, name string, servicePort int) (*corev1.Pod, int, error) {
+	svc, err := kc.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get service %s: %w", name, err)
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return nil, 0, fmt.Errorf("service %s has no selector, so it has no pods to forward to", name)
+	}
+
+	var port *corev1.ServicePort
+	var available []string
+	for i := range svc.Spec.Ports {
+		if int(svc.Spec.Ports[i].Port) == servicePort {
+			port = &svc.Spec.Ports[i]
+		}
+		available = append(available, strconv.Itoa(int(svc.Spec.Ports[i].Port)))
+	}
+	if port == nil {
+		return nil, 0, fmt.Errorf("service %s has no port %d (ports: %v)", name, servicePort, available)
+	}
+
+	pod, err := kc.findReadyPod(ctx, namespace, labels.SelectorFromSet(svc.Spec.Selector).String())
+	if err != nil {
+		return nil, 0, fmt.Errorf("service %s: %w", name, err)
+	}
+
+	targetPort, err := containerPortFor(pod, port)
+	if err != nil {
+		return nil, 0, fmt.Errorf("service %s: %w", name, err)
+	}
+	return pod, targetPort, nil
+}
+
+// containerPortFor resolves a service port's targetPort, which may be a
+// number or a named container port
+func containerPortFor(pod *corev1.Pod, port *corev1.ServicePort) (int, error) {
+	if port.TargetPort.StrVal == "" {
+		if port.TargetPort.IntVal == 0 {
+			return int(port.Port), nil
+		}
+		return int(port.TargetPort.IntVal), nil
+	}
+
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == port.TargetPort.StrVal {
+				return int(containerPort.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("pod %s has no container port named %q", pod.Name, port.TargetPort.StrVal)
+}
+
+// findReadyPod returns a ready pod matching the label selector
+func (kc *KubernetesClient) findReadyPod(ctx context.Context, namespace, selector string) (*corev1.Pod, error) {
+	pods, err := kc.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	for i := range pods.Items {
+		if isPodReady(&pods.Items[i]) {
+			return &pods.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no ready pod matches %s", selector)
+}
+
+// isPodReady reports whether a running pod passes its readiness checks
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// newPortForwardDialer returns a dialer for a pod's portforward subresource
+// that tunnels SPDY over WebSocket, falling back to plain SPDY for API
+// servers that don't support it yet (as kubectl does)
+func (kc *KubernetesClient) newPortForwardDialer(namespace, podName string) (httpstream.Dialer, error) {
+	forwardURL := kc.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("portforward").
+		URL()
+
+	transport, upgrader, err := spdy.RoundTripperFor(kc.config)
+	if err != nil {
+		return nil, err
+	}
+	spdyDialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, forwardURL)
+
+	tunnelingDialer, err := portforward.NewSPDYOverWebsocketDialer(forwardURL, kc.config)
+	if err != nil {
+		return nil, err
+	}
+
+	return portforward.NewFallbackDialer(tunnelingDialer, spdyDialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	}), nil
+}
+
+// countingDialer wraps the connection so data streams count their bytes
+type countingDialer struct {
+	httpstream.Dialer
+	forward *PortForward
+}
+
+// Dial opens the connection
+func (d *countingDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	conn, protocol, err := d.Dialer.Dial(protocols...)
+	if err != nil {
+		return nil, "", err
+	}
+	return &countingConnection{Connection: conn, forward: d.forward}, protocol, nil
+}
+
+// countingConnection counts the bytes of the data streams it creates
+type countingConnection struct {
+	httpstream.Connection
+	forward *PortForward
+}
+
+// CreateStream creates a stream, counting its traffic if it carries data
+func (c *countingConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	stream, err := c.Connection.CreateStream(headers)
+	if err != nil || headers.Get(corev1.StreamType) != corev1.StreamTypeData {
+		return stream, err
+	}
+	return &countingStream{Stream: stream, forward: c.forward}, nil
+}
+
+// countingStream adds the bytes read from the pod to BytesIn and the bytes
+// written to it to BytesOut
+type countingStream struct {
+	httpstream.Stream
+	forward *PortForward
+}
+
+func (s *countingStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	s.forward.bytesIn.Add(int64(n))
+	return n, err
+}
+
+func (s *countingStream) Write(p []byte) (int, error) {
+	n, err := s.Stream.Write(p)
+	s.forward.bytesOut.Add(int64(n))
+	return n, err
+}
+
+// setStatus updates the status and last error
+func (pf *PortForward) setStatus(status PortForwardStatus, err error) {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	pf.status = status
+	pf.lastError = err
+}
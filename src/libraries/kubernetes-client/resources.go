@@ -62,6 +62,7 @@ func convertKubernetesPod(pod *corev1.Pod) (*models.Resource, error) {
 		Name:              pod.Name,
 		Namespace:         pod.Namespace,
 		UID:               string(pod.UID),
+		OwnerReferences:   convertOwnerReferences(pod.OwnerReferences),
 		ResourceVersion:   pod.ResourceVersion,
 		Generation:        pod.Generation,
 		CreationTimestamp: pod.CreationTimestamp.Time,
@@ -94,6 +95,11 @@ func convertKubernetesPod(pod *corev1.Pod) (*models.Resource, error) {
 		}
 	}
 
+	// Keep what the pod holds on its node, for metrics served from informers
+	resource.Spec["nodeName"] = pod.Spec.NodeName
+	resource.Spec["requests"] = PodRequests(pod)
+	resource.Spec["limits"] = PodLimits(pod)
+
 	// Set status
 	resource.Status["phase"] = string(pod.Status.Phase)
 	resource.Status["hostIP"] = pod.Status.HostIP
@@ -105,6 +111,29 @@ func convertKubernetesPod(pod *corev1.Pod) (*models.Resource, error) {
 		restartCount += containerStatus.RestartCount
 	}
 	resource.Status["restartCount"] = fmt.Sprintf("%d", restartCount)
+	resource.Restarts = restartCount
+
+	resource.Containers = append(convertContainerStatuses(pod.Status.InitContainerStatuses, true),
+		convertContainerStatuses(pod.Status.ContainerStatuses, false)...)
+	for _, condition := range pod.Status.Conditions {
+		resource.Conditions = append(resource.Conditions, convertCondition(string(condition.Type), string(condition.Status),
+			condition.Reason, condition.Message, condition.LastTransitionTime))
+	}
+
+	// Surface why a container isn't running, e.g. CrashLoopBackOff, or why
+	// the pod stopped, e.g. Evicted
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if waiting := containerStatus.State.Waiting; waiting != nil && waiting.Reason != "" {
+			resource.Status["reason"] = waiting.Reason
+			break
+		}
+	}
+	if _, ok := resource.Status["reason"]; !ok && pod.Status.Reason != "" {
+		resource.Status["reason"] = pod.Status.Reason
+	}
+	if pod.Status.Message != "" {
+		resource.Status["message"] = pod.Status.Message
+	}
 
 	// Compute ready status
 	readyContainers := 0
@@ -129,6 +158,7 @@ func convertKubernetesService(svc *corev1.Service) (*models.Resource, error) {
 		Name:              svc.Name,
 		Namespace:         svc.Namespace,
 		UID:               string(svc.UID),
+		OwnerReferences:   convertOwnerReferences(svc.OwnerReferences),
 		ResourceVersion:   svc.ResourceVersion,
 		Generation:        svc.Generation,
 		CreationTimestamp: svc.CreationTimestamp.Time,
@@ -188,6 +218,7 @@ func convertKubernetesDeployment(dep *appsv1.Deployment) (*models.Resource, erro
 		Name:              dep.Name,
 		Namespace:         dep.Namespace,
 		UID:               string(dep.UID),
+		OwnerReferences:   convertOwnerReferences(dep.OwnerReferences),
 		ResourceVersion:   dep.ResourceVersion,
 		Generation:        dep.Generation,
 		CreationTimestamp: dep.CreationTimestamp.Time,
@@ -225,6 +256,13 @@ func convertKubernetesDeployment(dep *appsv1.Deployment) (*models.Resource, erro
 	resource.Status["readyReplicas"] = fmt.Sprintf("%d", dep.Status.ReadyReplicas)
 	resource.Status["availableReplicas"] = fmt.Sprintf("%d", dep.Status.AvailableReplicas)
 	resource.Status["updatedReplicas"] = fmt.Sprintf("%d", dep.Status.UpdatedReplicas)
+	if dep.Spec.Replicas != nil {
+		resource.Spec["replicas"] = fmt.Sprintf("%d", *dep.Spec.Replicas)
+	}
+	for _, condition := range dep.Status.Conditions {
+		resource.Conditions = append(resource.Conditions, convertCondition(string(condition.Type), string(condition.Status),
+			condition.Reason, condition.Message, condition.LastTransitionTime))
+	}
 
 	// Compute deployment status
 	ready := dep.Status.ReadyReplicas == dep.Status.Replicas && dep.Status.Replicas > 0
@@ -247,6 +285,7 @@ func convertKubernetesConfigMap(cm *corev1.ConfigMap) (*models.Resource, error)
 		Name:              cm.Name,
 		Namespace:         cm.Namespace,
 		UID:               string(cm.UID),
+		OwnerReferences:   convertOwnerReferences(cm.OwnerReferences),
 		ResourceVersion:   cm.ResourceVersion,
 		Generation:        cm.Generation,
 		CreationTimestamp: cm.CreationTimestamp.Time,
@@ -298,6 +337,7 @@ func convertKubernetesSecret(secret *corev1.Secret) (*models.Resource, error) {
 		Name:              secret.Name,
 		Namespace:         secret.Namespace,
 		UID:               string(secret.UID),
+		OwnerReferences:   convertOwnerReferences(secret.OwnerReferences),
 		ResourceVersion:   secret.ResourceVersion,
 		Generation:        secret.Generation,
 		CreationTimestamp: secret.CreationTimestamp.Time,
@@ -521,6 +561,7 @@ func convertKubernetesStatefulSet(sts *appsv1.StatefulSet) (*models.Resource, er
 		Name:              sts.Name,
 		Namespace:         sts.Namespace,
 		UID:               string(sts.UID),
+		OwnerReferences:   convertOwnerReferences(sts.OwnerReferences),
 		ResourceVersion:   sts.ResourceVersion,
 		Generation:        sts.Generation,
 		CreationTimestamp: sts.CreationTimestamp.Time,
@@ -568,6 +609,7 @@ func convertKubernetesIngress(ing *networkingv1.Ingress) (*models.Resource, erro
 		Name:              ing.Name,
 		Namespace:         ing.Namespace,
 		UID:               string(ing.UID),
+		OwnerReferences:   convertOwnerReferences(ing.OwnerReferences),
 		ResourceVersion:   ing.ResourceVersion,
 		Generation:        ing.Generation,
 		CreationTimestamp: ing.CreationTimestamp.Time,
@@ -622,6 +664,7 @@ func convertKubernetesPersistentVolume(pv *corev1.PersistentVolume) (*models.Res
 		Name:              pv.Name,
 		Namespace:         "", // PVs are cluster-scoped
 		UID:               string(pv.UID),
+		OwnerReferences:   convertOwnerReferences(pv.OwnerReferences),
 		ResourceVersion:   pv.ResourceVersion,
 		Generation:        pv.Generation,
 		CreationTimestamp: pv.CreationTimestamp.Time,
@@ -674,6 +717,7 @@ func convertKubernetesPersistentVolumeClaim(pvc *corev1.PersistentVolumeClaim) (
 		Name:              pvc.Name,
 		Namespace:         pvc.Namespace,
 		UID:               string(pvc.UID),
+		OwnerReferences:   convertOwnerReferences(pvc.OwnerReferences),
 		ResourceVersion:   pvc.ResourceVersion,
 		Generation:        pvc.Generation,
 		CreationTimestamp: pvc.CreationTimestamp.Time,
@@ -749,6 +793,7 @@ func convertKubernetesNode(node *corev1.Node) (*models.Resource, error) {
 		Name:              node.Name,
 		Namespace:         "", // Nodes are cluster-scoped
 		UID:               string(node.UID),
+		OwnerReferences:   convertOwnerReferences(node.OwnerReferences),
 		ResourceVersion:   node.ResourceVersion,
 		Generation:        node.Generation,
 		CreationTimestamp: node.CreationTimestamp.Time,
@@ -782,6 +827,8 @@ func convertKubernetesNode(node *corev1.Node) (*models.Resource, error) {
 	for _, condition := range node.Status.Conditions {
 		conditionKey := fmt.Sprintf("condition_%s", condition.Type)
 		resource.Status[conditionKey] = string(condition.Status)
+		resource.Conditions = append(resource.Conditions, convertCondition(string(condition.Type), string(condition.Status),
+			condition.Reason, condition.Message, condition.LastTransitionTime))
 
 		// Special handling for Ready condition
 		if condition.Type == corev1.NodeReady {
@@ -15,7 +15,7 @@ import (
 type MetricsCollector struct {
 	client           *kubernetesclient.KubernetesClient
 	aggregator       *MetricsAggregator
-	storage          *MetricsStorage
+	storage          Storage
 	collectors       map[string]Collector
 	config           *MetricsConfig
 	mu               sync.RWMutex
@@ -23,6 +23,8 @@ type MetricsCollector struct {
 	cancelFunc       context.CancelFunc
 	collectionTicker *time.Ticker
 	running          bool
+	lastCollection   time.Time // when the latest collection ended
+	lastErr          error     // what the latest collection failed with
 }
 
 // MetricsConfig holds configuration for the metrics collector
@@ -34,6 +36,9 @@ type MetricsConfig struct {
 	EnableCustomMetrics bool
 	MaxDataPoints       int
 	AggregationWindow   time.Duration
+	StorageDir          string          // keeps metrics on disk there; empty keeps them in memory
+	Retention           RetentionPolicy // how long the disk storage keeps each resolution
+	Lister              ResourceLister  // lists nodes and pods, e.g. from informers; nil lists them from the API server
 }
 
 // DefaultMetricsConfig returns default configuration for metrics collection
@@ -46,6 +51,7 @@ func DefaultMetricsConfig() *MetricsConfig {
 		EnableCustomMetrics: false,
 		MaxDataPoints:       1000,
 		AggregationWindow:   5 * time.Minute,
+		Retention:           DefaultRetentionPolicy(),
 	}
 }
 
@@ -61,7 +67,15 @@ func NewMetricsCollector(client *kubernetesclient.KubernetesClient, config *Metr
 
 	ctx, cancelFunc := context.WithCancel(context.Background())
 
-	storage := NewMetricsStorage(config.MaxDataPoints, config.RetentionPeriod)
+	var storage Storage = NewMetricsStorage(config.MaxDataPoints, config.RetentionPeriod)
+	if config.StorageDir != "" {
+		diskStorage, err := NewDiskStorage(config.StorageDir, config.Retention)
+		if err != nil {
+			cancelFunc()
+			return nil, fmt.Errorf("failed to open metrics storage: %w", err)
+		}
+		storage = diskStorage
+	}
 	aggregator := NewMetricsAggregator(config.AggregationWindow)
 
 	mc := &MetricsCollector{
@@ -78,6 +92,7 @@ func NewMetricsCollector(client *kubernetesclient.KubernetesClient, config *Metr
 	err := mc.initializeCollectors()
 	if err != nil {
 		cancelFunc()
+		storage.Close()
 		return nil, fmt.Errorf("failed to initialize collectors: %w", err)
 	}
 
@@ -88,13 +103,13 @@ func NewMetricsCollector(client *kubernetesclient.KubernetesClient, config *Metr
 func (mc *MetricsCollector) initializeCollectors() error {
 	// Node metrics collector
 	if mc.config.EnableNodeMetrics {
-		nodeCollector := NewNodeCollector(mc.client)
+		nodeCollector := NewNodeCollector(mc.client, mc.config.Lister)
 		mc.collectors["nodes"] = nodeCollector
 	}
 
 	// Pod metrics collector
 	if mc.config.EnablePodMetrics {
-		podCollector := NewPodCollector(mc.client)
+		podCollector := NewPodCollector(mc.client, mc.config.Lister)
 		mc.collectors["pods"] = podCollector
 	}
 
@@ -153,29 +168,56 @@ func (mc *MetricsCollector) CollectMetrics(ctx context.Context) error {
 	var allMetrics []*models.MetricDataPoint
 	var collectErrors []error
 
-	// Collect from all registered collectors
+	mc.mu.RLock()
+	collectors := make(map[string]Collector, len(mc.collectors))
 	for name, collector := range mc.collectors {
+		collectors[name] = collector
+	}
+	mc.mu.RUnlock()
+
+	// Collect from all registered collectors, keeping what a failing
+	// collector still returned (capacity without metrics-server, say)
+	for name, collector := range collectors {
+		if !collector.IsEnabled() {
+			continue
+		}
 		metrics, err := collector.Collect(ctx)
+		allMetrics = append(allMetrics, metrics...)
 		if err != nil {
 			collectErrors = append(collectErrors, fmt.Errorf("collector %s failed: %w", name, err))
-			continue
 		}
-		allMetrics = append(allMetrics, metrics...)
 	}
 
 	// Store collected metrics
 	for _, metric := range allMetrics {
-		mc.storage.Store(metric)
+		if err := mc.storage.Store(metric); err != nil {
+			collectErrors = append(collectErrors, fmt.Errorf("failed to store metrics: %w", err))
+			break
+		}
 	}
 
 	// Perform aggregation
 	mc.aggregator.ProcessMetrics(allMetrics)
 
+	var err error
 	if len(collectErrors) > 0 {
-		return fmt.Errorf("collection errors: %v", collectErrors)
+		err = fmt.Errorf("collection errors: %v", collectErrors)
 	}
 
-	return nil
+	mc.mu.Lock()
+	mc.lastCollection, mc.lastErr = time.Now(), err
+	mc.mu.Unlock()
+
+	return err
+}
+
+// LastCollection returns when the latest collection ended, zero before the
+// first, and the error it failed with, if any
+func (mc *MetricsCollector) LastCollection() (time.Time, error) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	return mc.lastCollection, mc.lastErr
 }
 
 // GetMetrics retrieves stored metrics with optional filtering
@@ -272,19 +314,23 @@ func (mc *MetricsCollector) GetMetricsStats() *MetricsStats {
 	}
 }
 
-// collectionLoop runs the periodic collection cycle
+// collectionLoop collects right away and then every collection interval.
+// Errors are kept for LastCollection rather than printed, so the loop can
+// run under a terminal UI.
 func (mc *MetricsCollector) collectionLoop() {
+	collect := func() {
+		ctx, cancel := context.WithTimeout(mc.ctx, mc.config.CollectionInterval)
+		defer cancel()
+		mc.CollectMetrics(ctx)
+	}
+
+	collect()
 	for {
 		select {
 		case <-mc.ctx.Done():
 			return
 		case <-mc.collectionTicker.C:
-			err := mc.CollectMetrics(mc.ctx)
-			if err != nil {
-				// Log error but continue collection
-				// In production, this would use a proper logger
-				fmt.Printf("Metrics collection error: %v\n", err)
-			}
+			collect()
 		}
 	}
 }
@@ -322,15 +368,18 @@ func (mc *MetricsCollector) computeClusterMetrics(metrics []*models.MetricDataPo
 
 	nodeSet := make(map[string]bool)
 	podSet := make(map[string]bool)
+	usageNodeSet := make(map[string]bool)
 
-	for _, metric := range metrics {
+	// Every collection adds a sample per series; only the latest counts
+	for _, metric := range latestMetrics(metrics) {
 		clusterMetrics.MetricCounts[string(metric.MetricType)]++
 
 		// Track unique resources by parsing ResourceID
 		// ResourceID format: "ResourceType/Namespace/Name" or "ResourceType//Name" for cluster resources
+		resourceType := ""
 		parts := parseResourceID(metric.ResourceID)
 		if len(parts) >= 2 {
-			resourceType := parts[0]
+			resourceType = parts[0]
 			if resourceType == "Node" {
 				nodeSet[metric.ResourceID] = true
 			} else if resourceType == "Pod" {
@@ -338,27 +387,35 @@ func (mc *MetricsCollector) computeClusterMetrics(metrics []*models.MetricDataPo
 			}
 		}
 
+		// Pods run on the nodes, so cluster CPU and memory come from the
+		// node metrics alone
+		if resourceType != "Node" && (metric.MetricType.Base() == models.MetricTypeCPU || metric.MetricType.Base() == models.MetricTypeMemory) {
+			continue
+		}
+
 		// Aggregate values based on metric type
-		switch string(metric.MetricType) {
-		case "cpu_usage":
+		switch metric.MetricType {
+		case models.MetricTypeCPUUsage:
 			clusterMetrics.UsedCPU += metric.Value
-		case "memory_usage":
+			usageNodeSet[metric.ResourceID] = true
+		case models.MetricTypeMemoryUsage:
 			clusterMetrics.UsedMemory += metric.Value
-		case "cpu_capacity":
+		case models.MetricTypeCPUCapacity:
 			clusterMetrics.TotalCPU += metric.Value
-		case "memory_capacity":
+		case models.MetricTypeMemoryCapacity:
 			clusterMetrics.TotalMemory += metric.Value
-		case "network_rx":
+		case models.MetricTypeNetworkRx:
 			clusterMetrics.NetworkIn += metric.Value
-		case "network_tx":
+		case models.MetricTypeNetworkTx:
 			clusterMetrics.NetworkOut += metric.Value
-		case "storage_usage":
+		case models.MetricTypeStorageUsage:
 			clusterMetrics.StorageUsed += metric.Value
 		}
 	}
 
 	clusterMetrics.NodeCount = len(nodeSet)
 	clusterMetrics.PodCount = len(podSet)
+	clusterMetrics.NodesWithUsage = len(usageNodeSet)
 
 	// Calculate utilization percentages
 	if clusterMetrics.TotalCPU > 0 {
@@ -371,6 +428,29 @@ func (mc *MetricsCollector) computeClusterMetrics(metrics []*models.MetricDataPo
 	return clusterMetrics, nil
 }
 
+// latestMetrics keeps the newest sample of each series, a series being a
+// metric type of one resource
+func latestMetrics(metrics []*models.MetricDataPoint) []*models.MetricDataPoint {
+	latest := make(map[string]*models.MetricDataPoint)
+	var keys []string
+	for _, metric := range metrics {
+		key := string(metric.MetricType) + ":" + metric.ResourceID
+		current, ok := latest[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		if !ok || metric.Timestamp.After(current.Timestamp) {
+			latest[key] = metric
+		}
+	}
+
+	result := make([]*models.MetricDataPoint, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, latest[key])
+	}
+	return result
+}
+
 // parseResourceID parses a ResourceID into components
 func parseResourceID(resourceID string) []string {
 	// Simple parsing - in practice this would be more sophisticated
@@ -392,6 +472,7 @@ type MetricsStats struct {
 type ClusterMetrics struct {
 	Timestamp         time.Time
 	NodeCount         int
+	NodesWithUsage    int // nodes metrics-server reported usage for
 	PodCount          int
 	TotalCPU          float64
 	TotalMemory       float64
@@ -485,7 +566,7 @@ func (mc *MetricsCollector) Close() error {
 
 	// Clean up storage
 	if mc.storage != nil {
-		mc.storage.Close()
+		return mc.storage.Close()
 	}
 
 	return nil
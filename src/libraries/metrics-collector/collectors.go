@@ -0,0 +1,299 @@
+package metricscollector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+	"github.com/anindyar/kuber/src/models"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// apiLister lists resources from the API server on every call
+func apiLister(client *kubernetesclient.KubernetesClient) ResourceLister {
+	return func(ctx context.Context, namespace, resourceType string) ([]*models.Resource, error) {
+		return client.GetResources(ctx, resourceType, namespace)
+	}
+}
+
+// NodeCollector collects node capacity and allocatable resources, and node
+// usage from metrics-server
+type NodeCollector struct {
+	client  *kubernetesclient.KubernetesClient
+	lister  ResourceLister
+	metrics *kubernetesclient.MetricsClient
+}
+
+// NewNodeCollector creates a new node collector listing nodes with lister,
+// or from the API server when it is nil
+func NewNodeCollector(client *kubernetesclient.KubernetesClient, lister ResourceLister) *NodeCollector {
+	if lister == nil {
+		lister = apiLister(client)
+	}
+	return &NodeCollector{client: client, lister: lister}
+}
+
+// Collect implements the Collector interface. Without metrics-server it
+// still returns the capacity metrics, along with the error.
+func (nc *NodeCollector) Collect(ctx context.Context) ([]*models.MetricDataPoint, error) {
+	nodes, err := nc.lister(ctx, "", "nodes")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	now := time.Now()
+	var metrics []*models.MetricDataPoint
+	for _, node := range nodes {
+		resourceID := fmt.Sprintf("Node//%s", node.Metadata.Name)
+		labels := map[string]string{"node": node.Metadata.Name}
+		capacity, _ := node.Status["capacity"].(corev1.ResourceList)
+		allocatable, _ := node.Status["allocatable"].(corev1.ResourceList)
+
+		metrics = appendQuantity(metrics, now, resourceID, models.MetricTypeCPUCapacity, capacity[corev1.ResourceCPU], labels)
+		metrics = appendQuantity(metrics, now, resourceID, models.MetricTypeMemoryCapacity, capacity[corev1.ResourceMemory], labels)
+		metrics = appendQuantity(metrics, now, resourceID, models.MetricTypeCPUAllocatable, allocatable[corev1.ResourceCPU], labels)
+		metrics = appendQuantity(metrics, now, resourceID, models.MetricTypeMemoryAllocatable, allocatable[corev1.ResourceMemory], labels)
+	}
+
+	metricsClient, err := nc.metricsClient()
+	if err != nil {
+		return metrics, err
+	}
+	usage, err := metricsClient.GetNodeMetrics(ctx)
+	if err != nil {
+		return metrics, err
+	}
+	return append(metrics, usageMetrics(usage, nil)...), nil
+}
+
+// metricsClient returns the metrics-server client, creating it on first use
+func (nc *NodeCollector) metricsClient() (*kubernetesclient.MetricsClient, error) {
+	if nc.metrics == nil {
+		metricsClient, err := nc.client.NewMetricsClient()
+		if err != nil {
+			return nil, err
+		}
+		nc.metrics = metricsClient
+	}
+	return nc.metrics, nil
+}
+
+// GetName returns the collector name
+func (nc *NodeCollector) GetName() string {
+	return "node-collector"
+}
+
+// IsEnabled returns whether the collector is enabled
+func (nc *NodeCollector) IsEnabled() bool {
+	return true
+}
+
+// PodCollector collects the requests and limits of running pods, and their
+// usage from metrics-server summed over their containers
+type PodCollector struct {
+	client  *kubernetesclient.KubernetesClient
+	lister  ResourceLister
+	metrics *kubernetesclient.MetricsClient
+}
+
+// NewPodCollector creates a new pod collector listing pods with lister, or
+// from the API server when it is nil
+func NewPodCollector(client *kubernetesclient.KubernetesClient, lister ResourceLister) *PodCollector {
+	if lister == nil {
+		lister = apiLister(client)
+	}
+	return &PodCollector{client: client, lister: lister}
+}
+
+// Collect implements the Collector interface. Without metrics-server it
+// still returns the request and limit metrics, along with the error.
+func (pc *PodCollector) Collect(ctx context.Context) ([]*models.MetricDataPoint, error) {
+	pods, err := pc.lister(ctx, "", "pods")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	now := time.Now()
+	var metrics []*models.MetricDataPoint
+	podLabels := make(map[string]map[string]string, len(pods))
+	for _, pod := range pods {
+		// Finished pods no longer hold resources
+		if phase, _ := pod.Status["phase"].(string); phase == string(corev1.PodSucceeded) || phase == string(corev1.PodFailed) {
+			continue
+		}
+
+		resourceID := fmt.Sprintf("Pod/%s/%s", pod.Metadata.Namespace, pod.Metadata.Name)
+		labels := map[string]string{"namespace": pod.Metadata.Namespace, "pod": pod.Metadata.Name}
+		if node, _ := pod.Spec["nodeName"].(string); node != "" {
+			labels["node"] = node
+		}
+		podLabels[resourceID] = labels
+
+		requests, _ := pod.Spec["requests"].(corev1.ResourceList)
+		limits, _ := pod.Spec["limits"].(corev1.ResourceList)
+		metrics = appendQuantity(metrics, now, resourceID, models.MetricTypeCPURequest, requests[corev1.ResourceCPU], labels)
+		metrics = appendQuantity(metrics, now, resourceID, models.MetricTypeMemoryRequest, requests[corev1.ResourceMemory], labels)
+		metrics = appendQuantity(metrics, now, resourceID, models.MetricTypeCPULimit, limits[corev1.ResourceCPU], labels)
+		metrics = appendQuantity(metrics, now, resourceID, models.MetricTypeMemoryLimit, limits[corev1.ResourceMemory], labels)
+	}
+
+	metricsClient, err := pc.metricsClient()
+	if err != nil {
+		return metrics, err
+	}
+	usage, err := metricsClient.GetPodMetrics(ctx, "")
+	if err != nil {
+		return metrics, err
+	}
+	return append(metrics, usageMetrics(usage, podLabels)...), nil
+}
+
+// metricsClient returns the metrics-server client, creating it on first use
+func (pc *PodCollector) metricsClient() (*kubernetesclient.MetricsClient, error) {
+	if pc.metrics == nil {
+		metricsClient, err := pc.client.NewMetricsClient()
+		if err != nil {
+			return nil, err
+		}
+		pc.metrics = metricsClient
+	}
+	return pc.metrics, nil
+}
+
+// GetName returns the collector name
+func (pc *PodCollector) GetName() string {
+	return "pod-collector"
+}
+
+// IsEnabled returns whether the collector is enabled
+func (pc *PodCollector) IsEnabled() bool {
+	return true
+}
+
+// MetricSource produces custom metrics for a CustomCollector
+type MetricSource func(ctx context.Context) ([]*models.MetricDataPoint, error)
+
+// CustomCollector collects metrics from sources added with AddSource, such
+// as application metrics scraped by the caller
+type CustomCollector struct {
+	client  *kubernetesclient.KubernetesClient
+	sources map[string]MetricSource
+	mu      sync.RWMutex
+}
+
+// NewCustomCollector creates a new custom collector
+func NewCustomCollector(client *kubernetesclient.KubernetesClient) *CustomCollector {
+	return &CustomCollector{client: client, sources: make(map[string]MetricSource)}
+}
+
+// AddSource adds or replaces a named metric source
+func (cc *CustomCollector) AddSource(name string, source MetricSource) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.sources[name] = source
+}
+
+// Collect implements the Collector interface
+func (cc *CustomCollector) Collect(ctx context.Context) ([]*models.MetricDataPoint, error) {
+	cc.mu.RLock()
+	names := make([]string, 0, len(cc.sources))
+	for name := range cc.sources {
+		names = append(names, name)
+	}
+	sources := make(map[string]MetricSource, len(cc.sources))
+	for name, source := range cc.sources {
+		sources[name] = source
+	}
+	cc.mu.RUnlock()
+	sort.Strings(names)
+
+	var metrics []*models.MetricDataPoint
+	var errs []error
+	for _, name := range names {
+		collected, err := sources[name](ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("source %s failed: %w", name, err))
+			continue
+		}
+		metrics = append(metrics, collected...)
+	}
+
+	if len(errs) > 0 {
+		return metrics, fmt.Errorf("custom metric errors: %v", errs)
+	}
+	return metrics, nil
+}
+
+// GetName returns the collector name
+func (cc *CustomCollector) GetName() string {
+	return "custom-collector"
+}
+
+// IsEnabled returns whether the collector has any sources
+func (cc *CustomCollector) IsEnabled() bool {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	return len(cc.sources) > 0
+}
+
+// appendQuantity appends a metric for a resource quantity, in cores for CPU
+// and bytes for memory. Unset quantities are skipped.
+func appendQuantity(metrics []*models.MetricDataPoint, timestamp time.Time, resourceID string, metricType models.MetricType, quantity resource.Quantity, labels map[string]string) []*models.MetricDataPoint {
+	if quantity.IsZero() {
+		return metrics
+	}
+
+	value, unit := float64(quantity.Value()), "bytes"
+	if metricType.Base() == models.MetricTypeCPU {
+		value, unit = float64(quantity.MilliValue())/1000.0, "cores"
+	}
+
+	metric, err := models.NewMetricDataPoint(timestamp, resourceID, metricType, value, unit)
+	if err != nil {
+		return metrics
+	}
+	metric.SetSource("kubernetes-api")
+	for key, label := range labels {
+		metric.SetLabel(key, label)
+	}
+	return append(metrics, metric)
+}
+
+// usageMetrics turns metrics-server readings into cpu_usage and
+// memory_usage metrics, one per resource: container readings are summed per
+// pod. extraLabels adds labels by resource ID, such as the pod's node.
+func usageMetrics(readings []*models.MetricDataPoint, extraLabels map[string]map[string]string) []*models.MetricDataPoint {
+	usageTypes := map[models.MetricType]models.MetricType{
+		models.MetricTypeCPU:    models.MetricTypeCPUUsage,
+		models.MetricTypeMemory: models.MetricTypeMemoryUsage,
+	}
+
+	var metrics []*models.MetricDataPoint
+	byKey := make(map[string]*models.MetricDataPoint)
+	for _, reading := range readings {
+		usageType, ok := usageTypes[reading.MetricType]
+		if !ok {
+			continue
+		}
+
+		key := string(usageType) + ":" + reading.ResourceID
+		if metric, ok := byKey[key]; ok {
+			metric.Value += reading.Value
+			continue
+		}
+
+		metric := reading.Clone()
+		metric.MetricType = usageType
+		metric.RemoveLabel("container")
+		for key, label := range extraLabels[reading.ResourceID] {
+			metric.SetLabel(key, label)
+		}
+		byKey[key] = metric
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
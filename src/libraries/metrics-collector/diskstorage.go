@@ -0,0 +1,515 @@
+package metricscollector
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anindyar/kuber/src/models"
+)
+
+// RetentionPolicy is how long a DiskStorage keeps each resolution. Zero
+// fields take the default.
+type RetentionPolicy struct {
+	Raw        time.Duration
+	Minute     time.Duration // 1m rollups
+	TenMinutes time.Duration // 10m rollups
+	Hour       time.Duration // 1h rollups
+}
+
+// DefaultRetentionPolicy keeps raw samples for 6 hours, 1m rollups for 2
+// days, 10m rollups for 2 weeks and 1h rollups for 90 days
+func DefaultRetentionPolicy() RetentionPolicy {
+	return RetentionPolicy{
+		Raw:        6 * time.Hour,
+		Minute:     48 * time.Hour,
+		TenMinutes: 14 * 24 * time.Hour,
+		Hour:       90 * 24 * time.Hour,
+	}
+}
+
+// withDefaults fills the unset fields from the default policy
+func (p RetentionPolicy) withDefaults() RetentionPolicy {
+	defaults := DefaultRetentionPolicy()
+	if p.Raw <= 0 {
+		p.Raw = defaults.Raw
+	}
+	if p.Minute <= 0 {
+		p.Minute = defaults.Minute
+	}
+	if p.TenMinutes <= 0 {
+		p.TenMinutes = defaults.TenMinutes
+	}
+	if p.Hour <= 0 {
+		p.Hour = defaults.Hour
+	}
+	return p
+}
+
+// rawSampleInterval is the finest spacing of the raw samples of a series: a
+// sample in the same interval as the series' newest one is dropped, so
+// storing the same collection twice, or collecting more often than this,
+// doesn't grow the store
+const rawSampleInterval = 15 * time.Second
+
+// DefaultDataDir returns where kuber keeps its data: $XDG_DATA_HOME/kuber,
+// or ~/.local/share/kuber
+func DefaultDataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "kuber"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", "kuber"), nil
+}
+
+// seriesInfo describes a series: one metric type of one resource
+type seriesInfo struct {
+	ID         uint32            `json:"id"`
+	ResourceID string            `json:"resourceId"`
+	MetricType models.MetricType `json:"metricType"`
+	Unit       string            `json:"unit"`
+	Source     string            `json:"source,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+// DiskStorage keeps metrics on disk, so history survives restarts. Raw
+// samples are rolled up into 1m, 10m and 1h buckets, each resolution kept
+// as long as its RetentionPolicy says. Series are listed in series.jsonl
+// and indexed per segment, so queries read only the series they match.
+// A series keeps at most one raw sample per rawSampleInterval.
+//
+// Records are buffered: a crash may lose the last few seconds.
+type DiskStorage struct {
+	dir            string
+	tiers          []*tier // finest first; tiers[0] holds the raw samples
+	series         map[uint32]*seriesInfo
+	seriesByKey    map[string]uint32
+	nextID         uint32
+	catalog        *os.File
+	lock           *os.File
+	lastCollection time.Time
+	closed         bool
+	mu             sync.RWMutex
+}
+
+// NewDiskStorage opens or creates a metrics store in dir. Only one process
+// can have a store open.
+func NewDiskStorage(dir string, policy RetentionPolicy) (*DiskStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	lock, err := lockDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ds := &DiskStorage{
+		dir:         dir,
+		series:      make(map[uint32]*seriesInfo),
+		seriesByKey: make(map[string]uint32),
+		lock:        lock,
+	}
+	if err := ds.open(policy.withDefaults()); err != nil {
+		ds.closeFiles()
+		return nil, err
+	}
+	return ds, nil
+}
+
+// open loads the series catalog and the tiers
+func (ds *DiskStorage) open(policy RetentionPolicy) error {
+	if err := ds.loadCatalog(); err != nil {
+		return err
+	}
+
+	// A segment holds about 120 records per series
+	tiers := []struct {
+		name      string
+		step      time.Duration
+		span      time.Duration
+		retention time.Duration
+	}{
+		{"raw", 0, time.Hour, policy.Raw},
+		{"1m", time.Minute, 2 * time.Hour, policy.Minute},
+		{"10m", 10 * time.Minute, 12 * time.Hour, policy.TenMinutes},
+		{"1h", time.Hour, 72 * time.Hour, policy.Hour},
+	}
+	for _, spec := range tiers {
+		t, err := openTier(ds.dir, spec.name, spec.step, spec.span, spec.retention)
+		if err != nil {
+			return err
+		}
+		ds.tiers = append(ds.tiers, t)
+	}
+	return ds.cleanup()
+}
+
+// loadCatalog reads the series catalog, where later lines update earlier
+// ones, and opens it for appending
+func (ds *DiskStorage) loadCatalog() error {
+	path := filepath.Join(ds.dir, "series.jsonl")
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	ds.catalog = file
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var info seriesInfo
+		// A line cut short by a crash is skipped
+		if err := json.Unmarshal(scanner.Bytes(), &info); err != nil {
+			continue
+		}
+		ds.addSeries(&info)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return nil
+}
+
+// addSeries registers a series in memory
+func (ds *DiskStorage) addSeries(info *seriesInfo) {
+	ds.series[info.ID] = info
+	ds.seriesByKey[seriesKey(info.ResourceID, info.MetricType)] = info.ID
+	if info.ID >= ds.nextID {
+		ds.nextID = info.ID + 1
+	}
+}
+
+// seriesKey identifies a series by resource and metric type
+func seriesKey(resourceID string, metricType models.MetricType) string {
+	return string(metricType) + ":" + resourceID
+}
+
+// seriesFor returns the series of a metric, adding it to the catalog, or
+// updating its labels there, as needed
+func (ds *DiskStorage) seriesFor(metric *models.MetricDataPoint) (uint32, error) {
+	id, ok := ds.seriesByKey[seriesKey(metric.ResourceID, metric.MetricType)]
+	if ok {
+		info := ds.series[id]
+		if info.Unit == metric.Unit && info.Source == metric.Source && maps.Equal(info.Labels, metric.Labels) {
+			return id, nil
+		}
+		info.Unit, info.Source, info.Labels = metric.Unit, metric.Source, maps.Clone(metric.Labels)
+		return id, ds.writeCatalog(info)
+	}
+
+	info := &seriesInfo{
+		ID:         ds.nextID,
+		ResourceID: metric.ResourceID,
+		MetricType: metric.MetricType,
+		Unit:       metric.Unit,
+		Source:     metric.Source,
+		Labels:     maps.Clone(metric.Labels),
+	}
+	ds.addSeries(info)
+	return info.ID, ds.writeCatalog(info)
+}
+
+// writeCatalog appends a series to the catalog
+func (ds *DiskStorage) writeCatalog(info *seriesInfo) error {
+	line, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("failed to encode series: %w", err)
+	}
+	if _, err := ds.catalog.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write series catalog: %w", err)
+	}
+	return nil
+}
+
+// compactCatalog rewrites the catalog without the series no tier holds
+// records for any more, such as those of deleted pods
+func (ds *DiskStorage) compactCatalog() error {
+	live := make(map[uint32]bool)
+	for _, t := range ds.tiers {
+		t.liveSeries(live)
+	}
+	if len(live) == len(ds.series) {
+		return nil
+	}
+
+	ids := make([]uint32, 0, len(live))
+	for id, info := range ds.series {
+		if !live[id] {
+			delete(ds.series, id)
+			delete(ds.seriesByKey, seriesKey(info.ResourceID, info.MetricType))
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var data []byte
+	for _, id := range ids {
+		line, err := json.Marshal(ds.series[id])
+		if err != nil {
+			return fmt.Errorf("failed to encode series: %w", err)
+		}
+		data = append(append(data, line...), '\n')
+	}
+
+	path := filepath.Join(ds.dir, "series.jsonl")
+	if err := writeFileAtomic(path, data); err != nil {
+		return err
+	}
+	ds.catalog.Close()
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	ds.catalog = file
+	return nil
+}
+
+// Store appends a metric to the raw samples and rolls it up, unless its
+// series already has a sample in the same rawSampleInterval
+func (ds *DiskStorage) Store(metric *models.MetricDataPoint) error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.closed {
+		return fmt.Errorf("metrics storage is closed")
+	}
+
+	id, err := ds.seriesFor(metric)
+	if err != nil {
+		return err
+	}
+	raw := newSample(id, metric.Timestamp.UnixNano(), metric.Value)
+	if ds.tiers[0].duplicate(raw, rawSampleInterval) {
+		return nil
+	}
+
+	sealed, err := ds.tiers[0].append(raw)
+	if err != nil {
+		return err
+	}
+	for _, t := range ds.tiers[1:] {
+		if err := t.rollUp(raw); err != nil {
+			return err
+		}
+	}
+	ds.lastCollection = time.Now()
+
+	// Expired data goes whenever a raw segment is sealed, once an hour
+	if sealed {
+		return ds.cleanup()
+	}
+	return nil
+}
+
+// GetMetrics returns the metrics matching a filter, oldest first. Queries
+// reaching further back than raw samples are kept, or asking for a coarser
+// Resolution, are answered from rollups: one metric per bucket, holding
+// the bucket's average, with Interval set to the bucket size.
+func (ds *DiskStorage) GetMetrics(filter *MetricsFilter) ([]*models.MetricDataPoint, error) {
+	// Reading a head flushes its log
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.closed {
+		return nil, fmt.Errorf("metrics storage is closed")
+	}
+
+	from, to := int64(math.MinInt64), int64(math.MaxInt64)
+	ids := make(map[uint32]bool)
+	for id, info := range ds.series {
+		if filter == nil || matchesSeries(info.ResourceID, info.MetricType, info.Labels, filter) {
+			ids[id] = true
+		}
+	}
+	if filter != nil {
+		if !filter.TimeRange.Start.IsZero() {
+			from = filter.TimeRange.Start.UnixNano()
+		}
+		if !filter.TimeRange.End.IsZero() {
+			to = filter.TimeRange.End.UnixNano()
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	t := ds.queryTier(filter)
+	samples, err := t.query(ids, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := make([]*models.MetricDataPoint, 0, len(samples))
+	for _, s := range samples {
+		metrics = append(metrics, ds.toMetric(t, s))
+	}
+	return metrics, nil
+}
+
+// queryTier picks the finest resolution at least as coarse as the filter
+// asks for that is still kept for the start of its time range
+func (ds *DiskStorage) queryTier(filter *MetricsFilter) *tier {
+	if filter == nil {
+		return ds.tiers[0]
+	}
+	for _, t := range ds.tiers {
+		if t.step < filter.Resolution {
+			continue
+		}
+		if filter.TimeRange.Start.IsZero() || time.Since(filter.TimeRange.Start) <= t.retention {
+			return t
+		}
+	}
+	return ds.tiers[len(ds.tiers)-1]
+}
+
+// toMetric turns a record into a metric data point
+func (ds *DiskStorage) toMetric(t *tier, s sample) *models.MetricDataPoint {
+	info := ds.series[s.Series]
+	metric := &models.MetricDataPoint{
+		Timestamp: time.Unix(0, s.Time),
+		Value:     s.Value(),
+		Interval:  t.step,
+	}
+	if info != nil {
+		metric.ResourceID = info.ResourceID
+		metric.MetricType = info.MetricType
+		metric.Unit = info.Unit
+		metric.Source = info.Source
+		metric.Labels = maps.Clone(info.Labels)
+	}
+	return metric
+}
+
+// Cleanup removes expired segments and forgets series without records
+func (ds *DiskStorage) Cleanup() error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.closed {
+		return nil
+	}
+	return ds.cleanup()
+}
+
+// cleanup expires segments, appending the rollup buckets of series that
+// stopped reporting first
+func (ds *DiskStorage) cleanup() error {
+	now := time.Now()
+	for _, t := range ds.tiers {
+		if t.step > 0 {
+			if err := t.flushOpen(now.Add(-t.step).UnixNano()); err != nil {
+				return err
+			}
+		}
+		if err := t.expire(now); err != nil {
+			return err
+		}
+	}
+	return ds.compactCatalog()
+}
+
+// GetTotalCount returns the number of raw samples kept
+func (ds *DiskStorage) GetTotalCount() int {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+	return ds.tiers[0].records()
+}
+
+// GetLastCollectionTime returns when a metric was last stored
+func (ds *DiskStorage) GetLastCollectionTime() time.Time {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+	return ds.lastCollection
+}
+
+// GetStorageSize returns the bytes the store takes on disk
+func (ds *DiskStorage) GetStorageSize() int64 {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	var size int64
+	for _, t := range ds.tiers {
+		size += t.size()
+	}
+	if info, err := os.Stat(filepath.Join(ds.dir, "series.jsonl")); err == nil {
+		size += info.Size()
+	}
+	return size
+}
+
+// GetOldestMetric returns the oldest raw sample kept
+func (ds *DiskStorage) GetOldestMetric() *models.MetricDataPoint {
+	return ds.edgeMetric(true)
+}
+
+// GetNewestMetric returns the newest raw sample kept
+func (ds *DiskStorage) GetNewestMetric() *models.MetricDataPoint {
+	return ds.edgeMetric(false)
+}
+
+// edgeMetric returns the oldest or newest raw sample, or nil
+func (ds *DiskStorage) edgeMetric(oldest bool) *models.MetricDataPoint {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.closed {
+		return nil
+	}
+	s, ok, err := ds.tiers[0].edge(oldest)
+	if err != nil || !ok {
+		return nil
+	}
+	return ds.toMetric(ds.tiers[0], s)
+}
+
+// Close writes out the rollup buckets still filling and closes the store;
+// the heads are replayed when it is opened again
+func (ds *DiskStorage) Close() error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.closed {
+		return nil
+	}
+	ds.closed = true
+
+	var errs []error
+	for _, t := range ds.tiers {
+		if err := t.flushOpen(math.MaxInt64); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(append(errs, ds.closeFiles())...)
+}
+
+// closeFiles closes the segment logs and the catalog, and releases the lock
+func (ds *DiskStorage) closeFiles() error {
+	var errs []error
+	for _, t := range ds.tiers {
+		if err := t.closeHead(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if ds.catalog != nil {
+		if err := ds.catalog.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close series catalog: %w", err))
+		}
+	}
+	if ds.lock != nil {
+		ds.lock.Close()
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,247 @@
+package metricscollector
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/anindyar/kuber/src/models"
+)
+
+// openTestStore opens a store in dir with the default retention
+func openTestStore(t *testing.T, dir string) *DiskStorage {
+	t.Helper()
+
+	ds, err := NewDiskStorage(dir, RetentionPolicy{})
+	if err != nil {
+		t.Fatalf("NewDiskStorage: %v", err)
+	}
+	return ds
+}
+
+// storeAt stores a CPU usage sample of a resource
+func storeAt(t *testing.T, ds *DiskStorage, resourceID string, at time.Time, value float64) {
+	t.Helper()
+
+	metric := &models.MetricDataPoint{
+		Timestamp:  at,
+		ResourceID: resourceID,
+		MetricType: models.MetricTypeCPUUsage,
+		Value:      value,
+		Unit:       "millicores",
+	}
+	if err := ds.Store(metric); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+}
+
+// queryValues returns the values stored for a node between from and to
+func queryValues(t *testing.T, ds *DiskStorage, node string, from, to time.Time, resolution time.Duration) []float64 {
+	t.Helper()
+
+	metrics, err := ds.GetMetrics(&MetricsFilter{
+		ResourceName: node,
+		TimeRange:    TimeRange{Start: from, End: to},
+		Resolution:   resolution,
+	})
+	if err != nil {
+		t.Fatalf("GetMetrics: %v", err)
+	}
+	values := make([]float64, 0, len(metrics))
+	for _, metric := range metrics {
+		if metric.Interval != resolution {
+			t.Errorf("Interval = %v, want %v", metric.Interval, resolution)
+		}
+		values = append(values, metric.Value)
+	}
+	return values
+}
+
+// equalValues reports whether two value lists are the same
+func equalValues(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// testHour returns the start of an hour recent enough to be kept
+func testHour() time.Time {
+	return time.Now().Truncate(time.Hour).Add(-2 * time.Hour)
+}
+
+func TestDiskStorageRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	hour := testHour()
+
+	ds := openTestStore(t, dir)
+	var want []float64
+	for i := 0; i < 8; i++ {
+		at := hour.Add(time.Duration(i) * 15 * time.Second)
+		storeAt(t, ds, "nodes/node-a", at, float64(i))
+		storeAt(t, ds, "nodes/node-b", at, float64(100+i))
+		want = append(want, float64(i))
+	}
+	if err := ds.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	ds = openTestStore(t, dir)
+	defer ds.Close()
+
+	if got := ds.GetTotalCount(); got != 16 {
+		t.Errorf("GetTotalCount = %d, want 16", got)
+	}
+	if got := queryValues(t, ds, "node-a", hour, hour.Add(time.Hour), 0); !equalValues(got, want) {
+		t.Errorf("node-a values = %v, want %v", got, want)
+	}
+	if oldest := ds.GetOldestMetric(); oldest == nil || !oldest.Timestamp.Equal(hour) {
+		t.Errorf("GetOldestMetric = %v, want a sample at %v", oldest, hour)
+	}
+}
+
+func TestDiskStorageSealsAtSpanBoundary(t *testing.T) {
+	dir := t.TempDir()
+	hour := testHour()
+	start := strconv.FormatInt(hour.UnixNano(), 10)
+	next := strconv.FormatInt(hour.Add(time.Hour).UnixNano(), 10)
+
+	ds := openTestStore(t, dir)
+	storeAt(t, ds, "nodes/node-a", hour.Add(59*time.Minute), 1)
+	if _, err := os.Stat(filepath.Join(dir, "raw", start+".wal")); err != nil {
+		t.Fatalf("head log missing before the span ends: %v", err)
+	}
+
+	storeAt(t, ds, "nodes/node-a", hour.Add(time.Hour), 2)
+	for _, name := range []string{start + ".seg", start + ".idx", next + ".wal"} {
+		if _, err := os.Stat(filepath.Join(dir, "raw", name)); err != nil {
+			t.Errorf("%s missing after sealing: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "raw", start+".wal")); !os.IsNotExist(err) {
+		t.Errorf("sealed segment's log still exists: %v", err)
+	}
+
+	want := []float64{1, 2}
+	if got := queryValues(t, ds, "node-a", hour, hour.Add(2*time.Hour), 0); !equalValues(got, want) {
+		t.Errorf("values = %v, want %v", got, want)
+	}
+	if err := ds.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	ds = openTestStore(t, dir)
+	defer ds.Close()
+	if got := queryValues(t, ds, "node-a", hour, hour.Add(2*time.Hour), 0); !equalValues(got, want) {
+		t.Errorf("values after reopening = %v, want %v", got, want)
+	}
+}
+
+func TestDiskStorageDropsTruncatedLogTail(t *testing.T) {
+	dir := t.TempDir()
+	hour := testHour()
+
+	ds := openTestStore(t, dir)
+	for i := 0; i < 4; i++ {
+		storeAt(t, ds, "nodes/node-a", hour.Add(time.Duration(i)*15*time.Second), float64(i))
+	}
+	if err := ds.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// A record cut short by a crash
+	path := filepath.Join(dir, "raw", strconv.FormatInt(hour.UnixNano(), 10)+".wal")
+	log, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := log.Write([]byte{1, 2, 3, 4, 5}); err != nil {
+		t.Fatal(err)
+	}
+	log.Close()
+
+	ds = openTestStore(t, dir)
+	defer ds.Close()
+
+	if got := ds.GetTotalCount(); got != 4 {
+		t.Errorf("GetTotalCount = %d, want 4", got)
+	}
+
+	// Records appended after replay line up with the ones before
+	storeAt(t, ds, "nodes/node-a", hour.Add(time.Minute), 4)
+	want := []float64{0, 1, 2, 3, 4}
+	if got := queryValues(t, ds, "node-a", hour, hour.Add(time.Hour), 0); !equalValues(got, want) {
+		t.Errorf("values = %v, want %v", got, want)
+	}
+}
+
+func TestDiskStorageDropsDuplicateSamples(t *testing.T) {
+	ds := openTestStore(t, t.TempDir())
+	defer ds.Close()
+	hour := testHour()
+
+	storeAt(t, ds, "nodes/node-a", hour, 1)
+	storeAt(t, ds, "nodes/node-a", hour, 1)
+	storeAt(t, ds, "nodes/node-a", hour.Add(5*time.Second), 2)
+	if got := ds.GetTotalCount(); got != 1 {
+		t.Errorf("GetTotalCount after samples in one interval = %d, want 1", got)
+	}
+
+	// Other series and later intervals are kept
+	storeAt(t, ds, "nodes/node-b", hour, 1)
+	storeAt(t, ds, "nodes/node-a", hour.Add(rawSampleInterval), 3)
+	if got := ds.GetTotalCount(); got != 3 {
+		t.Errorf("GetTotalCount = %d, want 3", got)
+	}
+
+	want := []float64{1, 3}
+	if got := queryValues(t, ds, "node-a", hour, hour.Add(time.Hour), 0); !equalValues(got, want) {
+		t.Errorf("values = %v, want %v", got, want)
+	}
+}
+
+func TestDiskStorageRollupAverages(t *testing.T) {
+	dir := t.TempDir()
+	hour := testHour()
+
+	ds := openTestStore(t, dir)
+	values := []float64{1, 2, 3, 4, 10, 20, 30, 40}
+	for i, value := range values {
+		storeAt(t, ds, "nodes/node-a", hour.Add(time.Duration(i)*15*time.Second), value)
+	}
+
+	tests := []struct {
+		resolution time.Duration
+		want       []float64
+	}{
+		{0, values},
+		{time.Minute, []float64{2.5, 25}},
+		{10 * time.Minute, []float64{13.75}},
+		{time.Hour, []float64{13.75}},
+	}
+	check := func(when string) {
+		for _, tt := range tests {
+			got := queryValues(t, ds, "node-a", hour, hour.Add(time.Hour), tt.resolution)
+			if !equalValues(got, tt.want) {
+				t.Errorf("%s: values at %v = %v, want %v", when, tt.resolution, got, tt.want)
+			}
+		}
+	}
+
+	// The newest buckets are still filling
+	check("open buckets")
+
+	if err := ds.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	ds = openTestStore(t, dir)
+	defer ds.Close()
+	check("after reopening")
+}
@@ -0,0 +1,140 @@
+package metricscollector
+
+import (
+	"sort"
+
+	"github.com/anindyar/kuber/src/models"
+)
+
+// ResourceUsage is one resource of a node: what it has, what the pods
+// scheduled on it request and are limited to, and what is in use.
+// CPU is in cores, memory in bytes.
+type ResourceUsage struct {
+	Capacity    float64
+	Allocatable float64
+	Requests    float64
+	Limits      float64
+	Usage       float64
+	HasUsage    bool // metrics-server reported usage
+}
+
+// Add adds another node's figures, e.g. for cluster totals
+func (r *ResourceUsage) Add(other ResourceUsage) {
+	r.Capacity += other.Capacity
+	r.Allocatable += other.Allocatable
+	r.Requests += other.Requests
+	r.Limits += other.Limits
+	r.Usage += other.Usage
+	r.HasUsage = r.HasUsage || other.HasUsage
+}
+
+// RequestsPercent returns the requests as a percentage of allocatable
+func (r ResourceUsage) RequestsPercent() float64 {
+	return percentOf(r.Requests, r.Allocatable)
+}
+
+// LimitsPercent returns the limits as a percentage of allocatable; above
+// 100 the node is overcommitted
+func (r ResourceUsage) LimitsPercent() float64 {
+	return percentOf(r.Limits, r.Allocatable)
+}
+
+// UsagePercent returns the usage as a percentage of allocatable, as
+// kubectl top node does
+func (r ResourceUsage) UsagePercent() float64 {
+	return percentOf(r.Usage, r.Allocatable)
+}
+
+// Overcommit returns the limits over allocatable, e.g. 1.5 when the pods
+// may use half again what the node has; 0 without allocatable
+func (r ResourceUsage) Overcommit() float64 {
+	if r.Allocatable <= 0 {
+		return 0
+	}
+	return r.Limits / r.Allocatable
+}
+
+// percentOf returns part as a percentage of total, 0 without total
+func percentOf(part, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return part / total * 100
+}
+
+// NodeUsage is the CPU and memory of one node
+type NodeUsage struct {
+	Node   string
+	CPU    ResourceUsage
+	Memory ResourceUsage
+}
+
+// GetNodeUsage returns the latest collected figures of every node, with the
+// requests and limits of the pods on it added up, sorted by node name
+func (mc *MetricsCollector) GetNodeUsage(timeRange TimeRange) ([]NodeUsage, error) {
+	metrics, err := mc.GetMetrics(&MetricsFilter{TimeRange: timeRange})
+	if err != nil {
+		return nil, err
+	}
+	return computeNodeUsage(latestMetrics(metrics)), nil
+}
+
+// computeNodeUsage adds up node and pod metrics by node
+func computeNodeUsage(metrics []*models.MetricDataPoint) []NodeUsage {
+	byNode := make(map[string]*NodeUsage)
+	node := func(name string) *NodeUsage {
+		usage, ok := byNode[name]
+		if !ok {
+			usage = &NodeUsage{Node: name}
+			byNode[name] = usage
+		}
+		return usage
+	}
+
+	for _, metric := range metrics {
+		name := metric.GetLabel("node")
+		if name == "" {
+			continue
+		}
+
+		switch {
+		case metric.IsFromNode():
+			usage := node(name)
+			resource := &usage.CPU
+			if metric.MetricType.Base() == models.MetricTypeMemory {
+				resource = &usage.Memory
+			}
+			switch metric.MetricType {
+			case models.MetricTypeCPUCapacity, models.MetricTypeMemoryCapacity:
+				resource.Capacity = metric.Value
+			case models.MetricTypeCPUAllocatable, models.MetricTypeMemoryAllocatable:
+				resource.Allocatable = metric.Value
+			case models.MetricTypeCPUUsage, models.MetricTypeMemoryUsage:
+				resource.Usage = metric.Value
+				resource.HasUsage = true
+			}
+
+		case metric.IsFromPod():
+			// Usage comes from the node itself, which includes system daemons
+			switch metric.MetricType {
+			case models.MetricTypeCPURequest:
+				node(name).CPU.Requests += metric.Value
+			case models.MetricTypeCPULimit:
+				node(name).CPU.Limits += metric.Value
+			case models.MetricTypeMemoryRequest:
+				node(name).Memory.Requests += metric.Value
+			case models.MetricTypeMemoryLimit:
+				node(name).Memory.Limits += metric.Value
+			}
+		}
+	}
+
+	nodes := make([]NodeUsage, 0, len(byNode))
+	for _, usage := range byNode {
+		nodes = append(nodes, *usage)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Node < nodes[j].Node
+	})
+	return nodes
+}
@@ -0,0 +1,816 @@
+package metricscollector
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A tier keeps the samples of one resolution in segment files of
+// fixed-size little-endian records, each segment covering a span of time.
+// The newest segment is the head: its records are appended to <start>.wal,
+// and only the number and time of each series' records are kept in memory,
+// so a query reads just the records in its range. Once
+// samples arrive for a later span the head is sealed: its records are
+// rewritten sorted by series and time into <start>.seg, and <start>.idx
+// records where each series lies, so a query reads only the series it
+// matches.
+
+const (
+	segmentMagic     = "KUBERTS1"
+	rawRecordSize    = 4 + 8 + 8         // series, time, value
+	rollupRecordSize = 4 + 8 + 4 + 4*8   // series, bucket start, count, sum, min, max, last
+	indexEntrySize   = 4 + 4 + 4 + 8 + 8 // series, first record, records, min and max time
+	headReadSize     = 4096              // bytes read from a head's log at once
+)
+
+// sample is one record of a tier: a raw sample, with a count of 1, or a
+// rollup bucket
+type sample struct {
+	Series uint32
+	Time   int64 // unix nanoseconds; the start of the bucket for rollups
+	Count  uint32
+	Sum    float64
+	Min    float64
+	Max    float64
+	Last   float64
+}
+
+// newSample returns a raw sample
+func newSample(series uint32, timestamp int64, value float64) sample {
+	return sample{Series: series, Time: timestamp, Count: 1, Sum: value, Min: value, Max: value, Last: value}
+}
+
+// Value returns the sample, or the average of a bucket
+func (s sample) Value() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Sum / float64(s.Count)
+}
+
+// merge adds a later part of the same bucket
+func (s *sample) merge(other sample) {
+	s.Count += other.Count
+	s.Sum += other.Sum
+	s.Min = math.Min(s.Min, other.Min)
+	s.Max = math.Max(s.Max, other.Max)
+	s.Last = other.Last
+}
+
+// indexEntry locates the records of a series in a sealed segment
+type indexEntry struct {
+	First   uint32
+	Count   uint32
+	MinTime int64
+	MaxTime int64
+}
+
+// sealedSegment is the index of a sealed segment, kept in memory
+type sealedSegment struct {
+	start   int64
+	minTime int64
+	maxTime int64
+	records int
+	size    int64
+	series  map[uint32]indexEntry
+}
+
+// headSegment is the segment a tier is appending to. Its records are read
+// back from the log; only their numbers and times are kept, by series.
+type headSegment struct {
+	start   int64
+	file    *os.File
+	writer  *bufio.Writer
+	minTime int64
+	maxTime int64
+	oldest  uint32 // the record holding minTime
+	newest  uint32 // the record holding maxTime
+	records int
+	series  map[uint32]*headSeries
+}
+
+// headSeries locates the records of a series in the head
+type headSeries struct {
+	records []uint32 // in the order written
+	times   []int64  // time of each record
+	latest  int64    // time of the newest record
+}
+
+// add keeps track of a record appended to the head
+func (h *headSegment) add(s sample) {
+	record := uint32(h.records)
+	if h.records == 0 || s.Time < h.minTime {
+		h.minTime, h.oldest = s.Time, record
+	}
+	if h.records == 0 || s.Time > h.maxTime {
+		h.maxTime, h.newest = s.Time, record
+	}
+	h.records++
+
+	series, ok := h.series[s.Series]
+	if !ok {
+		series = &headSeries{latest: s.Time}
+		h.series[s.Series] = series
+	}
+	series.records = append(series.records, record)
+	series.times = append(series.times, s.Time)
+	series.latest = max(series.latest, s.Time)
+}
+
+// tier is one resolution of a DiskStorage
+type tier struct {
+	name      string        // directory name, e.g. "1m"
+	step      time.Duration // bucket size; 0 keeps raw samples
+	span      time.Duration // time covered by a segment
+	retention time.Duration
+	dir       string
+	head      *headSegment
+	sealed    []*sealedSegment   // oldest first
+	open      map[uint32]*sample // rollup buckets still filling, by series
+}
+
+// openTier opens a tier's directory, loading the indexes of its sealed
+// segments and replaying its head
+func openTier(root, name string, step, span, retention time.Duration) (*tier, error) {
+	t := &tier{
+		name:      name,
+		step:      step,
+		span:      span,
+		retention: retention,
+		dir:       filepath.Join(root, name),
+		open:      make(map[uint32]*sample),
+	}
+	if err := os.MkdirAll(t.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", t.dir, err)
+	}
+
+	entries, err := os.ReadDir(t.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", t.dir, err)
+	}
+	var heads, sealed []int64
+	files := make(map[string]bool)
+	for _, entry := range entries {
+		name := entry.Name()
+		files[name] = true
+		ext := filepath.Ext(name)
+		if ext == ".tmp" {
+			// Left behind by a seal that did not finish
+			os.Remove(filepath.Join(t.dir, name))
+			continue
+		}
+		start, err := strconv.ParseInt(strings.TrimSuffix(name, ext), 10, 64)
+		if err != nil {
+			continue
+		}
+		switch ext {
+		case ".wal":
+			heads = append(heads, start)
+		case ".seg":
+			sealed = append(sealed, start)
+		}
+	}
+
+	sort.Slice(sealed, func(i, j int) bool { return sealed[i] < sealed[j] })
+	for _, start := range sealed {
+		// A segment still having its log was not sealed completely
+		if files[strconv.FormatInt(start, 10)+".wal"] || !files[strconv.FormatInt(start, 10)+".idx"] {
+			continue
+		}
+		segment, err := t.loadSealed(start)
+		if err != nil {
+			return nil, err
+		}
+		t.sealed = append(t.sealed, segment)
+	}
+
+	// Only the newest log is the head; older ones are sealed now
+	sort.Slice(heads, func(i, j int) bool { return heads[i] < heads[j] })
+	for _, start := range heads {
+		if t.head != nil {
+			if err := t.seal(); err != nil {
+				return nil, err
+			}
+		}
+		head, err := t.replayHead(start)
+		if err != nil {
+			return nil, err
+		}
+		t.head = head
+	}
+	return t, nil
+}
+
+// path returns the path of a segment file
+func (t *tier) path(start int64, ext string) string {
+	return filepath.Join(t.dir, strconv.FormatInt(start, 10)+ext)
+}
+
+// recordSize returns the size of the tier's records
+func (t *tier) recordSize() int {
+	if t.step == 0 {
+		return rawRecordSize
+	}
+	return rollupRecordSize
+}
+
+// encode writes a record into buf
+func (t *tier) encode(buf []byte, s sample) {
+	binary.LittleEndian.PutUint32(buf[0:], s.Series)
+	binary.LittleEndian.PutUint64(buf[4:], uint64(s.Time))
+	if t.step == 0 {
+		binary.LittleEndian.PutUint64(buf[12:], math.Float64bits(s.Last))
+		return
+	}
+	binary.LittleEndian.PutUint32(buf[12:], s.Count)
+	binary.LittleEndian.PutUint64(buf[16:], math.Float64bits(s.Sum))
+	binary.LittleEndian.PutUint64(buf[24:], math.Float64bits(s.Min))
+	binary.LittleEndian.PutUint64(buf[32:], math.Float64bits(s.Max))
+	binary.LittleEndian.PutUint64(buf[40:], math.Float64bits(s.Last))
+}
+
+// decode reads a record from buf
+func (t *tier) decode(buf []byte) sample {
+	series := binary.LittleEndian.Uint32(buf[0:])
+	timestamp := int64(binary.LittleEndian.Uint64(buf[4:]))
+	if t.step == 0 {
+		return newSample(series, timestamp, math.Float64frombits(binary.LittleEndian.Uint64(buf[12:])))
+	}
+	return sample{
+		Series: series,
+		Time:   timestamp,
+		Count:  binary.LittleEndian.Uint32(buf[12:]),
+		Sum:    math.Float64frombits(binary.LittleEndian.Uint64(buf[16:])),
+		Min:    math.Float64frombits(binary.LittleEndian.Uint64(buf[24:])),
+		Max:    math.Float64frombits(binary.LittleEndian.Uint64(buf[32:])),
+		Last:   math.Float64frombits(binary.LittleEndian.Uint64(buf[40:])),
+	}
+}
+
+// segmentStart returns the start of the span a time falls in
+func (t *tier) segmentStart(timestamp int64) int64 {
+	return timestamp - timestamp%int64(t.span)
+}
+
+// append writes a record to the head, sealing it first when the record
+// belongs to a later span. It reports whether the head was sealed. Late
+// records go to the head too: segments track the times they hold.
+func (t *tier) append(s sample) (bool, error) {
+	start := t.segmentStart(s.Time)
+	sealed := false
+	if t.head != nil && start > t.head.start {
+		if err := t.seal(); err != nil {
+			return false, err
+		}
+		sealed = true
+	}
+	if t.head == nil {
+		// Never reuse the name of a sealed segment
+		if n := len(t.sealed); n > 0 && start <= t.sealed[n-1].start {
+			start = t.sealed[n-1].start + int64(t.span)
+		}
+		head, err := t.createHead(start)
+		if err != nil {
+			return false, err
+		}
+		t.head = head
+	}
+
+	buf := make([]byte, t.recordSize())
+	t.encode(buf, s)
+	if _, err := t.head.writer.Write(buf); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", t.head.file.Name(), err)
+	}
+	t.head.add(s)
+	return sealed, nil
+}
+
+// duplicate reports whether the head already holds a record of the sample's
+// series in the same interval as the sample, counting from its newest
+func (t *tier) duplicate(s sample, interval time.Duration) bool {
+	if t.head == nil {
+		return false
+	}
+	series, ok := t.head.series[s.Series]
+	if !ok {
+		return false
+	}
+	return series.latest-series.latest%int64(interval) == s.Time-s.Time%int64(interval)
+}
+
+// rollUp adds a raw sample to its bucket, appending the buckets it closes
+func (t *tier) rollUp(raw sample) error {
+	bucket := raw.Time - raw.Time%int64(t.step)
+	s := raw
+	s.Time = bucket
+
+	open, ok := t.open[raw.Series]
+	switch {
+	case !ok:
+		t.open[raw.Series] = &s
+	case open.Time == bucket:
+		open.merge(s)
+	case open.Time < bucket:
+		if _, err := t.append(*open); err != nil {
+			return err
+		}
+		t.open[raw.Series] = &s
+	default:
+		// A late sample makes a bucket of its own, merged when read
+		if _, err := t.append(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flushOpen appends the open buckets that ended by before
+func (t *tier) flushOpen(before int64) error {
+	series := make([]uint32, 0, len(t.open))
+	for id, open := range t.open {
+		if before == math.MaxInt64 || open.Time+int64(t.step) <= before {
+			series = append(series, id)
+		}
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i] < series[j] })
+
+	for _, id := range series {
+		if _, err := t.append(*t.open[id]); err != nil {
+			return err
+		}
+		delete(t.open, id)
+	}
+	return nil
+}
+
+// createHead starts a new head segment
+func (t *tier) createHead(start int64) (*headSegment, error) {
+	path := t.path(start, ".wal")
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if _, err := file.WriteString(segmentMagic); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return &headSegment{start: start, file: file, writer: bufio.NewWriter(file), series: make(map[uint32]*headSeries)}, nil
+}
+
+// replayHead reopens a head segment's log, dropping a record cut short
+// when the process stopped
+func (t *tier) replayHead(start int64) (*headSegment, error) {
+	path := t.path(start, ".wal")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(data) < len(segmentMagic) || string(data[:len(segmentMagic)]) != segmentMagic {
+		return t.createHead(start)
+	}
+
+	head := &headSegment{start: start, series: make(map[uint32]*headSeries)}
+	size := t.recordSize()
+	records := data[len(segmentMagic):]
+	complete := len(records) - len(records)%size
+	for offset := 0; offset < complete; offset += size {
+		head.add(t.decode(records[offset : offset+size]))
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	end := int64(len(segmentMagic) + complete)
+	if err := file.Truncate(end); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to truncate %s: %w", path, err)
+	}
+	if _, err := file.Seek(end, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to seek %s: %w", path, err)
+	}
+	head.file = file
+	head.writer = bufio.NewWriter(file)
+	return head, nil
+}
+
+// closeHead flushes and closes the head's log, keeping it for replay
+func (t *tier) closeHead() error {
+	if t.head == nil || t.head.file == nil {
+		return nil
+	}
+	err := t.head.writer.Flush()
+	if closeErr := t.head.file.Close(); err == nil {
+		err = closeErr
+	}
+	t.head.file = nil
+	if err != nil {
+		return fmt.Errorf("failed to close %s segment: %w", t.name, err)
+	}
+	return nil
+}
+
+// seal rewrites the head sorted by series and time, with its index, and
+// removes its log
+func (t *tier) seal() error {
+	head := t.head
+	if err := t.closeHead(); err != nil {
+		return err
+	}
+	if head.records == 0 {
+		t.head = nil
+		if err := os.Remove(t.path(head.start, ".wal")); err != nil {
+			return fmt.Errorf("failed to remove %s segment log: %w", t.name, err)
+		}
+		return nil
+	}
+
+	path := t.path(head.start, ".wal")
+	log, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	size := t.recordSize()
+	if len(log) < len(segmentMagic)+head.records*size {
+		return fmt.Errorf("%s segment log %s is shorter than its records", t.name, path)
+	}
+	records := log[len(segmentMagic):]
+
+	ids := make([]uint32, 0, len(head.series))
+	for id := range head.series {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	segment := &sealedSegment{start: head.start, minTime: head.minTime, maxTime: head.maxTime, series: make(map[uint32]indexEntry, len(ids))}
+	data := []byte(segmentMagic)
+	index := []byte(segmentMagic)
+	record := make([]byte, size)
+	entry := make([]byte, indexEntrySize)
+	for _, id := range ids {
+		samples := make([]sample, 0, len(head.series[id].records))
+		for _, n := range head.series[id].records {
+			offset := int(n) * size
+			samples = append(samples, t.decode(records[offset:offset+size]))
+		}
+		samples = t.mergeBuckets(samples)
+		indexed := indexEntry{First: uint32(segment.records), Count: uint32(len(samples)), MinTime: samples[0].Time, MaxTime: samples[len(samples)-1].Time}
+		for _, s := range samples {
+			t.encode(record, s)
+			data = append(data, record...)
+		}
+		segment.records += len(samples)
+		segment.series[id] = indexed
+
+		binary.LittleEndian.PutUint32(entry[0:], id)
+		binary.LittleEndian.PutUint32(entry[4:], indexed.First)
+		binary.LittleEndian.PutUint32(entry[8:], indexed.Count)
+		binary.LittleEndian.PutUint64(entry[12:], uint64(indexed.MinTime))
+		binary.LittleEndian.PutUint64(entry[20:], uint64(indexed.MaxTime))
+		index = append(index, entry...)
+	}
+	segment.size = int64(len(data) + len(index))
+
+	// The log goes last, so an interrupted seal is redone from it
+	if err := writeFileAtomic(t.path(head.start, ".seg"), data); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(t.path(head.start, ".idx"), index); err != nil {
+		return err
+	}
+	if err := os.Remove(t.path(head.start, ".wal")); err != nil {
+		return fmt.Errorf("failed to remove %s segment log: %w", t.name, err)
+	}
+
+	t.sealed = append(t.sealed, segment)
+	sort.Slice(t.sealed, func(i, j int) bool { return t.sealed[i].start < t.sealed[j].start })
+	t.head = nil
+	return nil
+}
+
+// loadSealed reads the index of a sealed segment
+func (t *tier) loadSealed(start int64) (*sealedSegment, error) {
+	path := t.path(start, ".idx")
+	index, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(index) < len(segmentMagic) || string(index[:len(segmentMagic)]) != segmentMagic {
+		return nil, fmt.Errorf("%s is not a metrics index", path)
+	}
+	info, err := os.Stat(t.path(start, ".seg"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat segment: %w", err)
+	}
+
+	segment := &sealedSegment{start: start, size: info.Size() + int64(len(index)), series: make(map[uint32]indexEntry)}
+	entries := index[len(segmentMagic):]
+	for offset := 0; offset+indexEntrySize <= len(entries); offset += indexEntrySize {
+		entry := entries[offset : offset+indexEntrySize]
+		indexed := indexEntry{
+			First:   binary.LittleEndian.Uint32(entry[4:]),
+			Count:   binary.LittleEndian.Uint32(entry[8:]),
+			MinTime: int64(binary.LittleEndian.Uint64(entry[12:])),
+			MaxTime: int64(binary.LittleEndian.Uint64(entry[20:])),
+		}
+		if len(segment.series) == 0 || indexed.MinTime < segment.minTime {
+			segment.minTime = indexed.MinTime
+		}
+		if len(segment.series) == 0 || indexed.MaxTime > segment.maxTime {
+			segment.maxTime = indexed.MaxTime
+		}
+		segment.series[binary.LittleEndian.Uint32(entry[0:])] = indexed
+		segment.records += int(indexed.Count)
+	}
+	return segment, nil
+}
+
+// readSeries reads the records of the given series from a sealed segment
+func (t *tier) readSeries(segment *sealedSegment, ids []uint32) ([]sample, error) {
+	path := t.path(segment.start, ".seg")
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var samples []sample
+	size := t.recordSize()
+	for _, id := range ids {
+		entry, ok := segment.series[id]
+		if !ok {
+			continue
+		}
+		buf := make([]byte, int(entry.Count)*size)
+		if _, err := file.ReadAt(buf, int64(len(segmentMagic))+int64(entry.First)*int64(size)); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		for offset := 0; offset < len(buf); offset += size {
+			samples = append(samples, t.decode(buf[offset:offset+size]))
+		}
+	}
+	return samples, nil
+}
+
+// readHead reads the records of the given series between from and to from
+// the head's log
+func (t *tier) readHead(ids []uint32, from, to int64) ([]sample, error) {
+	var records []uint32
+	for _, id := range ids {
+		series, ok := t.head.series[id]
+		if !ok {
+			continue
+		}
+		for i, timestamp := range series.times {
+			if timestamp <= to && timestamp+int64(t.step) >= from {
+				records = append(records, series.records[i])
+			}
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i] < records[j] })
+	return t.readRecords(records)
+}
+
+// readRecords reads records from the head's log by number, in ascending
+// order. Records close together are read at once.
+func (t *tier) readRecords(records []uint32) ([]sample, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+	head := t.head
+	if err := head.writer.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", head.file.Name(), err)
+	}
+
+	size := t.recordSize()
+	buf := make([]byte, headReadSize/size*size)
+	first, count := 0, 0 // the records in buf
+	samples := make([]sample, 0, len(records))
+	for _, record := range records {
+		r := int(record)
+		if count == 0 || r >= first+count {
+			first, count = r, min(len(buf)/size, head.records-r)
+			if _, err := head.file.ReadAt(buf[:count*size], int64(len(segmentMagic)+r*size)); err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", head.file.Name(), err)
+			}
+		}
+		offset := (r - first) * size
+		samples = append(samples, t.decode(buf[offset:offset+size]))
+	}
+	return samples, nil
+}
+
+// query returns the records of the given series between from and to, in
+// time order. Rollups include the buckets still filling.
+func (t *tier) query(ids map[uint32]bool, from, to int64) ([]sample, error) {
+	sorted := make([]uint32, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var samples []sample
+	keep := func(s sample) {
+		if s.Time <= to && s.Time+int64(t.step) >= from {
+			samples = append(samples, s)
+		}
+	}
+
+	for _, segment := range t.sealed {
+		if segment.minTime > to || segment.maxTime+int64(t.step) < from {
+			continue
+		}
+		var matching []uint32
+		for _, id := range sorted {
+			if entry, ok := segment.series[id]; ok && entry.MinTime <= to && entry.MaxTime+int64(t.step) >= from {
+				matching = append(matching, id)
+			}
+		}
+		read, err := t.readSeries(segment, matching)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range read {
+			keep(s)
+		}
+	}
+	if t.head != nil && t.head.minTime <= to && t.head.maxTime+int64(t.step) >= from {
+		read, err := t.readHead(sorted, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range read {
+			keep(s)
+		}
+	}
+	for _, id := range sorted {
+		if open, ok := t.open[id]; ok {
+			keep(*open)
+		}
+	}
+
+	return t.mergeBuckets(samples), nil
+}
+
+// mergeBuckets sorts records by time and, for rollups, merges the parts of
+// a bucket written separately
+func (t *tier) mergeBuckets(samples []sample) []sample {
+	sorted := append([]sample(nil), samples...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Time != sorted[j].Time {
+			return sorted[i].Time < sorted[j].Time
+		}
+		return sorted[i].Series < sorted[j].Series
+	})
+	if t.step == 0 {
+		return sorted
+	}
+
+	merged := sorted[:0]
+	for _, s := range sorted {
+		if n := len(merged); n > 0 && merged[n-1].Series == s.Series && merged[n-1].Time == s.Time {
+			merged[n-1].merge(s)
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+// expire removes the sealed segments older than the tier's retention
+func (t *tier) expire(now time.Time) error {
+	cutoff := now.Add(-t.retention).UnixNano()
+	var kept []*sealedSegment
+	for _, segment := range t.sealed {
+		if segment.maxTime+int64(t.step) >= cutoff {
+			kept = append(kept, segment)
+			continue
+		}
+		for _, ext := range []string{".idx", ".seg"} {
+			if err := os.Remove(t.path(segment.start, ext)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove expired %s segment: %w", t.name, err)
+			}
+		}
+	}
+	t.sealed = kept
+	return nil
+}
+
+// liveSeries adds the series the tier holds records for
+func (t *tier) liveSeries(live map[uint32]bool) {
+	for _, segment := range t.sealed {
+		for id := range segment.series {
+			live[id] = true
+		}
+	}
+	if t.head != nil {
+		for id := range t.head.series {
+			live[id] = true
+		}
+	}
+	for id := range t.open {
+		live[id] = true
+	}
+}
+
+// records returns the number of records the tier holds
+func (t *tier) records() int {
+	count := 0
+	for _, segment := range t.sealed {
+		count += segment.records
+	}
+	if t.head != nil {
+		count += t.head.records
+	}
+	return count
+}
+
+// size returns the bytes the tier takes on disk
+func (t *tier) size() int64 {
+	var size int64
+	for _, segment := range t.sealed {
+		size += segment.size
+	}
+	if t.head != nil {
+		size += int64(len(segmentMagic) + t.head.records*t.recordSize())
+	}
+	return size
+}
+
+// edge returns the oldest or newest record of the tier
+func (t *tier) edge(oldest bool) (sample, bool, error) {
+	var best sample
+	found := false
+	better := func(s sample) bool {
+		return !found || (oldest && s.Time < best.Time) || (!oldest && s.Time > best.Time)
+	}
+
+	if t.head != nil && t.head.records > 0 {
+		record := t.head.newest
+		if oldest {
+			record = t.head.oldest
+		}
+		read, err := t.readRecords([]uint32{record})
+		if err != nil {
+			return sample{}, false, err
+		}
+		best, found = read[0], true
+	}
+
+	for _, segment := range t.sealed {
+		for id, entry := range segment.series {
+			edgeTime, record := entry.MaxTime, entry.First+entry.Count-1
+			if oldest {
+				edgeTime, record = entry.MinTime, entry.First
+			}
+			if !better(sample{Time: edgeTime}) {
+				continue
+			}
+			read, err := t.readSeries(&sealedSegment{start: segment.start, series: map[uint32]indexEntry{id: {First: record, Count: 1}}}, []uint32{id})
+			if err != nil {
+				return sample{}, false, err
+			}
+			best, found = read[0], true
+		}
+	}
+	return best, found, nil
+}
+
+// writeFileAtomic writes a file through a temporary file and a rename, so
+// readers never see it half written
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", tmp, err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("failed to sync %s: %w", tmp, err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to close %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to rename %s: %w", tmp, err)
+	}
+	return nil
+}
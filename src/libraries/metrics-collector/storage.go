@@ -27,7 +27,7 @@ func NewMetricsStorage(maxDataPoints int, retentionPeriod time.Duration) *Metric
 }
 
 // Store stores a metric data point
-func (ms *MetricsStorage) Store(metric *models.MetricDataPoint) {
+func (ms *MetricsStorage) Store(metric *models.MetricDataPoint) error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 
@@ -40,6 +40,7 @@ func (ms *MetricsStorage) Store(metric *models.MetricDataPoint) {
 		excess := len(ms.metrics) - ms.maxDataPoints
 		ms.metrics = ms.metrics[excess:]
 	}
+	return nil
 }
 
 // GetMetrics retrieves metrics with optional filtering
@@ -66,8 +67,25 @@ func (ms *MetricsStorage) GetMetrics(filter *MetricsFilter) ([]*models.MetricDat
 
 // matchesFilter checks if a metric matches the filter criteria
 func (ms *MetricsStorage) matchesFilter(metric *models.MetricDataPoint, filter *MetricsFilter) bool {
+	if !matchesSeries(metric.ResourceID, metric.MetricType, metric.Labels, filter) {
+		return false
+	}
+
+	// Check time range
+	if !filter.TimeRange.Start.IsZero() && metric.Timestamp.Before(filter.TimeRange.Start) {
+		return false
+	}
+	if !filter.TimeRange.End.IsZero() && metric.Timestamp.After(filter.TimeRange.End) {
+		return false
+	}
+	return true
+}
+
+// matchesSeries checks if a series, one metric type of a resource, matches
+// the filter criteria other than the time range
+func matchesSeries(resourceID string, metricType models.MetricType, labels map[string]string, filter *MetricsFilter) bool {
 	// Parse ResourceID for filtering
-	resourceParts := parseStorageResourceID(metric.ResourceID)
+	resourceParts := parseStorageResourceID(resourceID)
 
 	// Check resource type
 	if filter.ResourceType != "" && len(resourceParts) > 0 && resourceParts[0] != filter.ResourceType {
@@ -92,8 +110,8 @@ func (ms *MetricsStorage) matchesFilter(metric *models.MetricDataPoint, filter *
 	// Check metric types
 	if len(filter.MetricTypes) > 0 {
 		found := false
-		for _, metricType := range filter.MetricTypes {
-			if string(metric.MetricType) == metricType {
+		for _, filterType := range filter.MetricTypes {
+			if string(metricType) == filterType {
 				found = true
 				break
 			}
@@ -103,17 +121,9 @@ func (ms *MetricsStorage) matchesFilter(metric *models.MetricDataPoint, filter *
 		}
 	}
 
-	// Check time range
-	if !filter.TimeRange.Start.IsZero() && metric.Timestamp.Before(filter.TimeRange.Start) {
-		return false
-	}
-	if !filter.TimeRange.End.IsZero() && metric.Timestamp.After(filter.TimeRange.End) {
-		return false
-	}
-
 	// Check labels
 	for key, value := range filter.Labels {
-		if metricValue, exists := metric.Labels[key]; !exists || metricValue != value {
+		if metricValue, exists := labels[key]; !exists || metricValue != value {
 			return false
 		}
 	}
@@ -122,7 +132,7 @@ func (ms *MetricsStorage) matchesFilter(metric *models.MetricDataPoint, filter *
 }
 
 // Cleanup removes expired metrics
-func (ms *MetricsStorage) Cleanup() {
+func (ms *MetricsStorage) Cleanup() error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 
@@ -136,6 +146,7 @@ func (ms *MetricsStorage) Cleanup() {
 	}
 
 	ms.metrics = kept
+	return nil
 }
 
 // GetTotalCount returns the total number of stored metrics
@@ -188,8 +199,9 @@ func parseStorageResourceID(resourceID string) []string {
 }
 
 // Close cleans up storage resources
-func (ms *MetricsStorage) Close() {
+func (ms *MetricsStorage) Close() error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 	ms.metrics = nil
+	return nil
 }
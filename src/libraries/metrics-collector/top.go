@@ -0,0 +1,301 @@
+package metricscollector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+	"github.com/anindyar/kuber/src/models"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// activePodsSelector skips pods that finished and no longer hold resources
+const activePodsSelector = "status.phase!=Succeeded,status.phase!=Failed"
+
+// TopUsage is the usage of a pod or container against its requests and
+// limits. CPU is in cores, memory in bytes; zero requests and limits are
+// unset.
+type TopUsage struct {
+	CPU           float64
+	Memory        float64
+	CPURequest    float64
+	CPULimit      float64
+	MemoryRequest float64
+	MemoryLimit   float64
+}
+
+// CPURequestPercent returns the CPU usage as a percentage of the request,
+// or -1 without a request
+func (u TopUsage) CPURequestPercent() float64 {
+	return percentOfBound(u.CPU, u.CPURequest)
+}
+
+// CPULimitPercent returns the CPU usage as a percentage of the limit, or -1
+// without a limit
+func (u TopUsage) CPULimitPercent() float64 {
+	return percentOfBound(u.CPU, u.CPULimit)
+}
+
+// MemoryRequestPercent returns the memory usage as a percentage of the
+// request, or -1 without a request
+func (u TopUsage) MemoryRequestPercent() float64 {
+	return percentOfBound(u.Memory, u.MemoryRequest)
+}
+
+// MemoryLimitPercent returns the memory usage as a percentage of the limit,
+// or -1 without a limit
+func (u TopUsage) MemoryLimitPercent() float64 {
+	return percentOfBound(u.Memory, u.MemoryLimit)
+}
+
+// percentOfBound returns usage as a percentage of a request or limit, or -1
+// when it is unset
+func percentOfBound(usage, bound float64) float64 {
+	if bound <= 0 {
+		return -1
+	}
+	return usage / bound * 100
+}
+
+// ContainerTop is the resource use of one container
+type ContainerTop struct {
+	Name string
+	TopUsage
+	Restarts int32
+}
+
+// PodTop is the resource use of one pod and its containers. The pod's
+// limit is only set when every container has one.
+type PodTop struct {
+	Namespace string
+	Name      string
+	Node      string
+	TopUsage
+	Restarts   int32
+	Containers []ContainerTop
+}
+
+// TopSortKey is what a top list is sorted by, heaviest first
+type TopSortKey string
+
+const (
+	TopSortCPU           TopSortKey = "cpu"
+	TopSortMemory        TopSortKey = "memory"
+	TopSortCPURequest    TopSortKey = "cpu-request"
+	TopSortCPULimit      TopSortKey = "cpu-limit"
+	TopSortMemoryRequest TopSortKey = "memory-request"
+	TopSortMemoryLimit   TopSortKey = "memory-limit"
+	TopSortRestarts      TopSortKey = "restarts"
+	TopSortName          TopSortKey = "name"
+)
+
+// TopSortKeys lists the sort keys in the order a UI cycles through them
+var TopSortKeys = []TopSortKey{
+	TopSortCPU, TopSortMemory, TopSortCPURequest, TopSortCPULimit,
+	TopSortMemoryRequest, TopSortMemoryLimit, TopSortRestarts, TopSortName,
+}
+
+// ParseTopSortKey parses a sort key such as "cpu" or "memory-limit"
+func ParseTopSortKey(s string) (TopSortKey, error) {
+	for _, key := range TopSortKeys {
+		if string(key) == strings.ToLower(s) {
+			return key, nil
+		}
+	}
+
+	names := make([]string, len(TopSortKeys))
+	for i, key := range TopSortKeys {
+		names[i] = string(key)
+	}
+	return "", fmt.Errorf("unknown sort key %q, expected one of %s", s, strings.Join(names, ", "))
+}
+
+// TopPods returns the usage of the running pods in a namespace, or all
+// namespaces when namespace is "", from metrics-server joined with the
+// pods' requests, limits and restarts. Pods metrics-server has no reading
+// for are left out.
+func TopPods(ctx context.Context, client *kubernetesclient.KubernetesClient, namespace string) ([]PodTop, error) {
+	cs := client.GetClientset()
+	if cs == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	metricsClient, err := client.NewMetricsClient()
+	if err != nil {
+		return nil, err
+	}
+	readings, err := metricsClient.GetPodMetrics(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := cs.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{FieldSelector: activePodsSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	podsByID := make(map[string]*corev1.Pod, len(pods.Items))
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		podsByID[fmt.Sprintf("Pod/%s/%s", pod.Namespace, pod.Name)] = pod
+	}
+
+	var result []PodTop
+	byID := make(map[string]int)
+	for _, reading := range readings {
+		pod, ok := podsByID[reading.ResourceID]
+		if !ok {
+			continue
+		}
+
+		index, ok := byID[reading.ResourceID]
+		if !ok {
+			index = len(result)
+			byID[reading.ResourceID] = index
+			result = append(result, PodTop{Namespace: pod.Namespace, Name: pod.Name, Node: pod.Spec.NodeName})
+		}
+		addContainerReading(&result[index], pod, reading)
+	}
+
+	for i := range result {
+		totalPodTop(&result[i])
+	}
+	return result, nil
+}
+
+// addContainerReading adds a metrics-server reading to the container it is
+// for, taking the container's requests, limits and restarts from the pod
+func addContainerReading(top *PodTop, pod *corev1.Pod, reading *models.MetricDataPoint) {
+	name := reading.GetLabel("container")
+
+	var container *ContainerTop
+	for i := range top.Containers {
+		if top.Containers[i].Name == name {
+			container = &top.Containers[i]
+			break
+		}
+	}
+	if container == nil {
+		top.Containers = append(top.Containers, newContainerTop(pod, name))
+		container = &top.Containers[len(top.Containers)-1]
+	}
+
+	switch reading.MetricType {
+	case models.MetricTypeCPU:
+		container.CPU = reading.Value
+	case models.MetricTypeMemory:
+		container.Memory = reading.Value
+	}
+}
+
+// newContainerTop starts the figures of a container of a pod
+func newContainerTop(pod *corev1.Pod, name string) ContainerTop {
+	container := ContainerTop{Name: name}
+
+	specs := append(append([]corev1.Container{}, pod.Spec.Containers...), pod.Spec.InitContainers...)
+	for _, spec := range specs {
+		if spec.Name != name {
+			continue
+		}
+		container.CPURequest = cores(spec.Resources.Requests[corev1.ResourceCPU])
+		container.CPULimit = cores(spec.Resources.Limits[corev1.ResourceCPU])
+		container.MemoryRequest = memoryBytes(spec.Resources.Requests[corev1.ResourceMemory])
+		container.MemoryLimit = memoryBytes(spec.Resources.Limits[corev1.ResourceMemory])
+		break
+	}
+
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.ContainerStatuses...), pod.Status.InitContainerStatuses...)
+	for _, status := range statuses {
+		if status.Name == name {
+			container.Restarts = status.RestartCount
+			break
+		}
+	}
+	return container
+}
+
+// totalPodTop adds up a pod's containers
+func totalPodTop(top *PodTop) {
+	cpuLimited, memoryLimited := true, true
+	for _, container := range top.Containers {
+		top.CPU += container.CPU
+		top.Memory += container.Memory
+		top.CPURequest += container.CPURequest
+		top.CPULimit += container.CPULimit
+		top.MemoryRequest += container.MemoryRequest
+		top.MemoryLimit += container.MemoryLimit
+		top.Restarts += container.Restarts
+		cpuLimited = cpuLimited && container.CPULimit > 0
+		memoryLimited = memoryLimited && container.MemoryLimit > 0
+	}
+
+	// A container without a limit leaves the whole pod unbounded
+	if !cpuLimited {
+		top.CPULimit = 0
+	}
+	if !memoryLimited {
+		top.MemoryLimit = 0
+	}
+}
+
+// SortTop sorts pods, and the containers of each pod, heaviest first by
+// key; ties and the name key sort by namespace and name
+func SortTop(pods []PodTop, key TopSortKey) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		a, b := topSortValue(pods[i].TopUsage, float64(pods[i].Restarts), key), topSortValue(pods[j].TopUsage, float64(pods[j].Restarts), key)
+		if a != b {
+			return a > b
+		}
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	for _, pod := range pods {
+		containers := pod.Containers
+		sort.SliceStable(containers, func(i, j int) bool {
+			a, b := topSortValue(containers[i].TopUsage, float64(containers[i].Restarts), key), topSortValue(containers[j].TopUsage, float64(containers[j].Restarts), key)
+			if a != b {
+				return a > b
+			}
+			return containers[i].Name < containers[j].Name
+		})
+	}
+}
+
+// topSortValue returns the figure a key sorts by; unset percentages (-1)
+// sort last
+func topSortValue(usage TopUsage, restarts float64, key TopSortKey) float64 {
+	switch key {
+	case TopSortCPU:
+		return usage.CPU
+	case TopSortMemory:
+		return usage.Memory
+	case TopSortCPURequest:
+		return usage.CPURequestPercent()
+	case TopSortCPULimit:
+		return usage.CPULimitPercent()
+	case TopSortMemoryRequest:
+		return usage.MemoryRequestPercent()
+	case TopSortMemoryLimit:
+		return usage.MemoryLimitPercent()
+	case TopSortRestarts:
+		return restarts
+	default:
+		return 0
+	}
+}
+
+// cores converts a CPU quantity to cores
+func cores(quantity resource.Quantity) float64 {
+	return float64(quantity.MilliValue()) / 1000.0
+}
+
+// memoryBytes converts a memory quantity to bytes
+func memoryBytes(quantity resource.Quantity) float64 {
+	return float64(quantity.Value())
+}
@@ -14,6 +14,23 @@ type Collector interface {
 	IsEnabled() bool
 }
 
+// ResourceLister lists the resources of a type ("nodes" or "pods") in a
+// namespace, "" for all, such as from informers
+type ResourceLister func(ctx context.Context, namespace, resourceType string) ([]*models.Resource, error)
+
+// Storage keeps collected metrics and answers queries over them
+type Storage interface {
+	Store(metric *models.MetricDataPoint) error
+	GetMetrics(filter *MetricsFilter) ([]*models.MetricDataPoint, error)
+	Cleanup() error
+	GetTotalCount() int
+	GetLastCollectionTime() time.Time
+	GetStorageSize() int64
+	GetOldestMetric() *models.MetricDataPoint
+	GetNewestMetric() *models.MetricDataPoint
+	Close() error
+}
+
 // MetricsFilter defines filtering criteria for metrics queries
 type MetricsFilter struct {
 	ResourceType string
@@ -22,6 +39,11 @@ type MetricsFilter struct {
 	MetricTypes  []string
 	TimeRange    TimeRange
 	Labels       map[string]string
+	// Resolution is the spacing between samples wanted, e.g. time.Hour
+	// for a month-long chart. A DiskStorage answers from the finest rollup
+	// at least that coarse kept for the whole time range; 0 asks for the
+	// finest kept. MetricsStorage keeps raw samples only.
+	Resolution time.Duration
 }
 
 // TimeRange defines a time range for metrics queries
@@ -52,81 +74,3 @@ type AggregatedMetrics struct {
 	Timestamp    time.Time
 	DataPoints   []*models.MetricDataPoint
 }
-
-// NodeCollector collects node metrics
-type NodeCollector struct {
-	client interface{} // Simplified for now
-}
-
-// NewNodeCollector creates a new node collector
-func NewNodeCollector(client interface{}) *NodeCollector {
-	return &NodeCollector{client: client}
-}
-
-// Collect implements the Collector interface
-func (nc *NodeCollector) Collect(ctx context.Context) ([]*models.MetricDataPoint, error) {
-	// Placeholder implementation
-	return []*models.MetricDataPoint{}, nil
-}
-
-// GetName returns the collector name
-func (nc *NodeCollector) GetName() string {
-	return "node-collector"
-}
-
-// IsEnabled returns whether the collector is enabled
-func (nc *NodeCollector) IsEnabled() bool {
-	return true
-}
-
-// PodCollector collects pod metrics
-type PodCollector struct {
-	client interface{} // Simplified for now
-}
-
-// NewPodCollector creates a new pod collector
-func NewPodCollector(client interface{}) *PodCollector {
-	return &PodCollector{client: client}
-}
-
-// Collect implements the Collector interface
-func (pc *PodCollector) Collect(ctx context.Context) ([]*models.MetricDataPoint, error) {
-	// Placeholder implementation
-	return []*models.MetricDataPoint{}, nil
-}
-
-// GetName returns the collector name
-func (pc *PodCollector) GetName() string {
-	return "pod-collector"
-}
-
-// IsEnabled returns whether the collector is enabled
-func (pc *PodCollector) IsEnabled() bool {
-	return true
-}
-
-// CustomCollector collects custom metrics
-type CustomCollector struct {
-	client interface{} // Simplified for now
-}
-
-// NewCustomCollector creates a new custom collector
-func NewCustomCollector(client interface{}) *CustomCollector {
-	return &CustomCollector{client: client}
-}
-
-// Collect implements the Collector interface
-func (cc *CustomCollector) Collect(ctx context.Context) ([]*models.MetricDataPoint, error) {
-	// Placeholder implementation
-	return []*models.MetricDataPoint{}, nil
-}
-
-// GetName returns the collector name
-func (cc *CustomCollector) GetName() string {
-	return "custom-collector"
-}
-
-// IsEnabled returns whether the collector is enabled
-func (cc *CustomCollector) IsEnabled() bool {
-	return true
-}
@@ -0,0 +1,263 @@
+package resourcemanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+	"github.com/anindyar/kuber/src/models"
+)
+
+const (
+	// startupGracePeriod is how long a pod may be creating containers or
+	// failing readiness before it counts as a problem
+	startupGracePeriod = 3 * time.Minute
+
+	// recentWindow is how far back OOM kills, restarts and probe failures
+	// count as current
+	recentWindow = time.Hour
+
+	// probeEventWindow is how recent a probe failure event must be
+	probeEventWindow = 10 * time.Minute
+
+	// restartWarningThreshold is the restart count from which a container
+	// that keeps restarting is reported even while it runs
+	restartWarningThreshold = 5
+
+	// stuckTerminatingAfter is how long a pod may take to terminate
+	stuckTerminatingAfter = 5 * time.Minute
+)
+
+// Diagnose inspects the pods, deployments and, for the whole cluster
+// (namespace ""), nodes, and returns the problems found ranked by severity:
+// crash loops, OOM kills, image pull failures, unschedulable pods, failing
+// probes, NotReady or pressured nodes and stuck rollouts.
+func (rm *ResourceManager) Diagnose(ctx context.Context, namespace string) ([]models.Issue, error) {
+	pods, err := rm.GetResourcesByType(ctx, namespace, "pods")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	// Probe failures only show up in events; without them a failing
+	// readiness probe is still reported as a NotReady container
+	probeFailures := make(map[string]models.Event)
+	events, err := rm.client.GetEvents(ctx, kubernetesclient.EventQuery{Namespace: namespace, Kind: "Pod", Reason: "Unhealthy"})
+	if err == nil {
+		cutoff := time.Now().Add(-probeEventWindow)
+		for _, event := range events {
+			if event.LastTimestamp.After(cutoff) {
+				probeFailures[event.Object.Namespace+"/"+event.Object.Name] = event
+			}
+		}
+	}
+
+	now := time.Now()
+	var issues []models.Issue
+	for _, pod := range pods {
+		probeFailure, hasProbeFailure := probeFailures[pod.Metadata.Namespace+"/"+pod.Metadata.Name]
+		var failure *models.Event
+		if hasProbeFailure {
+			failure = &probeFailure
+		}
+		issues = append(issues, diagnosePod(pod, failure, now)...)
+	}
+
+	if deployments, err := rm.GetResourcesByType(ctx, namespace, "deployments"); err == nil {
+		for _, deployment := range deployments {
+			issues = append(issues, diagnoseDeployment(deployment)...)
+		}
+	}
+
+	if namespace == "" {
+		if nodes, err := rm.GetResourcesByType(ctx, "", "nodes"); err == nil {
+			for _, node := range nodes {
+				issues = append(issues, diagnoseNode(node)...)
+			}
+		}
+	}
+
+	models.SortIssues(issues)
+	return issues, nil
+}
+
+// diagnosePod finds the problems of one pod. probeFailure is its latest
+// recent Unhealthy event, if any.
+func diagnosePod(pod *models.Resource, probeFailure *models.Event, now time.Time) []models.Issue {
+	object := issueObject(pod)
+	issue := func(severity models.IssueSeverity, reason, container, message string, since time.Time) models.Issue {
+		return models.Issue{Severity: severity, Reason: reason, Object: object, Container: container, Message: message, Since: since}
+	}
+
+	if pod.IsDeleting() {
+		deleted := *pod.Metadata.DeletionTimestamp
+		if now.Sub(deleted) > stuckTerminatingAfter {
+			return []models.Issue{issue(models.IssueSeverityWarning, "StuckTerminating", "",
+				fmt.Sprintf("terminating for %s; check finalizers and the node", formatSince(deleted, now)), deleted)}
+		}
+		return nil
+	}
+
+	phase, _ := pod.Status["phase"].(string)
+	switch phase {
+	case "Succeeded":
+		return nil
+	case "Failed":
+		reason, _ := pod.Status["reason"].(string)
+		message, _ := pod.Status["message"].(string)
+		if reason == "" {
+			reason = "Failed"
+		}
+		if message == "" {
+			message = "pod failed"
+		}
+		return []models.Issue{issue(models.IssueSeverityWarning, reason, "", message, pod.Metadata.CreationTimestamp)}
+	}
+
+	var issues []models.Issue
+
+	if scheduled := pod.GetCondition("PodScheduled"); scheduled != nil && scheduled.Status == "False" && scheduled.Reason == "Unschedulable" {
+		severity := models.IssueSeverityWarning
+		if now.Sub(scheduled.LastTransitionTime) > startupGracePeriod {
+			severity = models.IssueSeverityCritical
+		}
+		issues = append(issues, issue(severity, "Unschedulable", "", scheduled.Message, scheduled.LastTransitionTime))
+	}
+
+	notReady := false
+	for _, container := range pod.Containers {
+		switch {
+		case container.State == "waiting" && container.Reason == "CrashLoopBackOff":
+			reason, message := "CrashLoopBackOff", fmt.Sprintf("exited with code %d", container.LastExitCode)
+			if container.LastReason == "OOMKilled" {
+				reason, message = "OOMKilled", "killed for exceeding its memory limit"
+			} else if container.LastReason != "" && container.LastReason != "Error" {
+				message += " (" + container.LastReason + ")"
+			}
+			message += fmt.Sprintf(", restarted %d times", container.RestartCount)
+			issues = append(issues, issue(models.IssueSeverityCritical, reason, container.Name, message, container.LastFinishedAt))
+
+		case container.State == "waiting" && (container.Reason == "ImagePullBackOff" || container.Reason == "ErrImagePull" || container.Reason == "InvalidImageName"):
+			issues = append(issues, issue(models.IssueSeverityCritical, "ImagePullBackOff", container.Name, container.Message, pod.Metadata.CreationTimestamp))
+
+		case container.IsFailing():
+			issues = append(issues, issue(models.IssueSeverityCritical, container.Reason, container.Name, container.Message, pod.Metadata.CreationTimestamp))
+
+		case container.State == "waiting":
+			// ContainerCreating, PodInitializing: only a problem once it takes too long
+			if now.Sub(pod.Metadata.CreationTimestamp) > startupGracePeriod {
+				reason := container.Reason
+				if reason == "" {
+					reason = "Waiting"
+				}
+				issues = append(issues, issue(models.IssueSeverityWarning, reason, container.Name,
+					fmt.Sprintf("still %s after %s", reason, formatSince(pod.Metadata.CreationTimestamp, now)), pod.Metadata.CreationTimestamp))
+			}
+
+		case container.State == "terminated" && container.Init && container.ExitCode != 0:
+			issues = append(issues, issue(models.IssueSeverityCritical, "InitFailed", container.Name,
+				fmt.Sprintf("init container exited with code %d (%s)", container.ExitCode, container.Reason), pod.Metadata.CreationTimestamp))
+
+		case container.State == "running" && container.LastReason == "OOMKilled" && now.Sub(container.LastFinishedAt) < recentWindow:
+			issues = append(issues, issue(models.IssueSeverityWarning, "OOMKilled", container.Name,
+				fmt.Sprintf("killed for exceeding its memory limit %s ago, restarted %d times", formatSince(container.LastFinishedAt, now), container.RestartCount),
+				container.LastFinishedAt))
+
+		case container.State == "running" && !container.Init && !container.Ready && now.Sub(container.StartedAt) > startupGracePeriod:
+			notReady = true
+			if probeFailure != nil {
+				issues = append(issues, issue(models.IssueSeverityWarning, "ProbeFailed", container.Name, probeFailure.Message, probeFailure.FirstTimestamp))
+			} else {
+				issues = append(issues, issue(models.IssueSeverityWarning, "NotReady", container.Name,
+					fmt.Sprintf("running for %s but not ready", formatSince(container.StartedAt, now)), container.StartedAt))
+			}
+
+		case container.State == "running" && container.RestartCount >= restartWarningThreshold && now.Sub(container.LastFinishedAt) < recentWindow:
+			issues = append(issues, issue(models.IssueSeverityInfo, "Restarting", container.Name,
+				fmt.Sprintf("restarted %d times, last %s ago with code %d", container.RestartCount, formatSince(container.LastFinishedAt, now), container.LastExitCode),
+				container.LastFinishedAt))
+		}
+	}
+
+	// A failing liveness probe restarts a container that may look healthy
+	// between restarts
+	if probeFailure != nil && !notReady && len(issues) == 0 {
+		issues = append(issues, issue(models.IssueSeverityWarning, "ProbeFailed", "", probeFailure.Message, probeFailure.FirstTimestamp))
+	}
+
+	return issues
+}
+
+// diagnoseNode reports a NotReady node and resource pressure
+func diagnoseNode(node *models.Resource) []models.Issue {
+	object := issueObject(node)
+
+	var issues []models.Issue
+	for _, condition := range node.Conditions {
+		switch {
+		case condition.Type == "Ready" && condition.Status != "True":
+			issues = append(issues, models.Issue{Severity: models.IssueSeverityCritical, Reason: "NodeNotReady", Object: object,
+				Message: conditionMessage(condition, "kubelet is not reporting ready"), Since: condition.LastTransitionTime})
+		case condition.Type == "NetworkUnavailable" && condition.Status == "True":
+			issues = append(issues, models.Issue{Severity: models.IssueSeverityCritical, Reason: condition.Type, Object: object,
+				Message: conditionMessage(condition, "node network is not configured"), Since: condition.LastTransitionTime})
+		case (condition.Type == "MemoryPressure" || condition.Type == "DiskPressure" || condition.Type == "PIDPressure") && condition.Status == "True":
+			issues = append(issues, models.Issue{Severity: models.IssueSeverityWarning, Reason: condition.Type, Object: object,
+				Message: conditionMessage(condition, "kubelet may evict pods"), Since: condition.LastTransitionTime})
+		}
+	}
+	return issues
+}
+
+// diagnoseDeployment reports rollouts that passed their progress deadline
+// and deployments without enough available replicas
+func diagnoseDeployment(deployment *models.Resource) []models.Issue {
+	object := issueObject(deployment)
+
+	var issues []models.Issue
+	if progressing := deployment.GetCondition("Progressing"); progressing != nil &&
+		progressing.Status == "False" && progressing.Reason == "ProgressDeadlineExceeded" {
+		issues = append(issues, models.Issue{Severity: models.IssueSeverityCritical, Reason: "RolloutStuck", Object: object,
+			Message: conditionMessage(*progressing, "rollout exceeded its progress deadline"), Since: progressing.LastTransitionTime})
+	}
+	if available := deployment.GetCondition("Available"); available != nil && available.Status == "False" {
+		desired, _ := deployment.Spec["replicas"].(string)
+		ready, _ := deployment.Status["availableReplicas"].(string)
+		issues = append(issues, models.Issue{Severity: models.IssueSeverityWarning, Reason: "Unavailable", Object: object,
+			Message: fmt.Sprintf("%s of %s replicas available", ready, desired), Since: available.LastTransitionTime})
+	}
+	return issues
+}
+
+// issueObject identifies the resource an issue is about
+func issueObject(resource *models.Resource) models.EventObject {
+	return models.EventObject{
+		Kind:      resource.Kind,
+		Name:      resource.Metadata.Name,
+		Namespace: resource.Metadata.Namespace,
+		UID:       resource.Metadata.UID,
+	}
+}
+
+// conditionMessage returns a condition's message, or fallback when it has none
+func conditionMessage(condition models.Condition, fallback string) string {
+	if condition.Message != "" {
+		return condition.Message
+	}
+	return fallback
+}
+
+// formatSince renders how long ago t was, e.g. 5m or 2h
+func formatSince(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
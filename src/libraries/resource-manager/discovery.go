@@ -3,12 +3,14 @@ package resourcemanager
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 )
 
@@ -25,6 +27,7 @@ type ResourceDiscovery struct {
 
 // ResourceTypeInfo holds information about a resource type
 type ResourceTypeInfo struct {
+	Name        string
 	Kind        string
 	Group       string
 	Version     string
@@ -90,7 +93,17 @@ func (rd *ResourceDiscovery) DiscoverResources(ctx context.Context) error {
 					continue
 				}
 
+				// When a plural is served more than once, the first group seen
+				// (core comes first) keeps the plain name, and within a group
+				// the preferred version wins
+				preferred := version.Version == group.PreferredVersion.Version
+				if existing, exists := rd.resourceTypes[resource.Name]; exists && !(existing.Group == group.Name && preferred) {
+					rd.addQualifiedType(resource, group.Name, version.Version, group.PreferredVersion.Version)
+					continue
+				}
+
 				info := &ResourceTypeInfo{
+					Name:        resource.Name,
 					Kind:        resource.Kind,
 					Group:       group.Name,
 					Version:     version.Version,
@@ -103,6 +116,7 @@ func (rd *ResourceDiscovery) DiscoverResources(ctx context.Context) error {
 				}
 
 				rd.resourceTypes[resource.Name] = info
+				rd.addQualifiedType(resource, group.Name, version.Version, group.PreferredVersion.Version)
 			}
 		}
 	}
@@ -111,6 +125,108 @@ func (rd *ResourceDiscovery) DiscoverResources(ctx context.Context) error {
 	return nil
 }
 
+// addQualifiedType records a resource under its "plural.group" name so that
+// ambiguous plurals can still be addressed explicitly
+func (rd *ResourceDiscovery) addQualifiedType(resource metav1.APIResource, group, version, preferredVersion string) {
+	if group == "" {
+		return
+	}
+
+	key := resource.Name + "." + group
+	if existing, exists := rd.resourceTypes[key]; exists && existing.Version == preferredVersion {
+		return
+	}
+
+	rd.resourceTypes[key] = &ResourceTypeInfo{
+		Name:        resource.Name,
+		Kind:        resource.Kind,
+		Group:       group,
+		Version:     version,
+		Namespace:   resource.Namespaced,
+		ShortNames:  resource.ShortNames,
+		Categories:  resource.Categories,
+		Verbs:       resource.Verbs,
+		Description: rd.getResourceDescription(resource.Kind),
+		Examples:    rd.getResourceExamples(resource.Kind),
+	}
+}
+
+// ResolveResource maps a resource type (plural, plural.group, short name or
+// kind) to its GroupVersionResource, running discovery when the cached
+// results are missing or stale. It satisfies kubernetesclient.ResourceResolver.
+func (rd *ResourceDiscovery) ResolveResource(ctx context.Context, resourceType string) (schema.GroupVersionResource, bool, error) {
+	rd.mu.RLock()
+	stale := len(rd.resourceTypes) == 0 || time.Since(rd.lastDiscovery) > rd.discoveryInterval
+	rd.mu.RUnlock()
+
+	if stale {
+		if err := rd.DiscoverResources(ctx); err != nil {
+			return schema.GroupVersionResource{}, false, err
+		}
+	}
+
+	info := rd.lookup(resourceType)
+	if info == nil {
+		return schema.GroupVersionResource{}, false, fmt.Errorf("resource type %s not found", resourceType)
+	}
+
+	return schema.GroupVersionResource{Group: info.Group, Version: info.Version, Resource: info.Name}, info.Namespace, nil
+}
+
+// lookup finds a discovered resource type by name, short name or kind
+func (rd *ResourceDiscovery) lookup(resourceType string) *ResourceTypeInfo {
+	rd.mu.RLock()
+	defer rd.mu.RUnlock()
+
+	name := strings.ToLower(resourceType)
+	if info, exists := rd.resourceTypes[name]; exists {
+		return info
+	}
+
+	for _, info := range rd.resourceTypes {
+		for _, shortName := range info.ShortNames {
+			if shortName == name {
+				return info
+			}
+		}
+	}
+
+	for _, info := range rd.resourceTypes {
+		if strings.ToLower(info.Kind) == name {
+			return info
+		}
+	}
+
+	return nil
+}
+
+// GetCustomResourceTypes returns the plural names of discovered resources
+// that are not served by a built-in Kubernetes API group, such as CRDs
+func (rd *ResourceDiscovery) GetCustomResourceTypes() []string {
+	rd.mu.RLock()
+	defer rd.mu.RUnlock()
+
+	var custom []string
+	for name, info := range rd.resourceTypes {
+		if isBuiltinGroup(info.Group) || name != info.Name+"."+info.Group {
+			continue
+		}
+		// Use the short plural unless another group already owns it
+		if plain, exists := rd.resourceTypes[info.Name]; exists && plain.Group == info.Group {
+			name = info.Name
+		}
+		custom = append(custom, name)
+	}
+
+	sort.Strings(custom)
+	return custom
+}
+
+// isBuiltinGroup reports whether an API group ships with Kubernetes itself
+func isBuiltinGroup(group string) bool {
+	return group == "" || !strings.Contains(group, ".") || strings.HasSuffix(group, ".k8s.io")
+}
+
 // GetResourceTypes returns all discovered resource types
 func (rd *ResourceDiscovery) GetResourceTypes() map[string]*ResourceTypeInfo {
 	rd.mu.RLock()
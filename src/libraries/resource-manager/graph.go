@@ -0,0 +1,359 @@
+package resourcemanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anindyar/kuber/src/models"
+)
+
+// ownerGraphTypes are the resource types listed to build a namespace's
+// ownership graph. Types the cluster doesn't serve or the user can't list
+// are skipped.
+var ownerGraphTypes = []string{"deployments", "replicasets", "statefulsets", "daemonsets", "cronjobs", "jobs", "pods"}
+
+// ownerGraphRefreshInterval limits how often a matcher rebuilds its graph
+// when it meets an owner it doesn't know, e.g. a new ReplicaSet in a rollout
+const ownerGraphRefreshInterval = 5 * time.Second
+
+// OwnerGraph links objects to their owners by UID, following
+// metadata.ownerReferences
+type OwnerGraph struct {
+	nodes    map[string]*models.Resource // by UID
+	children map[string][]string         // owner UID -> owned UIDs
+	mu       sync.RWMutex
+}
+
+// OwnerNode is an object in an ownership tree. Resource is nil for owners
+// that are referenced but weren't loaded, e.g. a custom controller's object.
+type OwnerNode struct {
+	Kind     string
+	Name     string
+	UID      string
+	Resource *models.Resource
+	Children []*OwnerNode
+}
+
+// NewOwnerGraph creates a graph of the given resources
+func NewOwnerGraph(resources ...*models.Resource) *OwnerGraph {
+	g := &OwnerGraph{
+		nodes:    make(map[string]*models.Resource),
+		children: make(map[string][]string),
+	}
+	g.Add(resources...)
+	return g
+}
+
+// Add adds or replaces resources in the graph
+func (g *OwnerGraph) Add(resources ...*models.Resource) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, resource := range resources {
+		uid := resource.Metadata.UID
+		if uid == "" {
+			continue
+		}
+		if _, exists := g.nodes[uid]; exists {
+			g.unlink(uid)
+		}
+
+		g.nodes[uid] = resource
+		for _, owner := range resource.Metadata.OwnerReferences {
+			g.children[owner.UID] = append(g.children[owner.UID], uid)
+		}
+	}
+}
+
+// Remove drops a resource from the graph. Objects it owns stay, as they do
+// in the cluster until the garbage collector removes them.
+func (g *OwnerGraph) Remove(uid string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.nodes[uid]; exists {
+		g.unlink(uid)
+		delete(g.nodes, uid)
+	}
+}
+
+// unlink removes a resource from its owners' child lists
+func (g *OwnerGraph) unlink(uid string) {
+	for _, owner := range g.nodes[uid].Metadata.OwnerReferences {
+		siblings := g.children[owner.UID]
+		for i, sibling := range siblings {
+			if sibling == uid {
+				g.children[owner.UID] = append(siblings[:i:i], siblings[i+1:]...)
+				break
+			}
+		}
+		if len(g.children[owner.UID]) == 0 {
+			delete(g.children, owner.UID)
+		}
+	}
+}
+
+// Get returns the resource with the given UID, or nil
+func (g *OwnerGraph) Get(uid string) *models.Resource {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.nodes[uid]
+}
+
+// Find returns the resource with the given kind and name, or nil
+func (g *OwnerGraph) Find(kind, namespace, name string) *models.Resource {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for _, resource := range g.nodes {
+		if resource.Kind == kind && resource.Metadata.Name == name && resource.Metadata.Namespace == namespace {
+			return resource
+		}
+	}
+	return nil
+}
+
+// Children returns the resources owned directly by uid, oldest first
+func (g *OwnerGraph) Children(uid string) []*models.Resource {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.childrenLocked(uid)
+}
+
+// childrenLocked returns the loaded children of uid, oldest first
+func (g *OwnerGraph) childrenLocked(uid string) []*models.Resource {
+	var children []*models.Resource
+	for _, child := range g.children[uid] {
+		if resource, ok := g.nodes[child]; ok {
+			children = append(children, resource)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool {
+		if !children[i].Metadata.CreationTimestamp.Equal(children[j].Metadata.CreationTimestamp) {
+			return children[i].Metadata.CreationTimestamp.Before(children[j].Metadata.CreationTimestamp)
+		}
+		return children[i].Metadata.Name < children[j].Metadata.Name
+	})
+	return children
+}
+
+// Descendants returns every resource owned by uid, directly or through
+// other objects
+func (g *OwnerGraph) Descendants(uid string) []*models.Resource {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var descendants []*models.Resource
+	seen := map[string]bool{uid: true}
+	queue := []string{uid}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range g.childrenLocked(current) {
+			if seen[child.Metadata.UID] {
+				continue
+			}
+			seen[child.Metadata.UID] = true
+			descendants = append(descendants, child)
+			queue = append(queue, child.Metadata.UID)
+		}
+	}
+	return descendants
+}
+
+// OwnedBy reports whether an object with the given owner references
+// descends from ancestorUID. known is false when the chain runs through an
+// owner that isn't in the graph, so the answer may be incomplete.
+func (g *OwnerGraph) OwnedBy(owners []models.OwnerReference, ancestorUID string) (owned, known bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	known = true
+	seen := make(map[string]bool)
+	queue := append([]models.OwnerReference(nil), owners...)
+	for len(queue) > 0 {
+		owner := queue[0]
+		queue = queue[1:]
+		if owner.UID == ancestorUID {
+			return true, true
+		}
+		if seen[owner.UID] {
+			continue
+		}
+		seen[owner.UID] = true
+
+		resource, ok := g.nodes[owner.UID]
+		if !ok {
+			known = false
+			continue
+		}
+		queue = append(queue, resource.Metadata.OwnerReferences...)
+	}
+	return false, known
+}
+
+// Ancestors returns the ownership chain above uid, nearest owner first.
+// When an object has several owners the controller is followed. Owners
+// that aren't loaded end the chain with a node built from the reference.
+func (g *OwnerGraph) Ancestors(uid string) []*OwnerNode {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var chain []*OwnerNode
+	seen := map[string]bool{uid: true}
+	current := g.nodes[uid]
+	for current != nil {
+		owner := controllerOf(current)
+		if owner == nil || seen[owner.UID] {
+			break
+		}
+		seen[owner.UID] = true
+
+		node := &OwnerNode{Kind: owner.Kind, Name: owner.Name, UID: owner.UID, Resource: g.nodes[owner.UID]}
+		chain = append(chain, node)
+		current = node.Resource
+	}
+	return chain
+}
+
+// Tree returns the ownership tree containing uid, rooted at its top-most
+// owner
+func (g *OwnerGraph) Tree(uid string) *OwnerNode {
+	root := uid
+	if ancestors := g.Ancestors(uid); len(ancestors) > 0 {
+		root = ancestors[len(ancestors)-1].UID
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	node := g.nodeLocked(root)
+	if node == nil {
+		return nil
+	}
+	if node.Resource == nil {
+		// A missing root is only known from the references to it
+		for _, resource := range g.nodes {
+			for _, owner := range resource.Metadata.OwnerReferences {
+				if owner.UID == root {
+					node.Kind, node.Name = owner.Kind, owner.Name
+				}
+			}
+		}
+	}
+	g.fillTree(node, map[string]bool{root: true})
+	return node
+}
+
+// nodeLocked returns a tree node for uid, which may be an unloaded owner
+func (g *OwnerGraph) nodeLocked(uid string) *OwnerNode {
+	if resource, ok := g.nodes[uid]; ok {
+		return &OwnerNode{Kind: resource.Kind, Name: resource.Metadata.Name, UID: uid, Resource: resource}
+	}
+	if _, ok := g.children[uid]; ok {
+		return &OwnerNode{UID: uid}
+	}
+	return nil
+}
+
+// fillTree adds the owned objects below node, recursively
+func (g *OwnerGraph) fillTree(node *OwnerNode, seen map[string]bool) {
+	for _, child := range g.childrenLocked(node.UID) {
+		if seen[child.Metadata.UID] {
+			continue
+		}
+		seen[child.Metadata.UID] = true
+
+		childNode := &OwnerNode{Kind: child.Kind, Name: child.Metadata.Name, UID: child.Metadata.UID, Resource: child}
+		g.fillTree(childNode, seen)
+		node.Children = append(node.Children, childNode)
+	}
+}
+
+// controllerOf returns the managing owner of a resource: its controller
+// reference, or its only owner
+func controllerOf(resource *models.Resource) *models.OwnerReference {
+	if controller := resource.GetController(); controller != nil {
+		return controller
+	}
+	if len(resource.Metadata.OwnerReferences) == 1 {
+		return &resource.Metadata.OwnerReferences[0]
+	}
+	return nil
+}
+
+// BuildOwnerGraph builds the ownership graph of the workloads in a namespace
+func (rm *ResourceManager) BuildOwnerGraph(ctx context.Context, namespace string) (*OwnerGraph, error) {
+	graph := NewOwnerGraph()
+
+	var listed int
+	var lastErr error
+	for _, resourceType := range ownerGraphTypes {
+		resources, err := rm.GetResourcesByType(ctx, namespace, resourceType)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		graph.Add(resources...)
+		listed++
+	}
+
+	if listed == 0 && lastErr != nil {
+		return nil, fmt.Errorf("failed to build owner graph: %w", lastErr)
+	}
+	return graph, nil
+}
+
+// OwnerMatcher returns a function reporting whether an object in the
+// namespace, given its owner references, descends from the named owner.
+// When an unknown owner turns up the graph is rebuilt in the background,
+// bounded by the ctx passed to the function, so objects created later, like
+// the ReplicaSet of a new rollout, are matched when they are asked about
+// again. The function itself never blocks on the API server.
+func (rm *ResourceManager) OwnerMatcher(ctx context.Context, namespace, kind, name string) (func(ctx context.Context, owners []models.OwnerReference) bool, error) {
+	graph, err := rm.BuildOwnerGraph(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := graph.Find(kind, namespace, name)
+	if owner == nil {
+		return nil, fmt.Errorf("%s %s not found in namespace %s", kind, name, namespace)
+	}
+	ownerUID := owner.Metadata.UID
+
+	var mu sync.Mutex
+	lastRefresh := time.Now()
+	refreshing := false
+	return func(ctx context.Context, owners []models.OwnerReference) bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		owned, known := graph.OwnedBy(owners, ownerUID)
+		if owned || known || refreshing || time.Since(lastRefresh) < ownerGraphRefreshInterval {
+			return owned
+		}
+
+		refreshing = true
+		go func() {
+			for _, resourceType := range ownerGraphTypes {
+				rm.InvalidateResources(namespace, resourceType)
+			}
+			refreshCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			defer cancel()
+			refreshed, err := rm.BuildOwnerGraph(refreshCtx, namespace)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				graph = refreshed
+			}
+			lastRefresh = time.Now()
+			refreshing = false
+		}()
+		return false
+	}, nil
+}
@@ -0,0 +1,346 @@
+package resourcemanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+	"github.com/anindyar/kuber/src/models"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// informerResource describes how to list and watch a built-in resource type
+type informerResource struct {
+	client   func(kubernetes.Interface) cache.Getter
+	resource string
+	object   runtime.Object
+}
+
+// informerResources are the resource types served from informers; anything
+// else falls back to the TTL cache
+var informerResources = map[string]informerResource{
+	"pods":                   {coreClient, "pods", &corev1.Pod{}},
+	"services":               {coreClient, "services", &corev1.Service{}},
+	"configmaps":             {coreClient, "configmaps", &corev1.ConfigMap{}},
+	"secrets":                {coreClient, "secrets", &corev1.Secret{}},
+	"persistentvolumeclaims": {coreClient, "persistentvolumeclaims", &corev1.PersistentVolumeClaim{}},
+	"persistentvolumes":      {coreClient, "persistentvolumes", &corev1.PersistentVolume{}},
+	"nodes":                  {coreClient, "nodes", &corev1.Node{}},
+	"namespaces":             {coreClient, "namespaces", &corev1.Namespace{}},
+	"deployments":            {appsClient, "deployments", &appsv1.Deployment{}},
+	"statefulsets":           {appsClient, "statefulsets", &appsv1.StatefulSet{}},
+	"ingress":                {networkingClient, "ingresses", &networkingv1.Ingress{}},
+}
+
+func coreClient(cs kubernetes.Interface) cache.Getter       { return cs.CoreV1().RESTClient() }
+func appsClient(cs kubernetes.Interface) cache.Getter       { return cs.AppsV1().RESTClient() }
+func networkingClient(cs kubernetes.Interface) cache.Getter { return cs.NetworkingV1().RESTClient() }
+
+// newListWatch returns a ListWatch for a built-in resource type
+func newListWatch(clientset kubernetes.Interface, namespace, resourceType string) (*cache.ListWatch, runtime.Object, error) {
+	def, ok := informerResources[resourceType]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+	return cache.NewListWatchFromClient(def.client(clientset), def.resource, namespace, fields.Everything()), def.object, nil
+}
+
+// resourceInformer is a running informer together with the converted
+// objects it holds
+type resourceInformer struct {
+	namespace    string
+	resourceType string
+	informer     cache.SharedIndexInformer
+	registration cache.ResourceEventHandlerRegistration
+	stopCh       chan struct{}
+
+	mu         sync.RWMutex
+	resources  map[string]*models.Resource // keyed by namespace/name
+	lastAccess time.Time
+	lastError  string
+}
+
+// InformerStats describes the state of one informer
+type InformerStats struct {
+	Namespace       string
+	ResourceType    string
+	Synced          bool
+	Objects         int
+	ResourceVersion string
+	LastError       string
+	LastAccess      time.Time
+}
+
+// InformerStore serves resource lists from memory using one shared informer
+// per (namespace, resourceType). The informers list once and then follow a
+// watch, resuming from the last resourceVersion and using bookmarks, so
+// reads never hit the API server after the initial sync.
+type InformerStore struct {
+	clientset   kubernetes.Interface
+	informers   map[string]*resourceInformer
+	idleTimeout time.Duration
+	mu          sync.Mutex
+}
+
+// NewInformerStore creates an informer store. Informers that have not been
+// read for idleTimeout are stopped by Prune.
+func NewInformerStore(clientset kubernetes.Interface, idleTimeout time.Duration) *InformerStore {
+	return &InformerStore{
+		clientset:   clientset,
+		informers:   make(map[string]*resourceInformer),
+		idleTimeout: idleTimeout,
+	}
+}
+
+// Supports reports whether a resource type can be served from an informer
+func (s *InformerStore) Supports(resourceType string) bool {
+	_, ok := informerResources[resourceType]
+	return ok
+}
+
+// List returns the resources of a type in a namespace ("" for all
+// namespaces), starting an informer and waiting for its initial sync on
+// first use
+func (s *InformerStore) List(ctx context.Context, namespace, resourceType string) ([]*models.Resource, error) {
+	// A synced all-namespaces informer can answer for any single namespace
+	if namespace != "" {
+		if ri := s.get("", resourceType); ri != nil && ri.registration.HasSynced() {
+			return ri.list(namespace), nil
+		}
+	}
+
+	ri, err := s.ensure(namespace, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := waitForSync(ctx, ri.registration); err != nil {
+		return nil, fmt.Errorf("informer for %s in %q not synced: %w", resourceType, namespace, err)
+	}
+
+	return ri.list(""), nil
+}
+
+// get returns the informer for a key, if running
+func (s *InformerStore) get(namespace, resourceType string) *resourceInformer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.informers[informerKey(namespace, resourceType)]
+}
+
+// ensure returns the informer for a key, starting it if needed
+func (s *InformerStore) ensure(namespace, resourceType string) (*resourceInformer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := informerKey(namespace, resourceType)
+	if ri, exists := s.informers[key]; exists {
+		return ri, nil
+	}
+
+	lw, object, err := newListWatch(s.clientset, namespace, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	informer := cache.NewSharedIndexInformer(lw, object, 0, cache.Indexers{})
+
+	ri := &resourceInformer{
+		namespace:    namespace,
+		resourceType: resourceType,
+		informer:     informer,
+		stopCh:       make(chan struct{}),
+		resources:    make(map[string]*models.Resource),
+		lastAccess:   time.Now(),
+	}
+
+	// Managed fields are never displayed and dominate memory on big clusters
+	if err := informer.SetTransform(stripManagedFields); err != nil {
+		return nil, fmt.Errorf("failed to set informer transform: %w", err)
+	}
+
+	// The default handler logs to stderr, which would corrupt the TUI.
+	// The reflector retries with backoff on its own.
+	if err := informer.SetWatchErrorHandler(func(_ *cache.Reflector, err error) {
+		ri.mu.Lock()
+		ri.lastError = err.Error()
+		ri.mu.Unlock()
+	}); err != nil {
+		return nil, fmt.Errorf("failed to set informer error handler: %w", err)
+	}
+
+	registration, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    ri.upsert,
+		UpdateFunc: func(_, obj interface{}) { ri.upsert(obj) },
+		DeleteFunc: ri.remove,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add informer handler: %w", err)
+	}
+	ri.registration = registration
+
+	s.informers[key] = ri
+	go informer.Run(ri.stopCh)
+
+	return ri, nil
+}
+
+// Prune stops informers that have not been read recently
+func (s *InformerStore) Prune() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pruned := 0
+	for key, ri := range s.informers {
+		ri.mu.RLock()
+		idle := time.Since(ri.lastAccess)
+		ri.mu.RUnlock()
+
+		if idle > s.idleTimeout {
+			close(ri.stopCh)
+			delete(s.informers, key)
+			pruned++
+		}
+	}
+	return pruned
+}
+
+// Stats returns the sync state and object count of every running informer
+func (s *InformerStore) Stats() []InformerStats {
+	s.mu.Lock()
+	informers := make([]*resourceInformer, 0, len(s.informers))
+	for _, ri := range s.informers {
+		informers = append(informers, ri)
+	}
+	s.mu.Unlock()
+
+	stats := make([]InformerStats, 0, len(informers))
+	for _, ri := range informers {
+		ri.mu.RLock()
+		stats = append(stats, InformerStats{
+			Namespace:       ri.namespace,
+			ResourceType:    ri.resourceType,
+			Synced:          ri.registration.HasSynced(),
+			Objects:         len(ri.resources),
+			ResourceVersion: ri.informer.LastSyncResourceVersion(),
+			LastError:       ri.lastError,
+			LastAccess:      ri.lastAccess,
+		})
+		ri.mu.RUnlock()
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].ResourceType != stats[j].ResourceType {
+			return stats[i].ResourceType < stats[j].ResourceType
+		}
+		return stats[i].Namespace < stats[j].Namespace
+	})
+	return stats
+}
+
+// Stop stops all informers
+func (s *InformerStore) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, ri := range s.informers {
+		close(ri.stopCh)
+		delete(s.informers, key)
+	}
+}
+
+// upsert converts and stores an added or updated object
+func (ri *resourceInformer) upsert(obj interface{}) {
+	runtimeObj, ok := obj.(runtime.Object)
+	if !ok {
+		return
+	}
+
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+
+	resource, err := kubernetesclient.ConvertObject(runtimeObj)
+	if err != nil {
+		return
+	}
+
+	ri.mu.Lock()
+	ri.resources[key] = resource
+	ri.lastError = ""
+	ri.mu.Unlock()
+}
+
+// remove drops a deleted object
+func (ri *resourceInformer) remove(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+
+	ri.mu.Lock()
+	delete(ri.resources, key)
+	ri.mu.Unlock()
+}
+
+// list returns the stored resources sorted by namespace and name, optionally
+// limited to one namespace
+func (ri *resourceInformer) list(namespace string) []*models.Resource {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+
+	ri.lastAccess = time.Now()
+
+	resources := make([]*models.Resource, 0, len(ri.resources))
+	for _, resource := range ri.resources {
+		if namespace == "" || resource.Metadata.Namespace == namespace {
+			resources = append(resources, resource)
+		}
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Metadata.Namespace != resources[j].Metadata.Namespace {
+			return resources[i].Metadata.Namespace < resources[j].Metadata.Namespace
+		}
+		return resources[i].Metadata.Name < resources[j].Metadata.Name
+	})
+	return resources
+}
+
+// waitForSync blocks until the handler has seen the initial list or ctx is done
+func waitForSync(ctx context.Context, registration cache.ResourceEventHandlerRegistration) error {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for !registration.HasSynced() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// stripManagedFields drops metadata.managedFields before objects are stored
+func stripManagedFields(obj interface{}) (interface{}, error) {
+	if accessor, err := meta.Accessor(obj); err == nil {
+		accessor.SetManagedFields(nil)
+	}
+	return obj, nil
+}
+
+// informerKey builds the map key for an informer
+func informerKey(namespace, resourceType string) string {
+	return fmt.Sprintf("%s:%s", namespace, resourceType)
+}
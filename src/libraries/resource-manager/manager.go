@@ -3,18 +3,23 @@ package resourcemanager
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
 	"github.com/anindyar/kuber/src/models"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // ResourceManager provides high-level resource management operations
 type ResourceManager struct {
 	client     *kubernetesclient.KubernetesClient
 	cache      *ResourceCache
+	informers  *InformerStore
 	watcher    *ResourceWatcher
+	discovery  *ResourceDiscovery
 	mu         sync.RWMutex
 	ctx        context.Context
 	cancelFunc context.CancelFunc
@@ -60,10 +65,21 @@ func NewResourceManager(client *kubernetesclient.KubernetesClient, config *Resou
 		return nil, fmt.Errorf("failed to create resource watcher: %w", err)
 	}
 
+	discovery, err := NewResourceDiscovery(client)
+	if err != nil {
+		cancelFunc()
+		return nil, fmt.Errorf("failed to create resource discovery: %w", err)
+	}
+
+	// Let the client list any discovered type (including CRDs) dynamically
+	client.SetResourceResolver(discovery.ResolveResource)
+
 	rm := &ResourceManager{
 		client:     client,
 		cache:      cache,
+		informers:  NewInformerStore(client.GetClientset(), config.CacheTTL),
 		watcher:    watcher,
+		discovery:  discovery,
 		ctx:        ctx,
 		cancelFunc: cancelFunc,
 	}
@@ -102,10 +118,16 @@ func (rm *ResourceManager) GetNamespaces(ctx context.Context) ([]*models.Namespa
 	return namespaces, nil
 }
 
-// GetResourcesByType retrieves resources of a specific type from a namespace
+// GetResourcesByType retrieves resources of a specific type from a namespace.
+// Built-in types are served from informers; other types use the TTL cache.
 func (rm *ResourceManager) GetResourcesByType(ctx context.Context, namespace, resourceType string) ([]*models.Resource, error) {
-	rm.mu.RLock()
-	defer rm.mu.RUnlock()
+	if rm.informers.Supports(resourceType) {
+		resources, err := rm.informers.List(ctx, namespace, resourceType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resources: %w", err)
+		}
+		return resources, nil
+	}
 
 	// Check cache first
 	cacheKey := fmt.Sprintf("resources:%s:%s", namespace, resourceType)
@@ -181,27 +203,75 @@ func (rm *ResourceManager) RefreshAll(ctx context.Context) error {
 	return nil
 }
 
+// GetCustomResourceTypes returns the discovered non built-in resource types
+// (CRDs and aggregated APIs), discovering them on first use
+func (rm *ResourceManager) GetCustomResourceTypes(ctx context.Context) ([]string, error) {
+	if len(rm.discovery.GetResourceTypes()) == 0 {
+		if err := rm.discovery.DiscoverResources(ctx); err != nil {
+			return nil, fmt.Errorf("failed to discover resources: %w", err)
+		}
+	}
+	return rm.discovery.GetCustomResourceTypes(), nil
+}
+
+// InvalidateResources drops the cached list for a resource type so the next
+// read goes to the API server (used after a resource has been modified)
+func (rm *ResourceManager) InvalidateResources(namespace, resourceType string) {
+	rm.cache.Delete(fmt.Sprintf("resources:%s:%s", namespace, resourceType))
+}
+
+// Search finds resources matching a query such as
+// `kind:pod ns:default status:running restarts>3 nginx`; see ParseQuery
+func (rm *ResourceManager) Search(ctx context.Context, query string) ([]*models.Resource, error) {
+	filters, err := ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return rm.SearchResources(ctx, "", filters)
+}
+
 // SearchResources searches for resources across namespaces
 func (rm *ResourceManager) SearchResources(ctx context.Context, query string, filters *ResourceFilters) ([]*models.Resource, error) {
+	if filters == nil {
+		filters = NewResourceFilters()
+	}
+
 	var allResources []*models.Resource
 
-	// Get namespaces to search
+	// Without a namespace filter each type is listed once for all
+	// namespaces, which informers serve from a single watch
 	namespaces := filters.Namespaces
 	if len(namespaces) == 0 {
-		// Search all namespaces
-		nsList, err := rm.GetNamespaces(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get namespaces: %w", err)
-		}
-		for _, ns := range nsList {
-			namespaces = append(namespaces, ns.Name)
+		namespaces = []string{""}
+	}
+	resourceTypes := filters.ResourceTypes
+	if len(resourceTypes) == 0 {
+		resourceTypes = searchResourceTypes
+	}
+
+	// Cluster-scoped types are in no namespace, so a namespace filter
+	// doesn't apply to them and they are listed only once
+	clusterScoped := make(map[string]bool)
+	if len(filters.Namespaces) > 0 {
+		for _, resourceType := range resourceTypes {
+			clusterScoped[resourceType] = rm.isClusterScoped(ctx, resourceType)
 		}
 	}
+	listed := make(map[string]bool)
 
 	// Search each namespace
 	for _, namespace := range namespaces {
-		for _, resourceType := range filters.ResourceTypes {
-			resources, err := rm.GetResourcesByType(ctx, namespace, resourceType)
+		for _, resourceType := range resourceTypes {
+			listNamespace := namespace
+			if clusterScoped[resourceType] {
+				if listed[resourceType] {
+					continue
+				}
+				listed[resourceType] = true
+				listNamespace = ""
+			}
+
+			resources, err := rm.GetResourcesByType(ctx, listNamespace, resourceType)
 			if err != nil {
 				continue // Skip failed resource types
 			}
@@ -215,19 +285,59 @@ func (rm *ResourceManager) SearchResources(ctx context.Context, query string, fi
 		}
 	}
 
+	sort.SliceStable(allResources, func(i, j int) bool {
+		a, b := allResources[i], allResources[j]
+		if a.Metadata.Namespace != b.Metadata.Namespace {
+			return a.Metadata.Namespace < b.Metadata.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Metadata.Name < b.Metadata.Name
+	})
+
 	return allResources, nil
 }
 
+// isClusterScoped reports whether a resource type has no namespace. Types
+// discovery doesn't know are taken to be namespaced.
+func (rm *ResourceManager) isClusterScoped(ctx context.Context, resourceType string) bool {
+	_, namespaced, err := rm.discovery.ResolveResource(ctx, resourceType)
+	return err == nil && !namespaced
+}
+
 // WatchResources starts watching for resource changes
 func (rm *ResourceManager) WatchResources(ctx context.Context, namespace, resourceType string, callback func(*models.Resource, string)) error {
 	return rm.watcher.WatchResources(ctx, namespace, resourceType, callback)
 }
 
+// SetWatchErrorHandler replaces the handler for watch errors, which by
+// default prints to stdout
+func (rm *ResourceManager) SetWatchErrorHandler(handler func(error)) {
+	rm.watcher.SetErrorHandler(handler)
+}
+
+// StopWatch stops a single watch started with WatchResources
+func (rm *ResourceManager) StopWatch(namespace, resourceType string) {
+	rm.watcher.StopWatch(namespace, resourceType)
+}
+
 // StopWatching stops watching for resource changes
 func (rm *ResourceManager) StopWatching() {
 	rm.watcher.Stop()
 }
 
+// WatchHealth returns an error while live updates are degraded, i.e. a watch
+// is reconnecting or events were recently dropped
+func (rm *ResourceManager) WatchHealth() error {
+	return rm.watcher.HealthCheck()
+}
+
+// WatchStats returns restart, relist and dropped-event counts for the watches
+func (rm *ResourceManager) WatchStats() WatcherStats {
+	return rm.watcher.GetStats()
+}
+
 // GetClusterInfo retrieves cluster information with caching
 func (rm *ResourceManager) GetClusterInfo(ctx context.Context) (*models.ClusterInfo, error) {
 	rm.mu.RLock()
@@ -253,15 +363,19 @@ func (rm *ResourceManager) GetClusterInfo(ctx context.Context) (*models.ClusterI
 	return info, nil
 }
 
-// GetCacheStats returns cache statistics
-func (rm *ResourceManager) GetCacheStats() CacheStats {
-	return rm.cache.GetStats()
+// GetCacheStats returns the sync state and object count of each informer
+func (rm *ResourceManager) GetCacheStats() []InformerStats {
+	return rm.informers.Stats()
 }
 
 // Close cleans up resources
 func (rm *ResourceManager) Close() error {
 	rm.cancelFunc()
 
+	if rm.informers != nil {
+		rm.informers.Stop()
+	}
+
 	if rm.watcher != nil {
 		rm.watcher.Stop()
 	}
@@ -308,20 +422,60 @@ func (rm *ResourceManager) handleWatchEvent(event *WatchEvent) {
 		return
 	}
 
-	// Invalidate relevant cache entries
-	cacheKey := fmt.Sprintf("resources:%s:%s", event.Namespace, event.ResourceType)
-	rm.cache.Delete(cacheKey)
-
-	// Also invalidate namespace cache if needed
+	// Namespaces are cached as models.Namespace, which events don't carry
 	if event.ResourceType == "namespaces" {
 		rm.cache.Delete("namespaces")
+		return
+	}
+
+	if event.Resource == nil {
+		return
+	}
+
+	// Patch both the watched list and, for all-namespace watches, the list
+	// of the namespace the object lives in
+	keys := []string{fmt.Sprintf("resources:%s:%s", event.Namespace, event.ResourceType)}
+	if event.Namespace != event.Resource.Metadata.Namespace {
+		keys = append(keys, fmt.Sprintf("resources:%s:%s", event.Resource.Metadata.Namespace, event.ResourceType))
+	}
+
+	for _, cacheKey := range keys {
+		cached, ok := rm.cache.Get(cacheKey).([]*models.Resource)
+		if !ok {
+			continue
+		}
+		rm.cache.Set(cacheKey, PatchResourceList(cached, event.Resource, event.Type))
 	}
 }
 
+// PatchResourceList returns a copy of resources with a watch event applied.
+// The cached slice is never modified in place since readers may hold it.
+func PatchResourceList(resources []*models.Resource, resource *models.Resource, eventType string) []*models.Resource {
+	patched := make([]*models.Resource, 0, len(resources)+1)
+	found := false
+	for _, existing := range resources {
+		if existing.Metadata.Name == resource.Metadata.Name && existing.Metadata.Namespace == resource.Metadata.Namespace {
+			found = true
+			if eventType == "DELETED" {
+				continue
+			}
+			patched = append(patched, resource)
+			continue
+		}
+		patched = append(patched, existing)
+	}
+
+	if !found && eventType != "DELETED" {
+		patched = append(patched, resource)
+	}
+	return patched
+}
+
 // performPeriodicRefresh performs periodic cache cleanup and refresh
 func (rm *ResourceManager) performPeriodicRefresh() {
-	// Clean expired cache entries
+	// Clean expired cache entries and stop informers nobody reads anymore
 	rm.cache.CleanExpired()
+	rm.informers.Prune()
 
 	// Optional: Pre-fetch commonly accessed resources
 	// This could be made configurable based on usage patterns
@@ -344,8 +498,9 @@ func (rm *ResourceManager) matchesFilters(resource *models.Resource, filters *Re
 		return true
 	}
 
-	// Check namespace filter
-	if len(filters.Namespaces) > 0 {
+	// Check namespace filter; cluster-scoped resources are in none, so
+	// it doesn't apply to them
+	if len(filters.Namespaces) > 0 && resource.IsNamespaced() {
 		found := false
 		for _, ns := range filters.Namespaces {
 			if resource.Metadata.Namespace == ns {
@@ -365,10 +520,35 @@ func (rm *ResourceManager) matchesFilters(resource *models.Resource, filters *Re
 		}
 	}
 
-	// Check status filter
+	if filters.LabelSelector != nil && !filters.LabelSelector.Matches(labels.Set(resource.Metadata.Labels)) {
+		return false
+	}
+
+	// Check status filter against the computed status, the phase or a
+	// reason such as CrashLoopBackOff
 	if filters.Status != "" {
-		// Compare with computed status phase
-		if string(resource.ComputeStatus()) != filters.Status {
+		phase, _ := resource.Status["phase"].(string)
+		reason, _ := resource.Status["reason"].(string)
+		if !strings.EqualFold(string(resource.ComputeStatus()), filters.Status) &&
+			!strings.EqualFold(phase, filters.Status) && !strings.EqualFold(reason, filters.Status) {
+			return false
+		}
+	}
+
+	created := resource.Metadata.CreationTimestamp
+	if filters.CreatedAfter != nil && created.Before(*filters.CreatedAfter) {
+		return false
+	}
+	if filters.CreatedBefore != nil && created.After(*filters.CreatedBefore) {
+		return false
+	}
+
+	if filters.Restarts != nil && !filters.Restarts.Matches(int64(resource.Restarts)) {
+		return false
+	}
+
+	for _, text := range filters.Text {
+		if !resource.ContainsText(text) {
 			return false
 		}
 	}
@@ -381,9 +561,12 @@ type ResourceFilters struct {
 	Namespaces    []string
 	ResourceTypes []string
 	Labels        map[string]string
+	LabelSelector labels.Selector
 	Status        string
 	CreatedAfter  *time.Time
 	CreatedBefore *time.Time
+	Restarts      *Comparison
+	Text          []string // each must appear in the name, labels or annotations
 }
 
 // NewResourceFilters creates a new resource filters instance
@@ -3,7 +3,8 @@
 //
 // This package builds on top of the kubernetes-client library to provide:
 //
-// - Resource caching with TTL and LRU eviction
+// - Informer-backed resource lists kept current by watches
+// - Resource caching with TTL and LRU eviction for other types
 // - Real-time resource watching and change notifications
 // - Resource type discovery and schema information
 // - Advanced filtering and searching capabilities
@@ -111,18 +112,23 @@
 //
 // # Caching
 //
-// The resource manager includes sophisticated caching:
+// Built-in resource types are served from shared informers, one per
+// (namespace, resourceType). Each informer lists once, then follows a watch
+// that resumes from the last resourceVersion, so reads are served from memory.
+// Informers that are not read for CacheTTL are stopped.
+//
+// Other types (CRDs, aggregated APIs) use the TTL cache:
 //
 // - **TTL-based expiration**: Entries expire after a configurable time
 // - **LRU eviction**: Least recently used entries are removed when cache is full
 // - **Pattern invalidation**: Invalidate cache entries matching patterns
-// - **Statistics tracking**: Monitor cache hit rates and performance
 //
-// Get cache statistics:
+// Get informer statistics:
 //
-//	stats := manager.GetCacheStats()
-//	fmt.Printf("Cache hits: %d, misses: %d, hit ratio: %.2f%%\n",
-//		stats.Hits, stats.Misses, stats.HitRatio*100)
+//	for _, s := range manager.GetCacheStats() {
+//		fmt.Printf("%s in %q: synced=%v objects=%d rv=%s\n",
+//			s.ResourceType, s.Namespace, s.Synced, s.Objects, s.ResourceVersion)
+//	}
 //
 // Manual cache operations:
 //
@@ -227,7 +233,7 @@
 //	stats := manager.GetCacheStats()
 //	watcherStats := manager.watcher.GetStats()
 //
-//	fmt.Printf("Cache performance: %.2f%% hit rate\n", stats.HitRatio*100)
+//	fmt.Printf("Informers: %d\n", len(stats))
 //	fmt.Printf("Active watchers: %d\n", watcherStats.ActiveWatchers)
 //
 // # Error Handling
@@ -0,0 +1,203 @@
+package resourcemanager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// searchResourceTypes are searched when a query names no kind: the
+// namespaced built-in types served from informers
+var searchResourceTypes = []string{"pods", "deployments", "statefulsets", "services", "ingress", "configmaps", "secrets", "persistentvolumeclaims"}
+
+// kindAliases maps the kinds, plurals and short names accepted by kind: to
+// resource types. Anything else is passed on as is and resolved through
+// discovery, so custom resources work too.
+var kindAliases = map[string]string{
+	"pod": "pods", "pods": "pods", "po": "pods",
+	"deployment": "deployments", "deployments": "deployments", "deploy": "deployments",
+	"statefulset": "statefulsets", "statefulsets": "statefulsets", "sts": "statefulsets",
+	"daemonset": "daemonsets", "daemonsets": "daemonsets", "ds": "daemonsets",
+	"replicaset": "replicasets", "replicasets": "replicasets", "rs": "replicasets",
+	"job": "jobs", "jobs": "jobs",
+	"cronjob": "cronjobs", "cronjobs": "cronjobs", "cj": "cronjobs",
+	"service": "services", "services": "services", "svc": "services",
+	"ingress": "ingress", "ingresses": "ingress", "ing": "ingress",
+	"configmap": "configmaps", "configmaps": "configmaps", "cm": "configmaps",
+	"secret": "secrets", "secrets": "secrets",
+	"persistentvolumeclaim": "persistentvolumeclaims", "persistentvolumeclaims": "persistentvolumeclaims", "pvc": "persistentvolumeclaims",
+	"persistentvolume": "persistentvolumes", "persistentvolumes": "persistentvolumes", "pv": "persistentvolumes",
+	"node": "nodes", "nodes": "nodes", "no": "nodes",
+}
+
+// Comparison is a numeric condition such as >3 or <=1
+type Comparison struct {
+	Op    string // one of < <= = != >= >
+	Value int64
+}
+
+// Matches reports whether v satisfies the comparison
+func (c Comparison) Matches(v int64) bool {
+	switch c.Op {
+	case "<":
+		return v < c.Value
+	case "<=":
+		return v <= c.Value
+	case "=":
+		return v == c.Value
+	case "!=":
+		return v != c.Value
+	case ">=":
+		return v >= c.Value
+	case ">":
+		return v > c.Value
+	}
+	return false
+}
+
+// ParseQuery parses a search query into filters. Terms are separated by
+// spaces and all must match:
+//
+//	kind:pod,deploy     resource types (kinds, plurals or short names)
+//	ns:default          namespaces, comma separated; cluster-scoped kinds ignore it
+//	l:app=web,tier!=db  label selector; a bare app=web works too
+//	status:running      computed status or phase, case-insensitive
+//	age<1h age>=2d      age, with s, m, h, d or w units
+//	restarts>3          container restarts
+//	nginx               free text in names, labels and annotations
+func ParseQuery(query string) (*ResourceFilters, error) {
+	filters := NewResourceFilters()
+	filters.ResourceTypes = searchResourceTypes
+
+	var selectors []string
+	kindSet := false
+	for _, term := range strings.Fields(query) {
+		if key, value, ok := strings.Cut(term, ":"); ok && value != "" {
+			switch strings.ToLower(key) {
+			case "kind", "k", "type":
+				if !kindSet {
+					filters.ResourceTypes = nil
+					kindSet = true
+				}
+				for _, kind := range strings.Split(value, ",") {
+					filters.AddResourceType(normalizeKind(kind))
+				}
+				continue
+			case "ns", "namespace", "n":
+				for _, namespace := range strings.Split(value, ",") {
+					filters.AddNamespace(namespace)
+				}
+				continue
+			case "l", "label", "labels":
+				selectors = append(selectors, value)
+				continue
+			case "status", "s":
+				filters.SetStatus(value)
+				continue
+			}
+		}
+
+		if field, op, value, ok := cutComparison(term); ok {
+			switch field {
+			case "age":
+				age, err := parseAge(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid age in %q: %w", term, err)
+				}
+				if err := filters.setAge(op, age); err != nil {
+					return nil, fmt.Errorf("invalid age in %q: %w", term, err)
+				}
+				continue
+			case "restarts":
+				n, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid restart count in %q", term)
+				}
+				filters.Restarts = &Comparison{Op: op, Value: n}
+				continue
+			}
+		}
+
+		// key=value and key!=value look like label selectors
+		if strings.ContainsAny(term, "=!") {
+			selectors = append(selectors, term)
+			continue
+		}
+
+		filters.Text = append(filters.Text, term)
+	}
+
+	if len(selectors) > 0 {
+		selector, err := labels.Parse(strings.Join(selectors, ","))
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector: %w", err)
+		}
+		filters.LabelSelector = selector
+	}
+
+	return filters, nil
+}
+
+// normalizeKind maps a kind, plural or short name to a resource type
+func normalizeKind(kind string) string {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if resourceType, ok := kindAliases[kind]; ok {
+		return resourceType
+	}
+	return kind
+}
+
+// cutComparison splits a term like restarts>=3 into field, operator and value
+func cutComparison(term string) (field, op, value string, ok bool) {
+	i := strings.IndexAny(term, "<>=!")
+	if i <= 0 {
+		return "", "", "", false
+	}
+
+	field, rest := strings.ToLower(term[:i]), term[i:]
+	for _, candidate := range []string{"<=", ">=", "!=", "<", ">", "="} {
+		if strings.HasPrefix(rest, candidate) {
+			value = rest[len(candidate):]
+			if value == "" {
+				return "", "", "", false
+			}
+			return field, candidate, value, true
+		}
+	}
+	return "", "", "", false
+}
+
+// parseAge parses a duration that may use d (days) and w (weeks), e.g. 2d
+func parseAge(value string) (time.Duration, error) {
+	unit := value[len(value)-1]
+	if unit == 'd' || unit == 'w' {
+		n, err := strconv.ParseFloat(value[:len(value)-1], 64)
+		if err != nil {
+			return 0, err
+		}
+		day := 24 * time.Hour
+		if unit == 'w' {
+			day *= 7
+		}
+		return time.Duration(n * float64(day)), nil
+	}
+	return time.ParseDuration(value)
+}
+
+// setAge turns an age condition into creation time bounds: younger than an
+// age means created after now minus that age
+func (rf *ResourceFilters) setAge(op string, age time.Duration) error {
+	bound := time.Now().Add(-age)
+	switch op {
+	case "<", "<=":
+		rf.SetCreatedAfter(bound)
+	case ">", ">=":
+		rf.SetCreatedBefore(bound)
+	default:
+		return fmt.Errorf("use < or > with age")
+	}
+	return nil
+}
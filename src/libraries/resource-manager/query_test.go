@@ -0,0 +1,168 @@
+package resourcemanager
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anindyar/kuber/src/models"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query      string
+		types      []string
+		namespaces []string
+		selector   string
+		restarts   *Comparison
+		status     string
+		text       []string
+	}{
+		{query: "", types: searchResourceTypes},
+		{query: "kind:po,deploy,sts", types: []string{"pods", "deployments", "statefulsets"}},
+		{query: "k:svc type:No", types: []string{"services", "nodes"}},
+		{query: "kind:Ingresses,pvc", types: []string{"ingress", "persistentvolumeclaims"}},
+		{query: "kind:widgets.example.com", types: []string{"widgets.example.com"}},
+		{query: "ns:default,kube-system", types: searchResourceTypes, namespaces: []string{"default", "kube-system"}},
+		{query: "app=web", types: searchResourceTypes, selector: "app=web"},
+		{query: "app!=web l:tier=db", types: searchResourceTypes, selector: "app!=web,tier=db"},
+		{query: "restarts>=3", types: searchResourceTypes, restarts: &Comparison{Op: ">=", Value: 3}},
+		{query: "restarts=0", types: searchResourceTypes, restarts: &Comparison{Op: "=", Value: 0}},
+		{query: "status:CrashLoopBackOff", types: searchResourceTypes, status: "CrashLoopBackOff"},
+		{query: "nginx", types: searchResourceTypes, text: []string{"nginx"}},
+		{
+			query:      "kind:pod ns:prod nginx app=web restarts>1 api",
+			types:      []string{"pods"},
+			namespaces: []string{"prod"},
+			selector:   "app=web",
+			restarts:   &Comparison{Op: ">", Value: 1},
+			text:       []string{"nginx", "api"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			filters, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery: %v", err)
+			}
+
+			if !reflect.DeepEqual(filters.ResourceTypes, tt.types) {
+				t.Errorf("ResourceTypes = %v, want %v", filters.ResourceTypes, tt.types)
+			}
+			if len(filters.Namespaces) != 0 || len(tt.namespaces) != 0 {
+				if !reflect.DeepEqual(filters.Namespaces, tt.namespaces) {
+					t.Errorf("Namespaces = %v, want %v", filters.Namespaces, tt.namespaces)
+				}
+			}
+
+			selector := ""
+			if filters.LabelSelector != nil {
+				selector = filters.LabelSelector.String()
+			}
+			if selector != tt.selector {
+				t.Errorf("LabelSelector = %q, want %q", selector, tt.selector)
+			}
+			if !reflect.DeepEqual(filters.Restarts, tt.restarts) {
+				t.Errorf("Restarts = %v, want %v", filters.Restarts, tt.restarts)
+			}
+			if filters.Status != tt.status {
+				t.Errorf("Status = %q, want %q", filters.Status, tt.status)
+			}
+			if !reflect.DeepEqual(filters.Text, tt.text) {
+				t.Errorf("Text = %v, want %v", filters.Text, tt.text)
+			}
+		})
+	}
+}
+
+func TestParseQueryAge(t *testing.T) {
+	tests := []struct {
+		query  string
+		before time.Duration // age of CreatedBefore, 0 when unset
+		after  time.Duration // age of CreatedAfter, 0 when unset
+	}{
+		{query: "age<1h", after: time.Hour},
+		{query: "age<=90m", after: 90 * time.Minute},
+		{query: "age>2d", before: 48 * time.Hour},
+		{query: "age>=1w", before: 7 * 24 * time.Hour},
+		{query: "age>1.5d", before: 36 * time.Hour},
+		{query: "age>1h age<1d", before: time.Hour, after: 24 * time.Hour},
+	}
+
+	near := func(bound *time.Time, age time.Duration) bool {
+		if bound == nil {
+			return age == 0
+		}
+		want := time.Now().Add(-age)
+		return age != 0 && bound.Sub(want).Abs() < time.Minute
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			filters, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery: %v", err)
+			}
+			if !near(filters.CreatedBefore, tt.before) {
+				t.Errorf("CreatedBefore = %v, want %v ago", filters.CreatedBefore, tt.before)
+			}
+			if !near(filters.CreatedAfter, tt.after) {
+				t.Errorf("CreatedAfter = %v, want %v ago", filters.CreatedAfter, tt.after)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{query: "age<abc", err: "invalid age"},
+		{query: "age<1x", err: "invalid age"},
+		{query: "age<d", err: "invalid age"},
+		{query: "age=1h", err: "use < or > with age"},
+		{query: "age!=1h", err: "use < or > with age"},
+		{query: "restarts>many", err: "invalid restart count"},
+		{query: "app==web=x", err: "invalid label selector"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestMatchesFiltersIgnoresNamespacesForClusterScoped(t *testing.T) {
+	rm := &ResourceManager{}
+	filters, err := ParseQuery("ns:prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		resource *models.Resource
+		want     bool
+	}{
+		{"node", &models.Resource{Kind: "Node", Metadata: models.Metadata{Name: "node-1"}}, true},
+		{"pod in namespace", &models.Resource{Kind: "Pod", Metadata: models.Metadata{Name: "web", Namespace: "prod"}}, true},
+		{"pod elsewhere", &models.Resource{Kind: "Pod", Metadata: models.Metadata{Name: "web", Namespace: "dev"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rm.matchesFilters(tt.resource, filters); got != tt.want {
+				t.Errorf("matchesFilters = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,449 @@
+package resourcemanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Relation is an object related to another through a selector or a
+// reference, e.g. a pod a Service selects or a Secret a pod mounts
+type Relation struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Type      string // how it relates, e.g. "selects", "mounted by"
+	Detail    string // e.g. the volume or port involved
+}
+
+// ResourceType returns the resource type used to list the related object,
+// e.g. "pods" for a Pod
+func (r Relation) ResourceType() string {
+	return ResourceTypeForKind(r.Kind)
+}
+
+// ResourceTypeForKind returns the resource type used to list objects of a
+// kind. Kinds without a built-in tab are returned lowercased, which
+// discovery resolves like a plural.
+func ResourceTypeForKind(kind string) string {
+	if resourceType, ok := kindResourceTypes[kind]; ok {
+		return resourceType
+	}
+	return strings.ToLower(kind)
+}
+
+// kindResourceTypes maps kinds to the resource types of the resource tabs
+var kindResourceTypes = map[string]string{
+	"Pod":                   "pods",
+	"Service":               "services",
+	"Ingress":               "ingress",
+	"ConfigMap":             "configmaps",
+	"Secret":                "secrets",
+	"PersistentVolumeClaim": "persistentvolumeclaims",
+	"PersistentVolume":      "persistentvolumes",
+	"Deployment":            "deployments",
+	"StatefulSet":           "statefulsets",
+	"DaemonSet":             "daemonsets",
+	"EndpointSlice":         "endpointslices",
+	"Node":                  "nodes",
+	"ReplicaSet":            "replicasets",
+	"Job":                   "jobs",
+	"CronJob":               "cronjobs",
+}
+
+// Relationships returns the objects related to a resource through label
+// selectors and references, beyond ownership: Service ↔ pods (also through
+// EndpointSlices), Ingress → Service, PVC ↔ PV, and ConfigMaps, Secrets and
+// PVCs used by pods in volumes, env and envFrom.
+func (rm *ResourceManager) Relationships(ctx context.Context, namespace, kind, name string) ([]Relation, error) {
+	cs := rm.client.GetClientset()
+	if cs == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	finder := &relationFinder{cs: cs, namespace: namespace}
+
+	var relations []Relation
+	var err error
+	switch kind {
+	case "Pod":
+		relations, err = finder.pod(ctx, name)
+	case "Service":
+		relations, err = finder.service(ctx, name)
+	case "Ingress":
+		relations, err = finder.ingress(ctx, name)
+	case "PersistentVolumeClaim":
+		relations, err = finder.claim(ctx, name)
+	case "PersistentVolume":
+		relations, err = finder.volume(ctx, name)
+	case "ConfigMap", "Secret":
+		relations, err = finder.configUsers(ctx, kind, name)
+	case "Deployment", "StatefulSet", "DaemonSet":
+		relations, err = finder.workload(ctx, kind, name)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find objects related to %s %s: %w", kind, name, err)
+	}
+
+	return dedupRelations(relations), nil
+}
+
+// relationFinder looks up related objects in one namespace
+type relationFinder struct {
+	cs        kubernetes.Interface
+	namespace string
+}
+
+// pod finds the Services selecting a pod, what it mounts and references,
+// and its node
+func (f *relationFinder) pod(ctx context.Context, name string) ([]Relation, error) {
+	pod, err := f.cs.CoreV1().Pods(f.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	relations, err := f.servicesSelecting(ctx, pod.Labels)
+	if err != nil {
+		return nil, err
+	}
+	relations = append(relations, podSpecReferences(f.namespace, &pod.Spec)...)
+	if pod.Spec.NodeName != "" {
+		relations = append(relations, Relation{Kind: "Node", Name: pod.Spec.NodeName, Type: "runs on"})
+	}
+	return relations, nil
+}
+
+// service finds the pods a Service selects, its EndpointSlices and the
+// Ingresses routing to it
+func (f *relationFinder) service(ctx context.Context, name string) ([]Relation, error) {
+	svc, err := f.cs.CoreV1().Services(f.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var relations []Relation
+	if len(svc.Spec.Selector) > 0 {
+		pods, err := f.cs.CoreV1().Pods(f.namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range pods.Items {
+			relations = append(relations, Relation{Kind: "Pod", Name: pod.Name, Namespace: f.namespace, Type: "selects", Detail: string(pod.Status.Phase)})
+		}
+	}
+
+	// Services without a selector get their endpoints from EndpointSlices
+	// managed by something else, which may point at pods too
+	slices, err := f.cs.DiscoveryV1().EndpointSlices(f.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: name}).String(),
+	})
+	if err == nil {
+		for _, slice := range slices.Items {
+			relations = append(relations, Relation{Kind: "EndpointSlice", Name: slice.Name, Namespace: f.namespace, Type: "endpoints",
+				Detail: fmt.Sprintf("%d endpoint(s)", len(slice.Endpoints))})
+			for _, endpoint := range slice.Endpoints {
+				if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" {
+					continue
+				}
+				detail := "ready"
+				if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+					detail = "not ready"
+				}
+				relations = append(relations, Relation{Kind: "Pod", Name: endpoint.TargetRef.Name, Namespace: f.namespace, Type: "endpoint", Detail: detail})
+			}
+		}
+	}
+
+	ingresses, err := f.cs.NetworkingV1().Ingresses(f.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, ing := range ingresses.Items {
+		for _, backend := range ingressBackends(&ing) {
+			if backend.Name == name {
+				relations = append(relations, Relation{Kind: "Ingress", Name: ing.Name, Namespace: f.namespace, Type: "routed from", Detail: backend.Detail})
+			}
+		}
+	}
+
+	return relations, nil
+}
+
+// ingress finds the Services an Ingress routes to and its TLS Secrets
+func (f *relationFinder) ingress(ctx context.Context, name string) ([]Relation, error) {
+	ing, err := f.cs.NetworkingV1().Ingresses(f.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var relations []Relation
+	for _, backend := range ingressBackends(ing) {
+		relations = append(relations, Relation{Kind: "Service", Name: backend.Name, Namespace: f.namespace, Type: "routes to", Detail: backend.Detail})
+	}
+	for _, tls := range ing.Spec.TLS {
+		if tls.SecretName != "" {
+			relations = append(relations, Relation{Kind: "Secret", Name: tls.SecretName, Namespace: f.namespace, Type: "TLS certificate"})
+		}
+	}
+	return relations, nil
+}
+
+// claim finds the volume a PVC is bound to and the pods mounting it
+func (f *relationFinder) claim(ctx context.Context, name string) ([]Relation, error) {
+	pvc, err := f.cs.CoreV1().PersistentVolumeClaims(f.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var relations []Relation
+	if pvc.Spec.VolumeName != "" {
+		relations = append(relations, Relation{Kind: "PersistentVolume", Name: pvc.Spec.VolumeName, Type: "bound to", Detail: string(pvc.Status.Phase)})
+	}
+
+	users, err := f.podsReferencing(ctx, "PersistentVolumeClaim", name)
+	if err != nil {
+		return nil, err
+	}
+	return append(relations, users...), nil
+}
+
+// volume finds the claim a PV is bound to
+func (f *relationFinder) volume(ctx context.Context, name string) ([]Relation, error) {
+	pv, err := f.cs.CoreV1().PersistentVolumes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if ref := pv.Spec.ClaimRef; ref != nil {
+		return []Relation{{Kind: "PersistentVolumeClaim", Name: ref.Name, Namespace: ref.Namespace, Type: "bound to", Detail: string(pv.Status.Phase)}}, nil
+	}
+	return nil, nil
+}
+
+// configUsers finds the pods and Ingresses using a ConfigMap or Secret
+func (f *relationFinder) configUsers(ctx context.Context, kind, name string) ([]Relation, error) {
+	relations, err := f.podsReferencing(ctx, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	if kind != "Secret" {
+		return relations, nil
+	}
+
+	ingresses, err := f.cs.NetworkingV1().Ingresses(f.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, ing := range ingresses.Items {
+		for _, tls := range ing.Spec.TLS {
+			if tls.SecretName == name {
+				relations = append(relations, Relation{Kind: "Ingress", Name: ing.Name, Namespace: f.namespace, Type: "TLS certificate of"})
+			}
+		}
+	}
+	return relations, nil
+}
+
+// workload finds the Services selecting a workload's pods and what its pod
+// template references
+func (f *relationFinder) workload(ctx context.Context, kind, name string) ([]Relation, error) {
+	var template *corev1.PodTemplateSpec
+	switch kind {
+	case "Deployment":
+		dep, err := f.cs.AppsV1().Deployments(f.namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		template = &dep.Spec.Template
+	case "StatefulSet":
+		sts, err := f.cs.AppsV1().StatefulSets(f.namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		template = &sts.Spec.Template
+	case "DaemonSet":
+		ds, err := f.cs.AppsV1().DaemonSets(f.namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		template = &ds.Spec.Template
+	}
+
+	relations, err := f.servicesSelecting(ctx, template.Labels)
+	if err != nil {
+		return nil, err
+	}
+	return append(relations, podSpecReferences(f.namespace, &template.Spec)...), nil
+}
+
+// servicesSelecting finds the Services whose selector matches a set of pod labels
+func (f *relationFinder) servicesSelecting(ctx context.Context, podLabels map[string]string) ([]Relation, error) {
+	services, err := f.cs.CoreV1().Services(f.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var relations []Relation
+	for _, svc := range services.Items {
+		// An empty selector selects nothing, not everything
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		if labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(podLabels)) {
+			relations = append(relations, Relation{Kind: "Service", Name: svc.Name, Namespace: f.namespace, Type: "selected by"})
+		}
+	}
+	return relations, nil
+}
+
+// podsReferencing finds the pods using a ConfigMap, Secret or PVC
+func (f *relationFinder) podsReferencing(ctx context.Context, kind, name string) ([]Relation, error) {
+	pods, err := f.cs.CoreV1().Pods(f.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var relations []Relation
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		for _, ref := range podSpecReferences(f.namespace, &pod.Spec) {
+			if ref.Kind == kind && ref.Name == name {
+				relations = append(relations, Relation{Kind: "Pod", Name: pod.Name, Namespace: f.namespace, Type: "used by", Detail: ref.Detail})
+			}
+		}
+	}
+	return relations, nil
+}
+
+// podSpecReferences returns the ConfigMaps, Secrets and PVCs a pod spec
+// uses in volumes (including projected ones), env, envFrom and image pull
+// secrets
+func podSpecReferences(namespace string, spec *corev1.PodSpec) []Relation {
+	var relations []Relation
+	add := func(kind, name, how string) {
+		if name != "" {
+			relations = append(relations, Relation{Kind: kind, Name: name, Namespace: namespace, Type: "uses", Detail: how})
+		}
+	}
+
+	for _, volume := range spec.Volumes {
+		how := "volume " + volume.Name
+		switch {
+		case volume.ConfigMap != nil:
+			add("ConfigMap", volume.ConfigMap.Name, how)
+		case volume.Secret != nil:
+			add("Secret", volume.Secret.SecretName, how)
+		case volume.PersistentVolumeClaim != nil:
+			add("PersistentVolumeClaim", volume.PersistentVolumeClaim.ClaimName, how)
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					add("ConfigMap", source.ConfigMap.Name, how)
+				}
+				if source.Secret != nil {
+					add("Secret", source.Secret.Name, how)
+				}
+			}
+		}
+	}
+
+	containers := append(append([]corev1.Container(nil), spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				add("ConfigMap", envFrom.ConfigMapRef.Name, "envFrom in "+container.Name)
+			}
+			if envFrom.SecretRef != nil {
+				add("Secret", envFrom.SecretRef.Name, "envFrom in "+container.Name)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			how := fmt.Sprintf("env %s in %s", env.Name, container.Name)
+			if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+				add("ConfigMap", ref.Name, how)
+			}
+			if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+				add("Secret", ref.Name, how)
+			}
+		}
+	}
+
+	for _, pullSecret := range spec.ImagePullSecrets {
+		add("Secret", pullSecret.Name, "image pull secret")
+	}
+
+	return relations
+}
+
+// ingressBackend is a Service an Ingress routes to
+type ingressBackend struct {
+	Name   string
+	Detail string // host and path, or "default backend"
+}
+
+// ingressBackends returns the Services an Ingress routes to
+func ingressBackends(ing *networkingv1.Ingress) []ingressBackend {
+	var backends []ingressBackend
+	if backend := ing.Spec.DefaultBackend; backend != nil && backend.Service != nil {
+		backends = append(backends, ingressBackend{Name: backend.Service.Name, Detail: "default backend"})
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil {
+				host := rule.Host
+				if host == "" {
+					host = "*"
+				}
+				backends = append(backends, ingressBackend{Name: path.Backend.Service.Name, Detail: host + path.Path})
+			}
+		}
+	}
+	return backends
+}
+
+// dedupRelations merges relations to the same object, keeping the first
+// relation type and joining the details, and sorts them by kind and name
+func dedupRelations(relations []Relation) []Relation {
+	index := make(map[string]int)
+	var result []Relation
+	for _, relation := range relations {
+		key := relation.Kind + "/" + relation.Namespace + "/" + relation.Name
+		if i, ok := index[key]; ok {
+			if relation.Detail != "" && result[i].Detail != relation.Detail {
+				if result[i].Detail != "" {
+					result[i].Detail += ", "
+				}
+				result[i].Detail += relation.Detail
+			}
+			continue
+		}
+		index[key] = len(result)
+		result = append(result, relation)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
@@ -0,0 +1,488 @@
+package resourcemanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// NodeFit is whether a pod fits on a node and, if not, why
+type NodeFit struct {
+	Node    string
+	Reasons []string
+}
+
+// Fits reports whether nothing keeps the pod off the node
+func (f NodeFit) Fits() bool {
+	return len(f.Reasons) == 0
+}
+
+// ExplainScheduling explains why a pending pod isn't scheduled by checking
+// it against every node
+func (rm *ResourceManager) ExplainScheduling(ctx context.Context, namespace, name string) ([]NodeFit, error) {
+	cs := rm.client.GetClientset()
+	if cs == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	pod, err := cs.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s: %w", name, err)
+	}
+	nodes, err := cs.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	// Finished pods no longer hold resources on their node
+	scheduled, err := cs.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.nodeName!=,status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled pods: %w", err)
+	}
+
+	return ExplainScheduling(pod, nodes.Items, scheduled.Items), nil
+}
+
+// ExplainScheduling checks a pod against each node the way the scheduler's
+// filters do: cordons, nodeSelector, required node affinity, taints, free
+// CPU, memory and other requested resources, host ports, required pod
+// (anti-)affinity and DoNotSchedule topology spread constraints. scheduled
+// are the pods already bound to nodes. Nodes the pod fits on come first.
+func ExplainScheduling(pod *corev1.Pod, nodes []corev1.Node, scheduled []corev1.Pod) []NodeFit {
+	podsByNode := make(map[string][]*corev1.Pod)
+	for i := range scheduled {
+		p := &scheduled[i]
+		if p.Spec.NodeName != "" && p.UID != pod.UID {
+			podsByNode[p.Spec.NodeName] = append(podsByNode[p.Spec.NodeName], p)
+		}
+	}
+	nodesByName := make(map[string]*corev1.Node, len(nodes))
+	for i := range nodes {
+		nodesByName[nodes[i].Name] = &nodes[i]
+	}
+
+	fits := make([]NodeFit, 0, len(nodes))
+	for i := range nodes {
+		node := &nodes[i]
+
+		var reasons []string
+		reasons = append(reasons, checkCordon(pod, node)...)
+		reasons = append(reasons, checkNodeSelector(pod, node)...)
+		reasons = append(reasons, checkNodeAffinity(pod, node)...)
+		reasons = append(reasons, checkTaints(pod, node)...)
+		reasons = append(reasons, checkResources(pod, node, podsByNode[node.Name])...)
+		reasons = append(reasons, checkHostPorts(pod, podsByNode[node.Name])...)
+		reasons = append(reasons, checkPodAffinity(pod, node, nodesByName, scheduled)...)
+		reasons = append(reasons, checkTopologySpread(pod, node, nodes, scheduled)...)
+
+		fits = append(fits, NodeFit{Node: node.Name, Reasons: reasons})
+	}
+
+	sort.SliceStable(fits, func(i, j int) bool {
+		if fits[i].Fits() != fits[j].Fits() {
+			return fits[i].Fits()
+		}
+		return fits[i].Node < fits[j].Node
+	})
+	return fits
+}
+
+// checkCordon reports a cordoned node the pod doesn't tolerate
+func checkCordon(pod *corev1.Pod, node *corev1.Node) []string {
+	if !node.Spec.Unschedulable {
+		return nil
+	}
+	taint := &corev1.Taint{Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule}
+	if toleratesTaint(pod.Spec.Tolerations, taint) {
+		return nil
+	}
+	return []string{"node is cordoned"}
+}
+
+// checkNodeSelector reports nodeSelector labels the node doesn't have
+func checkNodeSelector(pod *corev1.Pod, node *corev1.Node) []string {
+	var reasons []string
+	for _, key := range sortedKeys(pod.Spec.NodeSelector) {
+		want := pod.Spec.NodeSelector[key]
+		have, ok := node.Labels[key]
+		switch {
+		case !ok:
+			reasons = append(reasons, fmt.Sprintf("nodeSelector %s=%s: node has no %s label", key, want, key))
+		case have != want:
+			reasons = append(reasons, fmt.Sprintf("nodeSelector %s=%s: node has %s=%s", key, want, key, have))
+		}
+	}
+	return reasons
+}
+
+// checkNodeAffinity reports a node matching none of the required node
+// affinity terms
+func checkNodeAffinity(pod *corev1.Pod, node *corev1.Node) []string {
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return nil
+	}
+
+	// Terms are ORed; report why the first one fails
+	var firstMismatch string
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		mismatch := nodeSelectorTermMismatch(term, node)
+		if mismatch == "" {
+			return nil
+		}
+		if firstMismatch == "" {
+			firstMismatch = mismatch
+		}
+	}
+	return []string{"node affinity: " + firstMismatch}
+}
+
+// nodeSelectorTermMismatch returns the first requirement of a node selector
+// term the node fails, or "" if it matches
+func nodeSelectorTermMismatch(term corev1.NodeSelectorTerm, node *corev1.Node) string {
+	for _, expr := range term.MatchExpressions {
+		requirement, err := nodeSelectorRequirement(expr)
+		if err != nil {
+			return fmt.Sprintf("invalid expression on %s: %v", expr.Key, err)
+		}
+		if !requirement.Matches(labels.Set(node.Labels)) {
+			return fmt.Sprintf("%s %s %v not matched", expr.Key, expr.Operator, expr.Values)
+		}
+	}
+	for _, field := range term.MatchFields {
+		if field.Key != "metadata.name" {
+			continue
+		}
+		requirement, err := nodeSelectorRequirement(corev1.NodeSelectorRequirement{Key: "name", Operator: field.Operator, Values: field.Values})
+		if err != nil {
+			return fmt.Sprintf("invalid field expression: %v", err)
+		}
+		if !requirement.Matches(labels.Set{"name": node.Name}) {
+			return fmt.Sprintf("metadata.name %s %v not matched", field.Operator, field.Values)
+		}
+	}
+	return ""
+}
+
+// nodeSelectorRequirement converts a node selector expression to a label
+// requirement
+func nodeSelectorRequirement(expr corev1.NodeSelectorRequirement) (*labels.Requirement, error) {
+	var op selection.Operator
+	switch expr.Operator {
+	case corev1.NodeSelectorOpIn:
+		op = selection.In
+	case corev1.NodeSelectorOpNotIn:
+		op = selection.NotIn
+	case corev1.NodeSelectorOpExists:
+		op = selection.Exists
+	case corev1.NodeSelectorOpDoesNotExist:
+		op = selection.DoesNotExist
+	case corev1.NodeSelectorOpGt:
+		op = selection.GreaterThan
+	case corev1.NodeSelectorOpLt:
+		op = selection.LessThan
+	default:
+		return nil, fmt.Errorf("unknown operator %s", expr.Operator)
+	}
+	return labels.NewRequirement(expr.Key, op, expr.Values)
+}
+
+// checkTaints reports NoSchedule and NoExecute taints the pod doesn't
+// tolerate
+func checkTaints(pod *corev1.Pod, node *corev1.Node) []string {
+	var reasons []string
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		// Cordons are reported on their own
+		if taint.Key == corev1.TaintNodeUnschedulable && node.Spec.Unschedulable {
+			continue
+		}
+		if !toleratesTaint(pod.Spec.Tolerations, taint) {
+			reasons = append(reasons, "taint not tolerated: "+taint.ToString())
+		}
+	}
+	return reasons
+}
+
+// toleratesTaint reports whether any toleration tolerates the taint
+func toleratesTaint(tolerations []corev1.Toleration, taint *corev1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkResources reports requested resources the node doesn't have free,
+// counting the requests of the pods already on it
+func checkResources(pod *corev1.Pod, node *corev1.Node, nodePods []*corev1.Pod) []string {
+	var reasons []string
+
+	if allocatable, ok := node.Status.Allocatable[corev1.ResourcePods]; ok && int64(len(nodePods)) >= allocatable.Value() {
+		reasons = append(reasons, fmt.Sprintf("too many pods: %d of %d", len(nodePods), allocatable.Value()))
+	}
+
+	requests := kubernetesclient.PodRequests(pod)
+	used := make(corev1.ResourceList)
+	for _, p := range nodePods {
+		kubernetesclient.AddResources(used, kubernetesclient.PodRequests(p))
+	}
+
+	names := make([]string, 0, len(requests))
+	for name := range requests {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		resourceName := corev1.ResourceName(name)
+		request := requests[resourceName]
+		if request.IsZero() {
+			continue
+		}
+		allocatable := node.Status.Allocatable[resourceName]
+		free := allocatable.DeepCopy()
+		free.Sub(used[resourceName])
+		if free.Cmp(request) < 0 {
+			if free.Sign() < 0 {
+				free = resource.Quantity{}
+			}
+			reasons = append(reasons, fmt.Sprintf("insufficient %s: requests %s, %s free of %s",
+				resourceName, request.String(), free.String(), allocatable.String()))
+		}
+	}
+	return reasons
+}
+
+// checkHostPorts reports host ports already taken on the node
+func checkHostPorts(pod *corev1.Pod, nodePods []*corev1.Pod) []string {
+	taken := make(map[string]bool)
+	for _, p := range nodePods {
+		for _, port := range hostPorts(p) {
+			taken[port] = true
+		}
+	}
+
+	var reasons []string
+	for _, port := range hostPorts(pod) {
+		if taken[port] {
+			reasons = append(reasons, fmt.Sprintf("host port %s already in use", port))
+		}
+	}
+	return reasons
+}
+
+// hostPorts returns a pod's host ports as protocol/port
+func hostPorts(pod *corev1.Pod) []string {
+	var ports []string
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.HostPort == 0 {
+				continue
+			}
+			protocol := port.Protocol
+			if protocol == "" {
+				protocol = corev1.ProtocolTCP
+			}
+			ports = append(ports, fmt.Sprintf("%s/%d", protocol, port.HostPort))
+		}
+	}
+	return ports
+}
+
+// checkPodAffinity reports required pod affinity with no matching pod in
+// the node's topology domain, and required anti-affinity with one
+func checkPodAffinity(pod *corev1.Pod, node *corev1.Node, nodes map[string]*corev1.Node, scheduled []corev1.Pod) []string {
+	affinity := pod.Spec.Affinity
+	if affinity == nil {
+		return nil
+	}
+
+	// matchingInDomain finds a scheduled pod matching the term in the same
+	// topology domain as the node
+	matchingInDomain := func(term corev1.PodAffinityTerm) (*corev1.Pod, string, bool) {
+		domain, ok := node.Labels[term.TopologyKey]
+		if !ok {
+			return nil, "", false
+		}
+		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil {
+			return nil, domain, true
+		}
+		namespaces := term.Namespaces
+		if len(namespaces) == 0 && term.NamespaceSelector == nil {
+			namespaces = []string{pod.Namespace}
+		}
+		for i := range scheduled {
+			other := &scheduled[i]
+			if other.UID == pod.UID || (len(namespaces) > 0 && !containsString(namespaces, other.Namespace)) {
+				continue
+			}
+			otherNode := nodes[other.Spec.NodeName]
+			if otherNode == nil || otherNode.Labels[term.TopologyKey] != domain {
+				continue
+			}
+			if selector.Matches(labels.Set(other.Labels)) {
+				return other, domain, true
+			}
+		}
+		return nil, domain, true
+	}
+
+	var reasons []string
+	if affinity.PodAffinity != nil {
+		for _, term := range affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			match, domain, hasKey := matchingInDomain(term)
+			switch {
+			case !hasKey:
+				reasons = append(reasons, fmt.Sprintf("pod affinity: node has no %s label", term.TopologyKey))
+			case match == nil:
+				reasons = append(reasons, fmt.Sprintf("pod affinity: no pod matching %s in %s=%s",
+					metav1.FormatLabelSelector(term.LabelSelector), term.TopologyKey, domain))
+			}
+		}
+	}
+	if affinity.PodAntiAffinity != nil {
+		for _, term := range affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if match, domain, _ := matchingInDomain(term); match != nil {
+				reasons = append(reasons, fmt.Sprintf("pod anti-affinity: %s/%s already in %s=%s",
+					match.Namespace, match.Name, term.TopologyKey, domain))
+			}
+		}
+	}
+	return reasons
+}
+
+// checkTopologySpread reports DoNotSchedule topology spread constraints the
+// pod would break on the node. Domains are those of the nodes that pass the
+// pod's nodeSelector and node affinity, as the scheduler counts them.
+func checkTopologySpread(pod *corev1.Pod, node *corev1.Node, nodes []corev1.Node, scheduled []corev1.Pod) []string {
+	var reasons []string
+	for _, constraint := range pod.Spec.TopologySpreadConstraints {
+		if constraint.WhenUnsatisfiable != corev1.DoNotSchedule {
+			continue
+		}
+		domain, ok := node.Labels[constraint.TopologyKey]
+		if !ok {
+			reasons = append(reasons, fmt.Sprintf("topology spread: node has no %s label", constraint.TopologyKey))
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(constraint.LabelSelector)
+		if err != nil {
+			continue
+		}
+
+		domainOf := make(map[string]string)
+		counts := make(map[string]int)
+		for i := range nodes {
+			candidate := &nodes[i]
+			value, ok := candidate.Labels[constraint.TopologyKey]
+			if !ok || len(checkNodeSelector(pod, candidate)) > 0 || len(checkNodeAffinity(pod, candidate)) > 0 {
+				continue
+			}
+			domainOf[candidate.Name] = value
+			counts[value] += 0
+		}
+		for i := range scheduled {
+			other := &scheduled[i]
+			if other.Namespace != pod.Namespace || other.UID == pod.UID || !selector.Matches(labels.Set(other.Labels)) {
+				continue
+			}
+			if value, ok := domainOf[other.Spec.NodeName]; ok {
+				counts[value]++
+			}
+		}
+
+		minCount := -1
+		for _, count := range counts {
+			if minCount < 0 || count < minCount {
+				minCount = count
+			}
+		}
+		if minCount < 0 {
+			minCount = 0
+		}
+		if skew := counts[domain] + 1 - minCount; skew > int(constraint.MaxSkew) {
+			reasons = append(reasons, fmt.Sprintf("topology spread on %s: %s would have %d matching pods, skew %d > maxSkew %d",
+				constraint.TopologyKey, domain, counts[domain]+1, skew, constraint.MaxSkew))
+		}
+	}
+	return reasons
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// containsString reports whether values contains s
+func containsString(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+	return false
+}
+
+// SchedulingSummary condenses node fits into one line, e.g.
+// "0/3 nodes fit: 2 insufficient cpu, 1 taint not tolerated"
+func SchedulingSummary(fits []NodeFit) string {
+	fitting := 0
+	causes := make(map[string]int)
+	for _, fit := range fits {
+		if fit.Fits() {
+			fitting++
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, reason := range fit.Reasons {
+			cause := reason
+			if i := strings.Index(reason, ":"); i > 0 {
+				cause = reason[:i]
+			}
+			if !seen[cause] {
+				seen[cause] = true
+				causes[cause]++
+			}
+		}
+	}
+
+	summary := fmt.Sprintf("%d/%d nodes fit", fitting, len(fits))
+	if len(causes) == 0 {
+		return summary
+	}
+	names := make([]string, 0, len(causes))
+	for cause := range causes {
+		names = append(names, cause)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if causes[names[i]] != causes[names[j]] {
+			return causes[names[i]] > causes[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	parts := make([]string, 0, len(names))
+	for _, cause := range names {
+		parts = append(parts, fmt.Sprintf("%d %s", causes[cause], cause))
+	}
+	return summary + ": " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,313 @@
+package resourcemanager
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	hostnameKey = "kubernetes.io/hostname"
+	zoneKey     = "topology.kubernetes.io/zone"
+)
+
+// testNode returns a node with 4 CPUs, 8Gi of memory and room for 110 pods
+func testNode(name string, nodeLabels map[string]string) corev1.Node {
+	node := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{hostnameKey: name}},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+	}
+	for key, value := range nodeLabels {
+		node.Labels[key] = value
+	}
+	return node
+}
+
+// testPod returns a pod in the default namespace with one container
+// requesting cpu, bound to node unless it is empty
+func testPod(name, node, cpu string, podLabels map[string]string) corev1.Pod {
+	container := corev1.Container{Name: "app"}
+	if cpu != "" {
+		container.Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name), Labels: podLabels},
+		Spec:       corev1.PodSpec{NodeName: node, Containers: []corev1.Container{container}},
+	}
+}
+
+// withContainer returns a container requesting cpu
+func withContainer(name, cpu string) corev1.Container {
+	return corev1.Container{
+		Name:      name,
+		Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}},
+	}
+}
+
+func TestExplainScheduling(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	web := map[string]string{"app": "web"}
+
+	tests := []struct {
+		name      string
+		pod       func(*corev1.Pod)
+		nodes     []corev1.Node
+		scheduled []corev1.Pod
+		want      map[string]string // node -> part of its first reason; "" when the pod fits
+	}{
+		{
+			name:  "fits",
+			nodes: []corev1.Node{testNode("node-a", nil)},
+			want:  map[string]string{"node-a": ""},
+		},
+		{
+			name: "cordoned",
+			nodes: []corev1.Node{
+				func() corev1.Node { n := testNode("node-a", nil); n.Spec.Unschedulable = true; return n }(),
+			},
+			want: map[string]string{"node-a": "node is cordoned"},
+		},
+		{
+			name: "cordon tolerated",
+			pod: func(p *corev1.Pod) {
+				p.Spec.Tolerations = []corev1.Toleration{{Key: corev1.TaintNodeUnschedulable, Operator: corev1.TolerationOpExists}}
+			},
+			nodes: []corev1.Node{
+				func() corev1.Node { n := testNode("node-a", nil); n.Spec.Unschedulable = true; return n }(),
+			},
+			want: map[string]string{"node-a": ""},
+		},
+		{
+			name:  "nodeSelector",
+			pod:   func(p *corev1.Pod) { p.Spec.NodeSelector = map[string]string{"disktype": "ssd"} },
+			nodes: []corev1.Node{testNode("node-a", nil), testNode("node-b", map[string]string{"disktype": "hdd"}), testNode("node-c", map[string]string{"disktype": "ssd"})},
+			want: map[string]string{
+				"node-a": "nodeSelector disktype=ssd: node has no disktype label",
+				"node-b": "nodeSelector disktype=ssd: node has disktype=hdd",
+				"node-c": "",
+			},
+		},
+		{
+			name: "node affinity",
+			pod: func(p *corev1.Pod) {
+				p.Spec.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{Key: zoneKey, Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}},
+					}}},
+				}}
+			},
+			nodes: []corev1.Node{testNode("node-a", map[string]string{zoneKey: "a"}), testNode("node-b", map[string]string{zoneKey: "b"})},
+			want: map[string]string{
+				"node-a": "",
+				"node-b": "node affinity: " + zoneKey + " In [a] not matched",
+			},
+		},
+		{
+			name: "taints",
+			pod: func(p *corev1.Pod) {
+				p.Spec.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "db", Effect: corev1.TaintEffectNoSchedule}}
+			},
+			nodes: []corev1.Node{
+				func() corev1.Node {
+					n := testNode("node-a", nil)
+					n.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}
+					return n
+				}(),
+				func() corev1.Node {
+					n := testNode("node-b", nil)
+					n.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule}}
+					return n
+				}(),
+				func() corev1.Node {
+					n := testNode("node-c", nil)
+					n.Spec.Taints = []corev1.Taint{{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule}}
+					return n
+				}(),
+			},
+			want: map[string]string{
+				"node-a": "taint not tolerated: dedicated=gpu:NoSchedule",
+				"node-b": "",
+				"node-c": "",
+			},
+		},
+		{
+			name:      "insufficient cpu",
+			pod:       func(p *corev1.Pod) { p.Spec.Containers = []corev1.Container{withContainer("app", "2")} },
+			nodes:     []corev1.Node{testNode("node-a", nil), testNode("node-b", nil)},
+			scheduled: []corev1.Pod{testPod("busy", "node-a", "3", nil)},
+			want: map[string]string{
+				"node-a": "insufficient cpu: requests 2, 1 free of 4",
+				"node-b": "",
+			},
+		},
+		{
+			name: "too many pods",
+			nodes: []corev1.Node{
+				func() corev1.Node {
+					n := testNode("node-a", nil)
+					n.Status.Allocatable[corev1.ResourcePods] = resource.MustParse("1")
+					return n
+				}(),
+			},
+			scheduled: []corev1.Pod{testPod("other", "node-a", "", nil)},
+			want:      map[string]string{"node-a": "too many pods: 1 of 1"},
+		},
+		{
+			name: "sidecar started before an init container adds to its peak",
+			pod: func(p *corev1.Pod) {
+				sidecar := withContainer("proxy", "1")
+				sidecar.RestartPolicy = &always
+				p.Spec.InitContainers = []corev1.Container{sidecar, withContainer("migrate", "3")}
+				p.Spec.Containers = []corev1.Container{withContainer("app", "1")}
+			},
+			nodes:     []corev1.Node{testNode("node-a", nil)},
+			scheduled: []corev1.Pod{testPod("busy", "node-a", "1", nil)},
+			want:      map[string]string{"node-a": "insufficient cpu: requests 4, 3 free of 4"},
+		},
+		{
+			name: "init container before a sidecar peaks alone",
+			pod: func(p *corev1.Pod) {
+				sidecar := withContainer("proxy", "1")
+				sidecar.RestartPolicy = &always
+				p.Spec.InitContainers = []corev1.Container{withContainer("migrate", "3"), sidecar}
+				p.Spec.Containers = []corev1.Container{withContainer("app", "1")}
+			},
+			nodes:     []corev1.Node{testNode("node-a", nil)},
+			scheduled: []corev1.Pod{testPod("busy", "node-a", "1", nil)},
+			want:      map[string]string{"node-a": ""},
+		},
+		{
+			name: "host port in use",
+			pod: func(p *corev1.Pod) {
+				p.Spec.Containers[0].Ports = []corev1.ContainerPort{{ContainerPort: 80, HostPort: 8080}}
+			},
+			nodes: []corev1.Node{testNode("node-a", nil), testNode("node-b", nil)},
+			scheduled: []corev1.Pod{func() corev1.Pod {
+				p := testPod("proxy", "node-a", "", nil)
+				p.Spec.Containers[0].Ports = []corev1.ContainerPort{{ContainerPort: 80, HostPort: 8080, Protocol: corev1.ProtocolTCP}}
+				return p
+			}()},
+			want: map[string]string{
+				"node-a": "host port TCP/8080 already in use",
+				"node-b": "",
+			},
+		},
+		{
+			name: "pod affinity",
+			pod: func(p *corev1.Pod) {
+				p.Spec.Affinity = &corev1.Affinity{PodAffinity: &corev1.PodAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "cache"}},
+						TopologyKey:   hostnameKey,
+					}},
+				}}
+			},
+			nodes:     []corev1.Node{testNode("node-a", nil), testNode("node-b", nil)},
+			scheduled: []corev1.Pod{testPod("cache-1", "node-b", "", map[string]string{"app": "cache"})},
+			want: map[string]string{
+				"node-a": "pod affinity: no pod matching app=cache in " + hostnameKey + "=node-a",
+				"node-b": "",
+			},
+		},
+		{
+			name: "pod anti-affinity",
+			pod: func(p *corev1.Pod) {
+				p.Labels = web
+				p.Spec.Affinity = &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: web},
+						TopologyKey:   hostnameKey,
+					}},
+				}}
+			},
+			nodes:     []corev1.Node{testNode("node-a", nil), testNode("node-b", nil)},
+			scheduled: []corev1.Pod{testPod("web-1", "node-a", "", web)},
+			want: map[string]string{
+				"node-a": "pod anti-affinity: default/web-1 already in " + hostnameKey + "=node-a",
+				"node-b": "",
+			},
+		},
+		{
+			name: "topology spread",
+			pod: func(p *corev1.Pod) {
+				p.Labels = web
+				p.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+					MaxSkew:           1,
+					TopologyKey:       zoneKey,
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+					LabelSelector:     &metav1.LabelSelector{MatchLabels: web},
+				}}
+			},
+			nodes: []corev1.Node{
+				testNode("node-a", map[string]string{zoneKey: "a"}),
+				testNode("node-b", map[string]string{zoneKey: "b"}),
+				testNode("node-c", nil),
+			},
+			scheduled: []corev1.Pod{testPod("web-1", "node-a", "", web)},
+			want: map[string]string{
+				"node-a": "topology spread on " + zoneKey + ": a would have 2 matching pods, skew 2 > maxSkew 1",
+				"node-b": "",
+				"node-c": "topology spread: node has no " + zoneKey + " label",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := testPod("pending", "", "100m", nil)
+			if tt.pod != nil {
+				tt.pod(&pod)
+			}
+
+			fits := ExplainScheduling(&pod, tt.nodes, tt.scheduled)
+			if len(fits) != len(tt.want) {
+				t.Fatalf("got %d node fits, want %d", len(fits), len(tt.want))
+			}
+			for i, fit := range fits {
+				// Nodes the pod fits on come first
+				if i > 0 && !fits[i-1].Fits() && fit.Fits() {
+					t.Errorf("%s fits but comes after %s", fit.Node, fits[i-1].Node)
+				}
+
+				want, ok := tt.want[fit.Node]
+				if !ok {
+					t.Errorf("unexpected node %s", fit.Node)
+					continue
+				}
+				if want == "" {
+					if !fit.Fits() {
+						t.Errorf("%s: reasons %q, want none", fit.Node, fit.Reasons)
+					}
+					continue
+				}
+				if fit.Fits() || !strings.Contains(fit.Reasons[0], want) {
+					t.Errorf("%s: reasons %q, want %q", fit.Node, fit.Reasons, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSchedulingSummary(t *testing.T) {
+	fits := []NodeFit{
+		{Node: "node-a"},
+		{Node: "node-b", Reasons: []string{"insufficient cpu: requests 2, 1 free of 4", "insufficient memory: requests 1Gi, 0 free of 8Gi"}},
+		{Node: "node-c", Reasons: []string{"insufficient cpu: requests 2, 0 free of 4"}},
+		{Node: "node-d", Reasons: []string{"node is cordoned"}},
+	}
+
+	want := "1/4 nodes fit: 2 insufficient cpu, 1 insufficient memory, 1 node is cordoned"
+	if got := SchedulingSummary(fits); got != want {
+		t.Errorf("SchedulingSummary = %q, want %q", got, want)
+	}
+}
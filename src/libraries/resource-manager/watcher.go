@@ -3,14 +3,21 @@ package resourcemanager
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	kubernetesclient "github.com/anindyar/kuber/src/libraries/kubernetes-client"
 	"github.com/anindyar/kuber/src/models"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 )
 
 // WatchEvent represents a resource change event
@@ -27,6 +34,7 @@ type ResourceWatcher struct {
 	client       *kubernetesclient.KubernetesClient
 	clientset    kubernetes.Interface
 	watchers     map[string]watch.Interface
+	states       map[string]*watchState
 	events       chan *WatchEvent
 	mu           sync.RWMutex
 	ctx          context.Context
@@ -34,6 +42,33 @@ type ResourceWatcher struct {
 	enabled      bool
 	callbacks    map[string][]func(*models.Resource, string)
 	errorHandler func(error)
+
+	// Restart backoff
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	// Statistics
+	startTime     time.Time
+	totalEvents   int64
+	droppedEvents int64
+	errorCount    int64
+	restartCount  int64
+	relistCount   int64
+	lastEventTime time.Time
+	lastDropTime  time.Time
+}
+
+// watchState tracks one watch so it can resume where it left off
+type watchState struct {
+	namespace       string
+	resourceType    string
+	ctx             context.Context
+	cancel          context.CancelFunc
+	resourceVersion string            // last version seen; "" means relist
+	known           map[string]string // namespace/name -> resourceVersion
+	kind            string
+	apiVersion      string
+	failures        int // consecutive failures, drives the backoff
 }
 
 // NewResourceWatcher creates a new resource watcher
@@ -48,6 +83,7 @@ func NewResourceWatcher(client *kubernetesclient.KubernetesClient, enabled bool)
 		client:     client,
 		clientset:  client.GetClientset(),
 		watchers:   make(map[string]watch.Interface),
+		states:     make(map[string]*watchState),
 		events:     make(chan *WatchEvent, 1000), // Buffered channel
 		ctx:        ctx,
 		cancelFunc: cancelFunc,
@@ -57,21 +93,25 @@ func NewResourceWatcher(client *kubernetesclient.KubernetesClient, enabled bool)
 			// Default error handler - could log to stderr or a logger
 			fmt.Printf("Resource watcher error: %v\n", err)
 		},
-	}
-
-	if enabled {
-		go rw.eventProcessor()
+		initialBackoff: time.Second,
+		maxBackoff:     2 * time.Minute,
+		startTime:      time.Now(),
 	}
 
 	return rw, nil
 }
 
-// WatchResources starts watching for resource changes
+// WatchResources starts watching for resource changes. The watch keeps
+// running, resuming after disconnects, until StopWatch or Stop is called.
 func (rw *ResourceWatcher) WatchResources(ctx context.Context, namespace, resourceType string, callback func(*models.Resource, string)) error {
 	if !rw.enabled {
 		return fmt.Errorf("watcher is disabled")
 	}
 
+	if _, _, err := newListWatch(rw.clientset, namespace, resourceType); err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+
 	rw.mu.Lock()
 	defer rw.mu.Unlock()
 
@@ -84,18 +124,38 @@ func (rw *ResourceWatcher) WatchResources(ctx context.Context, namespace, resour
 	rw.callbacks[watchKey] = append(rw.callbacks[watchKey], callback)
 
 	// Start watcher if not already watching
-	if _, exists := rw.watchers[watchKey]; !exists {
-		watcher, err := rw.createWatcher(ctx, namespace, resourceType)
-		if err != nil {
-			return fmt.Errorf("failed to create watcher: %w", err)
+	if _, exists := rw.states[watchKey]; !exists {
+		watchCtx, cancel := context.WithCancel(rw.ctx)
+		state := &watchState{
+			namespace:    namespace,
+			resourceType: resourceType,
+			ctx:          watchCtx,
+			cancel:       cancel,
 		}
-		rw.watchers[watchKey] = watcher
-		go rw.handleWatch(watchKey, watcher)
+		rw.states[watchKey] = state
+		go rw.runWatch(watchKey, state)
 	}
 
 	return nil
 }
 
+// StopWatch stops a single watch and drops its callbacks
+func (rw *ResourceWatcher) StopWatch(namespace, resourceType string) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	watchKey := fmt.Sprintf("%s:%s", namespace, resourceType)
+	if state, exists := rw.states[watchKey]; exists {
+		state.cancel()
+		delete(rw.states, watchKey)
+	}
+	if watcher, exists := rw.watchers[watchKey]; exists {
+		watcher.Stop()
+		delete(rw.watchers, watchKey)
+	}
+	delete(rw.callbacks, watchKey)
+}
+
 // StopWatching stops watching for resource changes
 func (rw *ResourceWatcher) Stop() {
 	rw.mu.Lock()
@@ -108,6 +168,7 @@ func (rw *ResourceWatcher) Stop() {
 		}
 		delete(rw.watchers, key)
 	}
+	rw.states = make(map[string]*watchState)
 
 	// Clear callbacks
 	rw.callbacks = make(map[string][]func(*models.Resource, string))
@@ -138,240 +199,331 @@ func (rw *ResourceWatcher) GetWatchedResources() []string {
 	return resources
 }
 
-// createWatcher creates a Kubernetes watcher for a specific resource type
-func (rw *ResourceWatcher) createWatcher(ctx context.Context, namespace, resourceType string) (watch.Interface, error) {
-	switch resourceType {
-	case "pods":
-		return rw.clientset.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{})
-	case "services":
-		return rw.clientset.CoreV1().Services(namespace).Watch(ctx, metav1.ListOptions{})
-	case "deployments":
-		return rw.clientset.AppsV1().Deployments(namespace).Watch(ctx, metav1.ListOptions{})
-	case "configmaps":
-		return rw.clientset.CoreV1().ConfigMaps(namespace).Watch(ctx, metav1.ListOptions{})
-	case "secrets":
-		return rw.clientset.CoreV1().Secrets(namespace).Watch(ctx, metav1.ListOptions{})
-	case "namespaces":
-		return rw.clientset.CoreV1().Namespaces().Watch(ctx, metav1.ListOptions{})
-	default:
-		return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
+// runWatch keeps one watch alive until it is stopped. After a disconnect it
+// resumes from the last seen resourceVersion; when that version has expired
+// (410 Gone) it relists and emits the differences.
+func (rw *ResourceWatcher) runWatch(watchKey string, state *watchState) {
+	lw, _, err := newListWatch(rw.clientset, state.namespace, state.resourceType)
+	if err != nil {
+		rw.reportError(fmt.Errorf("failed to create watcher for %s: %w", watchKey, err))
+		return
 	}
-}
 
-// handleWatch processes watch events for a specific resource
-func (rw *ResourceWatcher) handleWatch(watchKey string, watcher watch.Interface) {
-	defer func() {
+	for state.ctx.Err() == nil {
+		if state.resourceVersion == "" {
+			if err := rw.relist(watchKey, state, lw); err != nil {
+				rw.reportError(fmt.Errorf("failed to list %s: %w", watchKey, err))
+				rw.backoff(state)
+				continue
+			}
+		}
+
+		watcher, err := lw.WatchWithContext(state.ctx, metav1.ListOptions{
+			ResourceVersion:     state.resourceVersion,
+			AllowWatchBookmarks: true,
+		})
+		if err != nil {
+			if isExpired(err) {
+				rw.markExpired(state)
+			}
+			rw.reportError(fmt.Errorf("failed to restart watcher for %s: %w", watchKey, err))
+			rw.backoff(state)
+			continue
+		}
+
 		rw.mu.Lock()
-		delete(rw.watchers, watchKey)
+		if state.ctx.Err() != nil {
+			rw.mu.Unlock()
+			watcher.Stop()
+			return
+		}
+		rw.watchers[watchKey] = watcher
+		rw.mu.Unlock()
+
+		received := rw.handleWatch(watchKey, state, watcher)
+
+		rw.mu.Lock()
+		if rw.watchers[watchKey] == watcher {
+			delete(rw.watchers, watchKey)
+		}
 		rw.mu.Unlock()
-	}()
+		watcher.Stop()
+
+		if state.ctx.Err() != nil {
+			return
+		}
+
+		atomic.AddInt64(&rw.restartCount, 1)
+		if !received {
+			// Closed or failed without delivering anything; don't spin
+			rw.backoff(state)
+		}
+	}
+}
 
+// handleWatch processes watch events until the stream ends. It reports
+// whether any events were received.
+func (rw *ResourceWatcher) handleWatch(watchKey string, state *watchState, watcher watch.Interface) bool {
+	received := false
 	for {
 		select {
-		case <-rw.ctx.Done():
-			return
+		case <-state.ctx.Done():
+			return received
 		case event, ok := <-watcher.ResultChan():
 			if !ok {
-				// Channel closed, restart watcher
-				rw.restartWatcher(watchKey)
-				return
+				return received
 			}
-			rw.processWatchEvent(watchKey, event)
+
+			if event.Type == watch.Error {
+				err := apierrors.FromObject(event.Object)
+				if isExpired(err) {
+					rw.markExpired(state)
+				}
+				rw.reportError(fmt.Errorf("watch %s failed: %w", watchKey, err))
+				return false
+			}
+
+			received = true
+			rw.mu.Lock()
+			state.failures = 0
+			rw.mu.Unlock()
+
+			if accessor, err := meta.Accessor(event.Object); err == nil {
+				state.resourceVersion = accessor.GetResourceVersion()
+			}
+			if event.Type == watch.Bookmark {
+				continue
+			}
+
+			rw.processWatchEvent(watchKey, state, event)
 		}
 	}
 }
 
-// processWatchEvent processes a single watch event
-func (rw *ResourceWatcher) processWatchEvent(watchKey string, event watch.Event) {
-	if event.Object == nil {
-		return
+// relist lists the watched resources, emits events for anything that changed
+// since the last known state and records the version to watch from
+func (rw *ResourceWatcher) relist(watchKey string, state *watchState, lw *cache.ListWatch) error {
+	list, err := lw.ListWithContext(state.ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
 	}
 
-	// Convert Kubernetes event to our internal event
-	watchEvent, err := rw.convertToWatchEvent(watchKey, event)
+	listMeta, err := meta.ListAccessor(list)
 	if err != nil {
-		if rw.errorHandler != nil {
-			rw.errorHandler(fmt.Errorf("failed to convert watch event: %w", err))
-		}
-		return
+		return fmt.Errorf("failed to read list metadata: %w", err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return fmt.Errorf("failed to extract list items: %w", err)
 	}
 
-	// Send to event channel
-	select {
-	case rw.events <- watchEvent:
-	default:
-		// Channel full, skip event (or could implement overflow handling)
+	// The first list only establishes a baseline; callers list on their own
+	initial := state.known == nil
+	previous := state.known
+	state.known = make(map[string]string, len(items))
+
+	for _, item := range items {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			continue
+		}
+		key := accessor.GetNamespace() + "/" + accessor.GetName()
+		state.known[key] = accessor.GetResourceVersion()
+
+		if initial {
+			continue
+		}
+
+		oldVersion, existed := previous[key]
+		if existed && oldVersion == accessor.GetResourceVersion() {
+			continue
+		}
+		eventType := watch.Added
+		if existed {
+			eventType = watch.Modified
+		}
+		rw.processWatchEvent(watchKey, state, watch.Event{Type: eventType, Object: item})
 	}
 
-	// Call registered callbacks
-	rw.mu.RLock()
-	callbacks := rw.callbacks[watchKey]
-	rw.mu.RUnlock()
+	if initial {
+		if len(items) > 0 {
+			if resource, err := rw.convertKubernetesObject(items[0], state.resourceType); err == nil {
+				state.kind, state.apiVersion = resource.Kind, resource.APIVersion
+			}
+		}
+	} else {
+		atomic.AddInt64(&rw.relistCount, 1)
 
-	for _, callback := range callbacks {
-		if callback != nil && watchEvent.Resource != nil {
-			go callback(watchEvent.Resource, watchEvent.Type)
+		// Anything that disappeared while we were not watching was deleted
+		for key := range previous {
+			if _, exists := state.known[key]; !exists {
+				rw.emitDeleted(watchKey, state, key)
+			}
 		}
 	}
+
+	state.resourceVersion = listMeta.GetResourceVersion()
+	return nil
 }
 
-// convertToWatchEvent converts a Kubernetes watch event to our internal format
-func (rw *ResourceWatcher) convertToWatchEvent(watchKey string, event watch.Event) (*WatchEvent, error) {
-	parts := splitWatchKey(watchKey)
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid watch key format: %s", watchKey)
+// emitDeleted sends a DELETED event for an object only known by its key
+func (rw *ResourceWatcher) emitDeleted(watchKey string, state *watchState, key string) {
+	if state.kind == "" {
+		return
 	}
 
-	namespace := parts[0]
-	resourceType := parts[1]
+	namespace, name := key, ""
+	if slash := strings.Index(key, "/"); slash != -1 {
+		namespace, name = key[:slash], key[slash+1:]
+	}
 
-	// Convert the Kubernetes object to our resource model
-	resource, err := rw.convertKubernetesObject(event.Object, resourceType)
+	resource, err := models.NewResource(state.kind, state.apiVersion, models.Metadata{
+		Name:        name,
+		Namespace:   namespace,
+		Labels:      make(map[string]string),
+		Annotations: make(map[string]string),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert object: %w", err)
+		return
 	}
 
-	return &WatchEvent{
-		Type:         string(event.Type),
-		ResourceType: resourceType,
-		Namespace:    namespace,
+	rw.dispatch(watchKey, &WatchEvent{
+		Type:         string(watch.Deleted),
+		ResourceType: state.resourceType,
+		Namespace:    state.namespace,
 		Resource:     resource,
 		Timestamp:    time.Now(),
-	}, nil
+	})
 }
 
-// convertKubernetesObject converts a Kubernetes object to our resource model
-func (rw *ResourceWatcher) convertKubernetesObject(obj interface{}, resourceType string) (*models.Resource, error) {
-	// This is a simplified conversion - in practice, we'd need proper type assertions
-	// and conversion logic for each resource type
-
-	// For now, return a basic resource - this should be implemented properly
-	// based on the specific Kubernetes object types
-
-	switch resourceType {
-	case "pods":
-		// Convert Pod object to Resource
-		// pod, ok := obj.(*corev1.Pod)
-		// if !ok {
-		//     return nil, fmt.Errorf("expected Pod object")
-		// }
-		// return convertKubernetesPod(pod)
-
-		// Placeholder implementation
-		metadata := models.Metadata{
-			Name:      "watch-event-pod",
-			Namespace: "default",
-		}
-		return models.NewResource("Pod", "v1", metadata)
-
-	case "services":
-		// Similar conversion for services
-		metadata := models.Metadata{
-			Name:      "watch-event-service",
-			Namespace: "default",
-		}
-		return models.NewResource("Service", "v1", metadata)
-
-	default:
-		// Generic resource
-		metadata := models.Metadata{
-			Name:      "watch-event-resource",
-			Namespace: "default",
-		}
-		return models.NewResource(resourceType, "v1", metadata)
+// processWatchEvent processes a single watch event
+func (rw *ResourceWatcher) processWatchEvent(watchKey string, state *watchState, event watch.Event) {
+	if event.Object == nil {
+		return
 	}
-}
 
-// restartWatcher restarts a watcher after failure
-func (rw *ResourceWatcher) restartWatcher(watchKey string) {
-	parts := splitWatchKey(watchKey)
-	if len(parts) != 2 {
-		if rw.errorHandler != nil {
-			rw.errorHandler(fmt.Errorf("invalid watch key format: %s", watchKey))
-		}
+	// Convert the Kubernetes object to our resource model
+	resource, err := rw.convertKubernetesObject(event.Object, state.resourceType)
+	if err != nil {
+		rw.reportError(fmt.Errorf("failed to convert watch event: %w", err))
 		return
 	}
 
-	namespace := parts[0]
-	resourceType := parts[1]
+	key := resource.Metadata.Namespace + "/" + resource.Metadata.Name
+	if state.known == nil {
+		state.known = make(map[string]string)
+	}
+	if event.Type == watch.Deleted {
+		delete(state.known, key)
+	} else {
+		state.known[key] = resource.Metadata.ResourceVersion
+	}
+	state.kind, state.apiVersion = resource.Kind, resource.APIVersion
 
-	// Wait a bit before restarting to avoid tight loops
-	time.Sleep(5 * time.Second)
+	rw.dispatch(watchKey, &WatchEvent{
+		Type:         string(event.Type),
+		ResourceType: state.resourceType,
+		Namespace:    state.namespace,
+		Resource:     resource,
+		Timestamp:    time.Now(),
+	})
+}
 
-	// Restart watcher
-	ctx, cancel := context.WithTimeout(rw.ctx, 30*time.Second)
-	defer cancel()
+// dispatch delivers an event to the event channel and the registered callbacks
+func (rw *ResourceWatcher) dispatch(watchKey string, watchEvent *WatchEvent) {
+	atomic.AddInt64(&rw.totalEvents, 1)
 
-	watcher, err := rw.createWatcher(ctx, namespace, resourceType)
-	if err != nil {
-		if rw.errorHandler != nil {
-			rw.errorHandler(fmt.Errorf("failed to restart watcher for %s: %w", watchKey, err))
-		}
-		return
+	// Send to event channel
+	select {
+	case rw.events <- watchEvent:
+	default:
+		// Channel full; count it so HealthCheck can report degraded updates
+		atomic.AddInt64(&rw.droppedEvents, 1)
+		rw.mu.Lock()
+		rw.lastDropTime = time.Now()
+		rw.mu.Unlock()
 	}
 
+	// Call registered callbacks
 	rw.mu.Lock()
-	rw.watchers[watchKey] = watcher
+	rw.lastEventTime = watchEvent.Timestamp
+	callbacks := rw.callbacks[watchKey]
 	rw.mu.Unlock()
 
-	go rw.handleWatch(watchKey, watcher)
+	// Callbacks run in order so consumers see events as the server sent them
+	for _, callback := range callbacks {
+		if callback != nil && watchEvent.Resource != nil {
+			callback(watchEvent.Resource, watchEvent.Type)
+		}
+	}
 }
 
-// eventProcessor processes events from the event channel
-func (rw *ResourceWatcher) eventProcessor() {
-	for {
-		select {
-		case <-rw.ctx.Done():
-			return
-		case event := <-rw.events:
-			if event != nil {
-				// Process event (could add additional processing logic here)
-				rw.processEvent(event)
-			}
-		}
+// convertKubernetesObject converts a Kubernetes object to our resource model
+func (rw *ResourceWatcher) convertKubernetesObject(obj runtime.Object, resourceType string) (*models.Resource, error) {
+	resource, err := kubernetesclient.ConvertObject(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s: %w", resourceType, err)
 	}
+	return resource, nil
 }
 
-// processEvent processes a watch event (placeholder for additional logic)
-func (rw *ResourceWatcher) processEvent(event *WatchEvent) {
-	// This is where we could add additional event processing logic
-	// such as logging, metrics collection, or triggering other actions
+// markExpired forgets the resourceVersion so the next attempt relists
+func (rw *ResourceWatcher) markExpired(state *watchState) {
+	state.resourceVersion = ""
 }
 
-// splitWatchKey splits a watch key into namespace and resource type
-func splitWatchKey(watchKey string) []string {
-	// Simple split on colon
-	parts := []string{}
-	for _, part := range []string{watchKey} {
-		if colon := findColon(part); colon != -1 {
-			parts = append(parts, part[:colon], part[colon+1:])
-			break
-		}
+// backoff waits before the next attempt, doubling the delay with each
+// consecutive failure and adding jitter so many watches don't retry in step
+func (rw *ResourceWatcher) backoff(state *watchState) {
+	rw.mu.Lock()
+	state.failures++
+	failures := state.failures
+	rw.mu.Unlock()
+
+	delay := rw.initialBackoff
+	for i := 1; i < failures && delay < rw.maxBackoff; i++ {
+		delay *= 2
 	}
-	if len(parts) == 0 {
-		parts = []string{watchKey}
+	if delay > rw.maxBackoff {
+		delay = rw.maxBackoff
+	}
+	// +/- 20% jitter
+	delay += time.Duration((rand.Float64()*0.4 - 0.2) * float64(delay))
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-state.ctx.Done():
+	case <-timer.C:
 	}
-	return parts
 }
 
-// findColon finds the first colon in a string
-func findColon(s string) int {
-	for i, char := range s {
-		if char == ':' {
-			return i
-		}
+// reportError counts an error and passes it to the error handler
+func (rw *ResourceWatcher) reportError(err error) {
+	atomic.AddInt64(&rw.errorCount, 1)
+	if rw.errorHandler != nil {
+		rw.errorHandler(err)
 	}
-	return -1
+}
+
+// isExpired reports whether the server no longer has the requested resourceVersion
+func isExpired(err error) bool {
+	return apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
 }
 
 // WatcherStats provides statistics about the watcher
 type WatcherStats struct {
-	ActiveWatchers  int
-	TotalEvents     int64
-	EventsPerSecond float64
-	LastEventTime   time.Time
-	ErrorCount      int64
-	RestartCount    int64
+	ActiveWatchers    int
+	ReconnectingWatch int
+	TotalEvents       int64
+	DroppedEvents     int64
+	EventsPerSecond   float64
+	LastEventTime     time.Time
+	LastDropTime      time.Time
+	ErrorCount        int64
+	RestartCount      int64
+	RelistCount       int64
 }
 
 // GetStats returns watcher statistics
@@ -379,23 +531,49 @@ func (rw *ResourceWatcher) GetStats() WatcherStats {
 	rw.mu.RLock()
 	defer rw.mu.RUnlock()
 
+	reconnecting := 0
+	for _, state := range rw.states {
+		if state.failures > 0 {
+			reconnecting++
+		}
+	}
+
+	totalEvents := atomic.LoadInt64(&rw.totalEvents)
+	eventsPerSecond := 0.0
+	if elapsed := time.Since(rw.startTime).Seconds(); elapsed > 0 {
+		eventsPerSecond = float64(totalEvents) / elapsed
+	}
+
 	return WatcherStats{
-		ActiveWatchers: len(rw.watchers),
-		// Additional stats would need counters in the struct
+		ActiveWatchers:    len(rw.watchers),
+		ReconnectingWatch: reconnecting,
+		TotalEvents:       totalEvents,
+		DroppedEvents:     atomic.LoadInt64(&rw.droppedEvents),
+		EventsPerSecond:   eventsPerSecond,
+		LastEventTime:     rw.lastEventTime,
+		LastDropTime:      rw.lastDropTime,
+		ErrorCount:        atomic.LoadInt64(&rw.errorCount),
+		RestartCount:      atomic.LoadInt64(&rw.restartCount),
+		RelistCount:       atomic.LoadInt64(&rw.relistCount),
 	}
 }
 
-// HealthCheck checks if the watcher is healthy
+// HealthCheck reports whether live updates can be trusted: it fails while a
+// watch is reconnecting or when events were dropped in the last minute
 func (rw *ResourceWatcher) HealthCheck() error {
 	if !rw.enabled {
 		return fmt.Errorf("watcher is disabled")
 	}
 
-	rw.mu.RLock()
-	defer rw.mu.RUnlock()
+	stats := rw.GetStats()
+
+	if stats.ReconnectingWatch > 0 {
+		return fmt.Errorf("%d watch(es) reconnecting (%d restarts, %d relists)",
+			stats.ReconnectingWatch, stats.RestartCount, stats.RelistCount)
+	}
 
-	if len(rw.watchers) == 0 {
-		return fmt.Errorf("no active watchers")
+	if !stats.LastDropTime.IsZero() && time.Since(stats.LastDropTime) < time.Minute {
+		return fmt.Errorf("%d watch event(s) dropped", stats.DroppedEvents)
 	}
 
 	return nil
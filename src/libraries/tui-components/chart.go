@@ -0,0 +1,388 @@
+package tuicomponents
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sparkBlocks are the eighth-height blocks sparklines are drawn with
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a one-line chart width cells wide, scaled
+// from 0 to the largest value. Longer series are averaged down to the
+// width; shorter ones are right-aligned, so the newest value is always in
+// the last cell.
+func Sparkline(values []float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	values = resample(values, width)
+
+	max := 0.0
+	for _, value := range values {
+		max = math.Max(max, value)
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", width-len(values)))
+	for _, value := range values {
+		level := 0
+		if max > 0 {
+			level = int(value/max*float64(len(sparkBlocks)-1) + 0.5)
+		}
+		level = int(math.Max(0, math.Min(float64(level), float64(len(sparkBlocks)-1))))
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// resample averages values down to at most n
+func resample(values []float64, n int) []float64 {
+	if len(values) <= n {
+		return values
+	}
+	resampled := make([]float64, n)
+	for i := range resampled {
+		from, to := i*len(values)/n, (i+1)*len(values)/n
+		sum := 0.0
+		for _, value := range values[from:to] {
+			sum += value
+		}
+		resampled[i] = sum / float64(to-from)
+	}
+	return resampled
+}
+
+// ChartPoint is one value of a chart series
+type ChartPoint struct {
+	Time  time.Time
+	Value float64
+}
+
+// ChartSeries is one line of a chart
+type ChartSeries struct {
+	Name   string
+	Points []ChartPoint // oldest first
+	Color  lipgloss.Color
+}
+
+// chartColors are the colors of series without one
+var chartColors = []lipgloss.Color{"39", "214", "76", "205", "141"}
+
+// brailleDots are the bits of the dots of a braille cell, two columns of
+// four rows
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// ChartComponent renders time series as a braille line chart, with a value
+// axis from 0, a time axis and a legend. Each cell holds 2x4 dots.
+type ChartComponent struct {
+	BaseComponent
+	title       string
+	series      []ChartSeries
+	start       time.Time
+	end         time.Time
+	formatValue func(float64) string
+	titleStyle  lipgloss.Style
+	axisStyle   lipgloss.Style
+}
+
+// NewChartComponent creates a new chart component
+func NewChartComponent(width, height int) *ChartComponent {
+	return &ChartComponent{
+		BaseComponent: NewBaseComponent(width, height),
+		formatValue: func(value float64) string {
+			return fmt.Sprintf("%.1f", value)
+		},
+		titleStyle: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("39")).
+			Bold(true),
+		axisStyle: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")),
+	}
+}
+
+// Update handles tea messages for the chart; it takes no input
+func (cc *ChartComponent) Update(msg tea.Msg) (Component, tea.Cmd) {
+	return cc, nil
+}
+
+// Type returns the component type
+func (cc *ChartComponent) Type() ComponentType {
+	return ComponentTypeChart
+}
+
+// SetTitle sets the line shown above the chart
+func (cc *ChartComponent) SetTitle(title string) {
+	cc.title = title
+}
+
+// SetSeries sets the lines of the chart
+func (cc *ChartComponent) SetSeries(series []ChartSeries) {
+	cc.series = series
+}
+
+// SetTimeRange sets the times at the left and right edges; zero times
+// fit the chart to its points
+func (cc *ChartComponent) SetTimeRange(start, end time.Time) {
+	cc.start, cc.end = start, end
+}
+
+// SetValueFormatter sets how values on the value axis are rendered
+func (cc *ChartComponent) SetValueFormatter(format func(float64) string) {
+	cc.formatValue = format
+}
+
+// View renders the chart component
+func (cc *ChartComponent) View() string {
+	var lines []string
+	if cc.title != "" {
+		lines = append(lines, cc.titleStyle.Render(cc.title))
+	}
+
+	// Below the plot: the time axis, its labels and the legend
+	rows := cc.height - len(lines) - 3
+	if rows < 2 {
+		rows = 2
+	}
+
+	start, end := cc.timeRange()
+	top := niceCeil(cc.maxValue())
+	labels := []string{cc.formatValue(top), cc.formatValue(top / 2), cc.formatValue(0)}
+	labelWidth := 0
+	for _, label := range labels {
+		labelWidth = max(labelWidth, lipgloss.Width(label))
+	}
+	plotWidth := cc.width - labelWidth - 1
+	if plotWidth < 2 {
+		plotWidth = 2
+	}
+
+	cells, colors := cc.plot(plotWidth, rows, start, end, top)
+	for row := 0; row < rows; row++ {
+		label, axis := "", "│"
+		switch row {
+		case 0:
+			label, axis = labels[0], "┤"
+		case rows / 2:
+			label, axis = labels[1], "┤"
+		case rows - 1:
+			label, axis = labels[2], "┤"
+		}
+		label = strings.Repeat(" ", labelWidth-lipgloss.Width(label)) + label
+		lines = append(lines, cc.axisStyle.Render(label+axis)+cc.renderRow(cells[row], colors[row]))
+	}
+	lines = append(lines, cc.axisStyle.Render(strings.Repeat(" ", labelWidth)+"└"+strings.Repeat("─", plotWidth)))
+	lines = append(lines, strings.Repeat(" ", labelWidth+1)+cc.timeLabels(plotWidth, start, end))
+	lines = append(lines, cc.legend())
+
+	return strings.Join(lines, "\n")
+}
+
+// timeRange returns the set time range, or that of the points
+func (cc *ChartComponent) timeRange() (time.Time, time.Time) {
+	start, end := cc.start, cc.end
+	for _, series := range cc.series {
+		for _, point := range series.Points {
+			if cc.start.IsZero() && (start.IsZero() || point.Time.Before(start)) {
+				start = point.Time
+			}
+			if cc.end.IsZero() && (end.IsZero() || point.Time.After(end)) {
+				end = point.Time
+			}
+		}
+	}
+	return start, end
+}
+
+// maxValue returns the largest value of any series
+func (cc *ChartComponent) maxValue() float64 {
+	max := 0.0
+	for _, series := range cc.series {
+		for _, point := range series.Points {
+			max = math.Max(max, point.Value)
+		}
+	}
+	return max
+}
+
+// plot draws the series into braille cells, remembering which series
+// drew each cell last
+func (cc *ChartComponent) plot(width, rows int, start, end time.Time, top float64) ([][]rune, [][]int) {
+	cells := make([][]rune, rows)
+	colors := make([][]int, rows)
+	for row := range cells {
+		cells[row] = make([]rune, width)
+		colors[row] = make([]int, width)
+	}
+
+	dotsWide, dotsHigh := width*2, rows*4
+	span := end.Sub(start)
+	toDots := func(point ChartPoint) (int, int) {
+		x := 0
+		if span > 0 {
+			x = int(math.Round(float64(point.Time.Sub(start)) / float64(span) * float64(dotsWide-1)))
+		}
+		y := dotsHigh - 1 - int(math.Round(point.Value/top*float64(dotsHigh-1)))
+		return x, y
+	}
+	setDot := func(x, y, series int) {
+		if x < 0 || x >= dotsWide || y < 0 || y >= dotsHigh {
+			return
+		}
+		cells[y/4][x/2] |= brailleDots[x%2][y%4]
+		colors[y/4][x/2] = series + 1
+	}
+
+	for i, series := range cc.series {
+		gap := maxGap(series.Points)
+		for j, point := range series.Points {
+			x, y := toDots(point)
+			if j == 0 || point.Time.Sub(series.Points[j-1].Time) > gap {
+				setDot(x, y, i)
+				continue
+			}
+			prevX, prevY := toDots(series.Points[j-1])
+			drawLine(prevX, prevY, x, y, func(x, y int) { setDot(x, y, i) })
+		}
+	}
+	return cells, colors
+}
+
+// maxGap is the longest time between points still joined by a line:
+// three times the usual spacing, so missing samples leave a gap
+func maxGap(points []ChartPoint) time.Duration {
+	if len(points) < 2 {
+		return 0
+	}
+	spacings := make([]time.Duration, 0, len(points)-1)
+	for i := 1; i < len(points); i++ {
+		spacings = append(spacings, points[i].Time.Sub(points[i-1].Time))
+	}
+	sort.Slice(spacings, func(i, j int) bool { return spacings[i] < spacings[j] })
+	return 3 * spacings[len(spacings)/2]
+}
+
+// drawLine calls set for each dot on the line between two dots
+func drawLine(x0, y0, x1, y1 int, set func(x, y int)) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		set(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		if e2 := 2 * err; e2 >= dy {
+			err += dy
+			x0 += sx
+		} else {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// abs returns the absolute value of an int
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// renderRow renders a row of braille cells, coloring runs of cells drawn
+// by the same series at once
+func (cc *ChartComponent) renderRow(cells []rune, colors []int) string {
+	var b strings.Builder
+	for i := 0; i < len(cells); {
+		j := i
+		var run strings.Builder
+		for ; j < len(cells) && colors[j] == colors[i]; j++ {
+			if cells[j] == 0 {
+				run.WriteRune(' ')
+			} else {
+				run.WriteRune(0x2800 + cells[j])
+			}
+		}
+		if colors[i] == 0 {
+			b.WriteString(run.String())
+		} else {
+			b.WriteString(lipgloss.NewStyle().Foreground(cc.seriesColor(colors[i] - 1)).Render(run.String()))
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// seriesColor returns the color of a series
+func (cc *ChartComponent) seriesColor(index int) lipgloss.Color {
+	if color := cc.series[index].Color; color != "" {
+		return color
+	}
+	return chartColors[index%len(chartColors)]
+}
+
+// timeLabels renders the start, middle and end times under the time axis,
+// with dates once the range spans more than a day
+func (cc *ChartComponent) timeLabels(width int, start, end time.Time) string {
+	if start.IsZero() || end.IsZero() {
+		return ""
+	}
+	layout := "15:04"
+	if end.Sub(start) > 24*time.Hour {
+		layout = "Jan 02"
+	}
+	left, middle, right := start.Format(layout), start.Add(end.Sub(start)/2).Format(layout), end.Format(layout)
+
+	line := []rune(strings.Repeat(" ", width))
+	place := func(label string, at int) {
+		at = max(0, min(at, width-len(label)))
+		copy(line[at:], []rune(label))
+	}
+	place(left, 0)
+	if width > 3*len(middle)+4 {
+		place(middle, width/2-len(middle)/2)
+	}
+	place(right, width-len(right))
+	return cc.axisStyle.Render(string(line))
+}
+
+// legend renders the name of each series in its color
+func (cc *ChartComponent) legend() string {
+	var entries []string
+	for i, series := range cc.series {
+		marker := lipgloss.NewStyle().Foreground(cc.seriesColor(i)).Render("━━")
+		entries = append(entries, marker+" "+series.Name)
+	}
+	return strings.Join(entries, "  ")
+}
+
+// niceCeil rounds a chart's largest value up to 1, 2, 2.5 or 5 times a
+// power of ten, so the axis labels are round
+func niceCeil(value float64) float64 {
+	if value <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(value)))
+	for _, step := range []float64{1, 2, 2.5, 5, 10} {
+		if value <= step*magnitude {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
@@ -15,6 +15,7 @@ const (
 	ComponentTypeTextInput
 	ComponentTypeStatusBar
 	ComponentTypeBreadcrumb
+	ComponentTypeChart
 )
 
 // Component interface for all UI components
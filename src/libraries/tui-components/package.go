@@ -10,6 +10,9 @@
 // - TextInputComponent: Text input fields with validation and multiline support
 // - StatusBarComponent: Status bars for displaying system information
 // - BreadcrumbComponent: Navigation breadcrumbs with hierarchical display
+// - ChartComponent: Braille line charts of time series with axes and a legend
+//
+// Sparkline renders a series as a one-line chart, e.g. for a table cell.
 //
 // All components implement the Component interface and follow consistent
 // patterns for styling, focus management, and event handling.
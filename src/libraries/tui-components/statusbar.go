@@ -443,3 +443,34 @@ func (sbc *StatusBarComponent) SetConnectionStatus(status string) {
 		Visible: true,
 	})
 }
+
+// SetLiveStatus shows whether live updates are flowing. An empty status
+// hides the indicator.
+func (sbc *StatusBarComponent) SetLiveStatus(status string, degraded bool) {
+	if status == "" {
+		sbc.RemoveItem("live")
+		return
+	}
+
+	style := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("46")) // Green
+	if degraded {
+		style = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("214")) // Orange
+	}
+
+	for i := range sbc.rightItems {
+		if sbc.rightItems[i].Key == "live" {
+			sbc.rightItems[i].Value = status
+			sbc.rightItems[i].Style = style
+			return
+		}
+	}
+
+	sbc.rightItems = append([]StatusItem{{
+		Key:     "live",
+		Value:   status,
+		Style:   style,
+		Visible: true,
+	}}, sbc.rightItems...)
+}
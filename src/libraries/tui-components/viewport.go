@@ -218,6 +218,16 @@ func (vc *ViewportComponent) ScrollDown() {
 	vc.viewport.LineDown(1)
 }
 
+// YOffset returns the index of the first visible line
+func (vc *ViewportComponent) YOffset() int {
+	return vc.viewport.YOffset
+}
+
+// SetYOffset scrolls so the given line is the first visible one
+func (vc *ViewportComponent) SetYOffset(offset int) {
+	vc.viewport.SetYOffset(offset)
+}
+
 // GetScrollPercent returns the current scroll position as percentage
 func (vc *ViewportComponent) GetScrollPercent() float64 {
 	return vc.viewport.ScrollPercent()
@@ -0,0 +1,96 @@
+package models
+
+import "strings"
+
+// EventFilter selects events by type, reason, namespace, object kind and
+// free text, e.g. `type=warning reason=backoff ns=default oom`. All terms
+// must match.
+type EventFilter struct {
+	Type      string // prefix of the type, e.g. "warn"
+	Reason    string // substring of the reason
+	Namespace string
+	Kind      string
+	Text      []string // each must appear in the reason, object or message
+}
+
+// ParseEventFilter parses a filter query. Terms are separated by spaces and
+// are either key=value, with key one of type, reason, ns (or namespace) and
+// kind, or plain text.
+func ParseEventFilter(query string) *EventFilter {
+	filter := &EventFilter{}
+
+	for _, term := range strings.Fields(query) {
+		key, value, ok := strings.Cut(term, "=")
+		if !ok {
+			filter.Text = append(filter.Text, strings.ToLower(term))
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "type":
+			filter.Type = strings.ToLower(value)
+		case "reason":
+			filter.Reason = strings.ToLower(value)
+		case "ns", "namespace":
+			filter.Namespace = value
+		case "kind":
+			filter.Kind = strings.ToLower(value)
+		default:
+			filter.Text = append(filter.Text, strings.ToLower(term))
+		}
+	}
+
+	return filter
+}
+
+// IsEmpty reports whether the filter matches every event
+func (f *EventFilter) IsEmpty() bool {
+	return f == nil || (f.Type == "" && f.Reason == "" && f.Namespace == "" && f.Kind == "" && len(f.Text) == 0)
+}
+
+// Matches reports whether an event satisfies every term of the filter
+func (f *EventFilter) Matches(event Event) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.Type != "" && !strings.HasPrefix(strings.ToLower(event.Type), f.Type) {
+		return false
+	}
+	if f.Reason != "" && !strings.Contains(strings.ToLower(event.Reason), f.Reason) {
+		return false
+	}
+	if f.Namespace != "" && event.Namespace != f.Namespace {
+		return false
+	}
+	if f.Kind != "" && strings.ToLower(event.Object.Kind) != f.Kind {
+		return false
+	}
+
+	if len(f.Text) > 0 {
+		haystack := strings.ToLower(event.Reason + " " + event.Object.String() + " " + event.Message)
+		for _, text := range f.Text {
+			if !strings.Contains(haystack, text) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// String returns the filter as a query that parses back to it
+func (f *EventFilter) String() string {
+	if f == nil {
+		return ""
+	}
+
+	var terms []string
+	for _, term := range [][2]string{{"type", f.Type}, {"reason", f.Reason}, {"ns", f.Namespace}, {"kind", f.Kind}} {
+		if term[1] != "" {
+			terms = append(terms, term[0]+"="+term[1])
+		}
+	}
+	terms = append(terms, f.Text...)
+	return strings.Join(terms, " ")
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// EventList keeps the latest version of each event seen on a watch and
+// collapses repeats into one line. It holds at most capacity events,
+// dropping the oldest first.
+type EventList struct {
+	events   map[string]Event // by event UID
+	capacity int
+	mu       sync.RWMutex
+}
+
+// NewEventList creates an event list holding at most capacity events
+func NewEventList(capacity int) *EventList {
+	if capacity <= 0 {
+		capacity = 1
+	}
+
+	return &EventList{
+		events:   make(map[string]Event),
+		capacity: capacity,
+	}
+}
+
+// Upsert adds an event or replaces an older version of it
+func (l *EventList) Upsert(events ...Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, event := range events {
+		key := event.UID
+		if key == "" {
+			key = eventGroupKey(event) + "/" + event.FirstTimestamp.String()
+		}
+		l.events[key] = event
+	}
+
+	if len(l.events) > l.capacity {
+		l.evictOldest(len(l.events) - l.capacity)
+	}
+}
+
+// Len returns the number of events held
+func (l *EventList) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.events)
+}
+
+// Clear removes all events
+func (l *EventList) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = make(map[string]Event)
+}
+
+// Grouped returns the events matching filter with repeats collapsed, newest
+// first. See DedupEvents.
+func (l *EventList) Grouped(filter *EventFilter) []Event {
+	l.mu.RLock()
+	matching := make([]Event, 0, len(l.events))
+	for _, event := range l.events {
+		if filter.Matches(event) {
+			matching = append(matching, event)
+		}
+	}
+	l.mu.RUnlock()
+
+	return DedupEvents(matching)
+}
+
+// evictOldest drops the n least recently seen events
+func (l *EventList) evictOldest(n int) {
+	keys := make([]string, 0, len(l.events))
+	for key := range l.events {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return l.events[keys[i]].LastTimestamp.Before(l.events[keys[j]].LastTimestamp)
+	})
+
+	for _, key := range keys[:n] {
+		delete(l.events, key)
+	}
+}
+
+// DedupEvents collapses events that repeat the same type, reason and message
+// for the same object. Counts are summed and the time span widened; the
+// result is sorted newest first.
+func DedupEvents(events []Event) []Event {
+	groups := make(map[string]*Event)
+	var order []string
+
+	for _, event := range events {
+		if event.Count < 1 {
+			event.Count = 1
+		}
+
+		key := eventGroupKey(event)
+		group, ok := groups[key]
+		if !ok {
+			copied := event
+			groups[key] = &copied
+			order = append(order, key)
+			continue
+		}
+
+		group.Count += event.Count
+		if !event.FirstTimestamp.IsZero() && (group.FirstTimestamp.IsZero() || event.FirstTimestamp.Before(group.FirstTimestamp)) {
+			group.FirstTimestamp = event.FirstTimestamp
+		}
+		if event.LastTimestamp.After(group.LastTimestamp) {
+			group.LastTimestamp = event.LastTimestamp
+			group.Source = event.Source
+		}
+	}
+
+	result := make([]Event, 0, len(order))
+	for _, key := range order {
+		result = append(result, *groups[key])
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].LastTimestamp.After(result[j].LastTimestamp)
+	})
+	return result
+}
+
+// eventGroupKey identifies events that are repeats of each other
+func eventGroupKey(event Event) string {
+	return strings.Join([]string{
+		event.Namespace, event.Object.Kind, event.Object.Name,
+		event.Type, event.Reason, event.Message,
+	}, "\x00")
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// IssueSeverity ranks how urgently a problem needs attention
+type IssueSeverity string
+
+const (
+	IssueSeverityCritical IssueSeverity = "Critical"
+	IssueSeverityWarning  IssueSeverity = "Warning"
+	IssueSeverityInfo     IssueSeverity = "Info"
+)
+
+// Rank orders severities, most severe first
+func (s IssueSeverity) Rank() int {
+	switch s {
+	case IssueSeverityCritical:
+		return 0
+	case IssueSeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Icon returns the icon shown for the severity
+func (s IssueSeverity) Icon() string {
+	switch s {
+	case IssueSeverityCritical:
+		return "🔴"
+	case IssueSeverityWarning:
+		return "🟡"
+	default:
+		return "🔵"
+	}
+}
+
+// Issue is a problem found in the cluster, such as a crash-looping
+// container or a NotReady node
+type Issue struct {
+	Severity  IssueSeverity `json:"severity" yaml:"severity"`
+	Reason    string        `json:"reason" yaml:"reason"` // e.g. CrashLoopBackOff
+	Object    EventObject   `json:"object" yaml:"object"`
+	Container string        `json:"container,omitempty" yaml:"container,omitempty"`
+	Message   string        `json:"message" yaml:"message"`
+	Since     time.Time     `json:"since,omitempty" yaml:"since,omitempty"`
+}
+
+// IssueCounts counts issues by severity
+type IssueCounts struct {
+	Critical int
+	Warning  int
+	Info     int
+}
+
+// Total returns the number of issues counted
+func (c IssueCounts) Total() int {
+	return c.Critical + c.Warning + c.Info
+}
+
+// CountIssues counts issues by severity
+func CountIssues(issues []Issue) IssueCounts {
+	var counts IssueCounts
+	for _, issue := range issues {
+		switch issue.Severity {
+		case IssueSeverityCritical:
+			counts.Critical++
+		case IssueSeverityWarning:
+			counts.Warning++
+		default:
+			counts.Info++
+		}
+	}
+	return counts
+}
+
+// SortIssues orders issues by severity, then by namespace, object and
+// reason so the list stays put between refreshes
+func SortIssues(issues []Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Severity.Rank() != b.Severity.Rank() {
+			return a.Severity.Rank() < b.Severity.Rank()
+		}
+		if a.Object.Namespace != b.Object.Namespace {
+			return a.Object.Namespace < b.Object.Namespace
+		}
+		if a.Object.Kind != b.Object.Kind {
+			return a.Object.Kind < b.Object.Kind
+		}
+		if a.Object.Name != b.Object.Name {
+			return a.Object.Name < b.Object.Name
+		}
+		return a.Reason < b.Reason
+	})
+}
@@ -0,0 +1,105 @@
+package models
+
+import "sync"
+
+// LogBuffer is a bounded ring buffer of log entries. Once full, appending
+// evicts the oldest entries so memory stays constant while following.
+type LogBuffer struct {
+	entries  []*LogEntry
+	start    int
+	count    int
+	capacity int
+	evicted  int64
+	mu       sync.RWMutex
+}
+
+// NewLogBuffer creates a log buffer holding at most capacity entries
+func NewLogBuffer(capacity int) *LogBuffer {
+	if capacity <= 0 {
+		capacity = 1
+	}
+
+	return &LogBuffer{
+		entries:  make([]*LogEntry, capacity),
+		capacity: capacity,
+	}
+}
+
+// Append adds entries to the buffer and returns how many old entries were
+// evicted to make room
+func (lb *LogBuffer) Append(entries ...*LogEntry) int {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	evicted := 0
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		end := (lb.start + lb.count) % lb.capacity
+		lb.entries[end] = entry
+
+		if lb.count < lb.capacity {
+			lb.count++
+		} else {
+			lb.start = (lb.start + 1) % lb.capacity
+			evicted++
+		}
+	}
+	lb.evicted += int64(evicted)
+
+	return evicted
+}
+
+// Entries returns the buffered entries, oldest first
+func (lb *LogBuffer) Entries() []*LogEntry {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+
+	result := make([]*LogEntry, lb.count)
+	for i := 0; i < lb.count; i++ {
+		result[i] = lb.entries[(lb.start+i)%lb.capacity]
+	}
+	return result
+}
+
+// Last returns the most recent entry, or nil when empty
+func (lb *LogBuffer) Last() *LogEntry {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+
+	if lb.count == 0 {
+		return nil
+	}
+	return lb.entries[(lb.start+lb.count-1)%lb.capacity]
+}
+
+// Len returns the number of buffered entries
+func (lb *LogBuffer) Len() int {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+	return lb.count
+}
+
+// Capacity returns the maximum number of entries the buffer holds
+func (lb *LogBuffer) Capacity() int {
+	return lb.capacity
+}
+
+// Evicted returns the total number of entries dropped since creation
+func (lb *LogBuffer) Evicted() int64 {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+	return lb.evicted
+}
+
+// Clear empties the buffer
+func (lb *LogBuffer) Clear() {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	lb.entries = make([]*LogEntry, lb.capacity)
+	lb.start = 0
+	lb.count = 0
+}
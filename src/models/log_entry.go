@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"hash/fnv"
 	"strings"
 	"time"
 )
@@ -33,6 +34,26 @@ type LogSource struct {
 	Namespace     string `json:"namespace" yaml:"namespace"`
 }
 
+// sourceColors are the 256-color codes used to tell log sources apart
+var sourceColors = []string{"39", "208", "141", "42", "214", "81", "205", "118", "171", "45", "220", "99"}
+
+// Prefix returns the "[pod/container]" label used when logs from several
+// sources are shown together
+func (ls LogSource) Prefix() string {
+	if ls.ContainerName != "" {
+		return fmt.Sprintf("[%s/%s]", ls.PodName, ls.ContainerName)
+	}
+	return fmt.Sprintf("[%s]", ls.PodName)
+}
+
+// Color returns a terminal color code that stays the same for a source, so
+// each stream keeps its color as lines arrive
+func (ls LogSource) Color() string {
+	h := fnv.New32a()
+	h.Write([]byte(ls.Namespace + "/" + ls.PodName + "/" + ls.ContainerName))
+	return sourceColors[h.Sum32()%uint32(len(sourceColors))]
+}
+
 // LogEntry represents an individual log line from pods/containers
 type LogEntry struct {
 	Timestamp  time.Time         `json:"timestamp" yaml:"timestamp"`
@@ -44,6 +65,8 @@ type LogEntry struct {
 	Parsed     map[string]string `json:"parsed,omitempty" yaml:"parsed,omitempty"`
 	Tags       []string          `json:"tags,omitempty" yaml:"tags,omitempty"`
 	LineNumber int64             `json:"lineNumber,omitempty" yaml:"lineNumber,omitempty"`
+	Format     string            `json:"format,omitempty" yaml:"format,omitempty"`   // json, logfmt, klog; empty when unstructured
+	Message    string            `json:"message,omitempty" yaml:"message,omitempty"` // message field of a structured line
 }
 
 // NewLogEntry creates a new log entry with validation
@@ -71,31 +94,57 @@ func NewLogEntry(timestamp time.Time, source LogSource, content string) (*LogEnt
 		Tags:      make([]string, 0),
 	}
 
-	// Try to parse log level from content
-	entry.Level = parseLogLevel(content)
+	// Structured lines carry their own level and fields
+	if parsed, ok := ParseLogLine(content); ok {
+		entry.ApplyParsed(parsed)
+	} else {
+		entry.Level = parseLogLevel(content)
+	}
 
 	return entry, nil
 }
 
-// parseLogLevel attempts to extract log level from log content
-func parseLogLevel(content string) LogLevel {
-	contentUpper := strings.ToUpper(content)
-
-	// Common log level patterns
-	if strings.Contains(contentUpper, "ERROR") || strings.Contains(contentUpper, "ERR") {
-		return LogLevelError
+// ApplyParsed stores the structure a parser extracted from the line
+func (le *LogEntry) ApplyParsed(parsed *ParsedLog) {
+	le.Format = parsed.Format
+	le.Message = parsed.Message
+	if !parsed.Timestamp.IsZero() {
+		le.Timestamp = parsed.Timestamp
 	}
-	if strings.Contains(contentUpper, "WARN") || strings.Contains(contentUpper, "WARNING") {
-		return LogLevelWarning
+	for key, value := range parsed.Fields {
+		le.SetParsedField(key, value)
 	}
-	if strings.Contains(contentUpper, "INFO") || strings.Contains(contentUpper, "INFORMATION") {
-		return LogLevelInfo
-	}
-	if strings.Contains(contentUpper, "DEBUG") || strings.Contains(contentUpper, "DBG") {
-		return LogLevelDebug
+
+	le.Level = parsed.Level
+	if le.Level == LogLevelUnknown {
+		le.Level = parseLogLevel(parsed.Message)
 	}
-	if strings.Contains(contentUpper, "FATAL") || strings.Contains(contentUpper, "CRITICAL") {
-		return LogLevelFatal
+}
+
+// parseLogLevel attempts to extract log level from unstructured log content.
+// Only the first few words are considered, so a message that merely
+// mentions "error" is not classified as one.
+func parseLogLevel(content string) LogLevel {
+	words := strings.FieldsFunc(content, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+
+	for i, word := range words {
+		if i >= 3 {
+			break
+		}
+		switch strings.ToUpper(word) {
+		case "ERROR", "ERR":
+			return LogLevelError
+		case "WARN", "WARNING":
+			return LogLevelWarning
+		case "INFO", "INFORMATION":
+			return LogLevelInfo
+		case "DEBUG", "DBG", "TRACE":
+			return LogLevelDebug
+		case "FATAL", "CRITICAL", "PANIC":
+			return LogLevelFatal
+		}
 	}
 
 	// If no level found, return unknown
@@ -365,6 +414,8 @@ func (le *LogEntry) Clone() *LogEntry {
 		Stream:     le.Stream,
 		Raw:        le.Raw,
 		LineNumber: le.LineNumber,
+		Format:     le.Format,
+		Message:    le.Message,
 	}
 
 	// Deep copy parsed fields
@@ -0,0 +1,253 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// logFilterOperators are the comparison operators, longest first so ">="
+// is not read as ">"
+var logFilterOperators = []string{">=", "<=", "!=", "=~", "=", ">", "<", "~"}
+
+// LogFieldCondition compares one field of a log entry with a value
+type LogFieldCondition struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+// LogFilter selects log entries by field conditions and free text, e.g.
+// `level>=warn user_id=42 timeout`. All terms must match.
+type LogFilter struct {
+	Conditions []LogFieldCondition
+	Text       []string
+}
+
+// ParseLogFilter parses a filter query. Terms are separated by spaces and
+// are either field<op>value, with op one of = != > >= < <= ~ (contains), or
+// plain text that must appear in the line. Values and phrases may be
+// double-quoted.
+func ParseLogFilter(query string) (*LogFilter, error) {
+	filter := &LogFilter{}
+
+	for _, term := range splitFilterTerms(query) {
+		// A quoted term is a phrase, even when it contains an operator
+		condition, ok := parseFilterCondition(term)
+		if !ok || strings.HasPrefix(term, `"`) {
+			filter.Text = append(filter.Text, unquoteFilterText(term))
+			continue
+		}
+		if condition.Field == "level" && condition.Value != "" {
+			level, ok := parseFilterLevel(condition.Value)
+			if !ok {
+				return nil, fmt.Errorf("unknown log level %q", condition.Value)
+			}
+			condition.Value = string(level)
+		}
+		filter.Conditions = append(filter.Conditions, condition)
+	}
+
+	return filter, nil
+}
+
+// IsEmpty reports whether the filter matches everything
+func (f *LogFilter) IsEmpty() bool {
+	return f == nil || (len(f.Conditions) == 0 && len(f.Text) == 0)
+}
+
+// Matches reports whether a log entry satisfies every term of the filter
+func (f *LogFilter) Matches(le *LogEntry) bool {
+	if f == nil {
+		return true
+	}
+
+	for _, text := range f.Text {
+		if !le.ContainsText(text) {
+			return false
+		}
+	}
+
+	for _, condition := range f.Conditions {
+		if !condition.matches(le) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matches evaluates the condition against an entry. A condition on a field
+// the entry doesn't have only matches with !=.
+func (c LogFieldCondition) matches(le *LogEntry) bool {
+	// A term still being typed, like "level>=", doesn't filter yet
+	if c.Value == "" && c.Operator != "=" && c.Operator != "!=" {
+		return true
+	}
+
+	if c.Field == "level" {
+		return compareOrdered(logLevelSeverity(le.Level), logLevelSeverity(NormalizeLogLevel(c.Value)), c.Operator)
+	}
+
+	value, ok := le.fieldValue(c.Field)
+	if !ok {
+		return c.Operator == "!="
+	}
+
+	switch c.Operator {
+	case "~", "=~":
+		return strings.Contains(strings.ToLower(value), strings.ToLower(c.Value))
+	case "=":
+		return value == c.Value
+	case "!=":
+		return value != c.Value
+	}
+
+	// Ordered comparisons are numeric when both sides are numbers
+	left, leftErr := strconv.ParseFloat(value, 64)
+	right, rightErr := strconv.ParseFloat(c.Value, 64)
+	if leftErr == nil && rightErr == nil {
+		return compareOrdered(left, right, c.Operator)
+	}
+	return compareOrdered(strings.Compare(value, c.Value), 0, c.Operator)
+}
+
+// fieldValue returns a parsed field, or one of the built-in fields
+// msg, pod, container and namespace
+func (le *LogEntry) fieldValue(field string) (string, bool) {
+	if value, ok := le.GetParsedField(field); ok {
+		return value, true
+	}
+
+	switch field {
+	case "msg", "message":
+		if le.Message != "" {
+			return le.Message, true
+		}
+		return le.Content, true
+	case "pod":
+		return le.Source.PodName, true
+	case "container":
+		return le.Source.ContainerName, true
+	case "namespace":
+		return le.Source.Namespace, true
+	}
+	return "", false
+}
+
+// parseFilterLevel reads a level in a filter, accepting any prefix of a
+// level name so "level>=wa" works while typing
+func parseFilterLevel(value string) (LogLevel, bool) {
+	if level := NormalizeLogLevel(value); level != LogLevelUnknown {
+		return level, true
+	}
+
+	lower := strings.ToLower(value)
+	for _, name := range []string{"debug", "info", "warning", "error", "fatal"} {
+		if strings.HasPrefix(name, lower) {
+			return NormalizeLogLevel(name), true
+		}
+	}
+	return LogLevelUnknown, false
+}
+
+// logLevelSeverity orders log levels from least to most severe
+func logLevelSeverity(level LogLevel) int {
+	switch level {
+	case LogLevelDebug:
+		return 1
+	case LogLevelInfo:
+		return 2
+	case LogLevelWarning:
+		return 3
+	case LogLevelError:
+		return 4
+	case LogLevelFatal:
+		return 5
+	default:
+		return 0
+	}
+}
+
+// compareOrdered applies an operator to two ordered values
+func compareOrdered[T int | float64](left, right T, operator string) bool {
+	switch operator {
+	case "=", "~", "=~":
+		return left == right
+	case "!=":
+		return left != right
+	case ">":
+		return left > right
+	case ">=":
+		return left >= right
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	}
+	return false
+}
+
+// parseFilterCondition splits a field<op>value term
+func parseFilterCondition(term string) (LogFieldCondition, bool) {
+	for i := 0; i < len(term); i++ {
+		for _, operator := range logFilterOperators {
+			if !strings.HasPrefix(term[i:], operator) {
+				continue
+			}
+			if i == 0 {
+				return LogFieldCondition{}, false
+			}
+
+			value := term[i+len(operator):]
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				value = unquoted
+			}
+			return LogFieldCondition{
+				Field:    term[:i],
+				Operator: operator,
+				Value:    value,
+			}, true
+		}
+	}
+	return LogFieldCondition{}, false
+}
+
+// unquoteFilterText strips the quotes of a phrase. A phrase still being
+// typed has no closing quote yet.
+func unquoteFilterText(term string) string {
+	if !strings.HasPrefix(term, `"`) {
+		return term
+	}
+	if unquoted, err := strconv.Unquote(term); err == nil {
+		return unquoted
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(term, `"`), `"`)
+}
+
+// splitFilterTerms splits a query on spaces, keeping double-quoted values together
+func splitFilterTerms(query string) []string {
+	var terms []string
+	var current strings.Builder
+	inQuotes := false
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case r == ' ' && !inQuotes:
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+
+	return terms
+}
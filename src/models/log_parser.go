@@ -0,0 +1,314 @@
+package models
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Log formats detected by the built-in parsers
+const (
+	LogFormatJSON   = "json"
+	LogFormatLogfmt = "logfmt"
+	LogFormatKlog   = "klog"
+)
+
+// ParsedLog is the structure a parser extracted from a log line
+type ParsedLog struct {
+	Format    string
+	Level     LogLevel
+	Timestamp time.Time
+	Message   string
+	Fields    map[string]string
+}
+
+// LogParser recognizes one log line format
+type LogParser interface {
+	// Name returns the format name, e.g. "json"
+	Name() string
+	// Parse returns the parsed line, or false if the line is not in this format
+	Parse(line string) (*ParsedLog, bool)
+}
+
+var (
+	logParsers   = []LogParser{JSONLogParser{}, KlogParser{}, LogfmtParser{}}
+	logParsersMu sync.RWMutex
+)
+
+// RegisterLogParser adds a parser that is tried before the built-in ones
+func RegisterLogParser(parser LogParser) {
+	logParsersMu.Lock()
+	defer logParsersMu.Unlock()
+	logParsers = append([]LogParser{parser}, logParsers...)
+}
+
+// ParseLogLine runs the registered parsers over a line and returns the first match
+func ParseLogLine(line string) (*ParsedLog, bool) {
+	logParsersMu.RLock()
+	defer logParsersMu.RUnlock()
+
+	for _, parser := range logParsers {
+		if parsed, ok := parser.Parse(line); ok {
+			return parsed, true
+		}
+	}
+	return nil, false
+}
+
+// Well-known field names for the level, message and time
+var (
+	levelKeys   = []string{"level", "lvl", "severity", "log.level", "loglevel"}
+	messageKeys = []string{"msg", "message", "log"}
+	timeKeys    = []string{"time", "ts", "timestamp", "@timestamp", "t"}
+)
+
+// newParsedLog fills level, message and time from well-known fields
+func newParsedLog(format string, fields map[string]string) *ParsedLog {
+	parsed := &ParsedLog{
+		Format: format,
+		Level:  LogLevelUnknown,
+		Fields: fields,
+	}
+
+	for _, key := range levelKeys {
+		if value, ok := fields[key]; ok {
+			parsed.Level = NormalizeLogLevel(value)
+			break
+		}
+	}
+	for _, key := range messageKeys {
+		if value, ok := fields[key]; ok {
+			parsed.Message = value
+			break
+		}
+	}
+	for _, key := range timeKeys {
+		if value, ok := fields[key]; ok {
+			parsed.Timestamp = parseLogTime(value)
+			break
+		}
+	}
+
+	return parsed
+}
+
+// JSONLogParser parses one JSON object per line, as written by zap, logrus,
+// slog and most structured loggers
+type JSONLogParser struct{}
+
+// Name returns the format name
+func (JSONLogParser) Name() string { return LogFormatJSON }
+
+// Parse parses a JSON log line. Nested values are kept as compact JSON.
+func (JSONLogParser) Parse(line string) (*ParsedLog, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "{") || !strings.HasSuffix(trimmed, "}") {
+		return nil, false
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(trimmed), &object); err != nil {
+		return nil, false
+	}
+
+	fields := make(map[string]string, len(object))
+	for key, value := range object {
+		switch v := value.(type) {
+		case string:
+			fields[key] = v
+		case nil:
+			fields[key] = ""
+		case float64:
+			fields[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			fields[key] = strconv.FormatBool(v)
+		default:
+			encoded, _ := json.Marshal(v)
+			fields[key] = string(encoded)
+		}
+	}
+
+	return newParsedLog(LogFormatJSON, fields), true
+}
+
+// LogfmtParser parses key=value lines as written by logfmt and Go's slog
+// text handler
+type LogfmtParser struct{}
+
+// Name returns the format name
+func (LogfmtParser) Name() string { return LogFormatLogfmt }
+
+// Parse parses a logfmt line. The line must start with a key=value pair
+// and contain at least two of them.
+func (LogfmtParser) Parse(line string) (*ParsedLog, bool) {
+	fields, rest := parseLogfmtPairs(line)
+	if len(fields) < 2 || strings.TrimSpace(rest) != "" {
+		return nil, false
+	}
+	return newParsedLog(LogFormatLogfmt, fields), true
+}
+
+// parseLogfmtPairs reads key=value pairs, with optionally quoted values,
+// until it meets something that is not a pair. It returns the pairs and
+// the unparsed remainder.
+func parseLogfmtPairs(line string) (map[string]string, string) {
+	fields := make(map[string]string)
+	rest := strings.TrimLeft(line, " ")
+
+	for rest != "" {
+		eq := strings.IndexByte(rest, '=')
+		space := strings.IndexByte(rest, ' ')
+		if eq <= 0 || (space != -1 && space < eq) {
+			break
+		}
+		key := rest[:eq]
+		value := rest[eq+1:]
+
+		if strings.HasPrefix(value, `"`) {
+			quoted, err := strconv.QuotedPrefix(value)
+			if err != nil {
+				break
+			}
+			unquoted, _ := strconv.Unquote(quoted)
+			fields[key] = unquoted
+			value = value[len(quoted):]
+		} else {
+			end := strings.IndexByte(value, ' ')
+			if end == -1 {
+				end = len(value)
+			}
+			fields[key] = value[:end]
+			value = value[end:]
+		}
+
+		rest = strings.TrimLeft(value, " ")
+	}
+
+	return fields, rest
+}
+
+// klogPattern matches the klog/glog header: Lmmdd hh:mm:ss.uuuuuu threadid file:line] msg
+var klogPattern = regexp.MustCompile(`^([IWEF])(\d{4}) (\d{2}:\d{2}:\d{2}\.\d{6})\s+(\d+) ([^ \]]+:\d+)\] ?(.*)$`)
+
+// KlogParser parses the klog/glog format used by Kubernetes components
+type KlogParser struct{}
+
+// Name returns the format name
+func (KlogParser) Name() string { return LogFormatKlog }
+
+// Parse parses a klog line, including structured key=value pairs after a
+// quoted message
+func (KlogParser) Parse(line string) (*ParsedLog, bool) {
+	match := klogPattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil, false
+	}
+
+	fields := map[string]string{
+		"thread": match[4],
+		"source": match[5],
+	}
+
+	message := match[6]
+	if strings.HasPrefix(message, `"`) {
+		if quoted, err := strconv.QuotedPrefix(message); err == nil {
+			pairs, _ := parseLogfmtPairs(message[len(quoted):])
+			for key, value := range pairs {
+				fields[key] = value
+			}
+			message, _ = strconv.Unquote(quoted)
+		}
+	}
+
+	parsed := &ParsedLog{
+		Format:  LogFormatKlog,
+		Level:   NormalizeLogLevel(match[1]),
+		Message: message,
+		Fields:  fields,
+	}
+
+	// klog omits the year
+	if timestamp, err := time.ParseInLocation("0102 15:04:05.000000", match[2]+" "+match[3], time.Local); err == nil {
+		parsed.Timestamp = timestamp.AddDate(time.Now().Year(), 0, 0)
+	}
+
+	return parsed, true
+}
+
+// NormalizeLogLevel maps the many spellings of a log level, including klog
+// letters and bunyan/pino numbers, to a LogLevel
+func NormalizeLogLevel(value string) LogLevel {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "trace", "debug", "dbg", "d", "10", "20":
+		return LogLevelDebug
+	case "info", "information", "notice", "i", "30":
+		return LogLevelInfo
+	case "warn", "warning", "w", "40":
+		return LogLevelWarning
+	case "error", "err", "e", "50":
+		return LogLevelError
+	case "fatal", "critical", "crit", "panic", "dpanic", "emergency", "alert", "f", "60":
+		return LogLevelFatal
+	default:
+		return LogLevelUnknown
+	}
+}
+
+// parseLogTime parses an RFC3339 time or a Unix time in seconds or milliseconds
+func parseLogTime(value string) time.Time {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.000Z0700", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds > 1e12 { // milliseconds
+			seconds /= 1000
+		}
+		whole := int64(seconds)
+		return time.Unix(whole, int64((seconds-float64(whole))*1e9))
+	}
+
+	return time.Time{}
+}
+
+// PrettyLog formats a parsed entry as "message key=value ...", with the
+// fields sorted and the level, message and time fields left out
+func (le *LogEntry) PrettyLog() string {
+	if le.Format == "" {
+		return le.Content
+	}
+
+	var b strings.Builder
+	b.WriteString(le.Message)
+
+	skip := make(map[string]bool)
+	for _, keys := range [][]string{levelKeys, messageKeys, timeKeys} {
+		for _, key := range keys {
+			skip[key] = true
+		}
+	}
+
+	keys := make([]string, 0, len(le.Parsed))
+	for key := range le.Parsed {
+		if !skip[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := le.Parsed[key]
+		if value == "" || strings.ContainsAny(value, " \t\"=") {
+			value = strconv.Quote(value)
+		}
+		b.WriteString(" " + key + "=" + value)
+	}
+
+	return b.String()
+}
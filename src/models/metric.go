@@ -14,8 +14,41 @@ const (
 	MetricTypeNetwork MetricType = "network"
 	MetricTypeStorage MetricType = "storage"
 	MetricTypeCustom  MetricType = "custom"
+
+	// Collected figures for a resource: what it uses, what it has and what
+	// its pods request and are limited to
+	MetricTypeCPUUsage          MetricType = "cpu_usage"
+	MetricTypeCPUCapacity       MetricType = "cpu_capacity"
+	MetricTypeCPUAllocatable    MetricType = "cpu_allocatable"
+	MetricTypeCPURequest        MetricType = "cpu_request"
+	MetricTypeCPULimit          MetricType = "cpu_limit"
+	MetricTypeMemoryUsage       MetricType = "memory_usage"
+	MetricTypeMemoryCapacity    MetricType = "memory_capacity"
+	MetricTypeMemoryAllocatable MetricType = "memory_allocatable"
+	MetricTypeMemoryRequest     MetricType = "memory_request"
+	MetricTypeMemoryLimit       MetricType = "memory_limit"
+	MetricTypeNetworkRx         MetricType = "network_rx"
+	MetricTypeNetworkTx         MetricType = "network_tx"
+	MetricTypeStorageUsage      MetricType = "storage_usage"
 )
 
+// Base returns the kind of quantity a metric type measures: cpu, memory,
+// network, storage or custom
+func (t MetricType) Base() MetricType {
+	switch t {
+	case MetricTypeCPUUsage, MetricTypeCPUCapacity, MetricTypeCPUAllocatable, MetricTypeCPURequest, MetricTypeCPULimit:
+		return MetricTypeCPU
+	case MetricTypeMemoryUsage, MetricTypeMemoryCapacity, MetricTypeMemoryAllocatable, MetricTypeMemoryRequest, MetricTypeMemoryLimit:
+		return MetricTypeMemory
+	case MetricTypeNetworkRx, MetricTypeNetworkTx:
+		return MetricTypeNetwork
+	case MetricTypeStorageUsage:
+		return MetricTypeStorage
+	default:
+		return t
+	}
+}
+
 // MetricDataPoint represents a performance measurement with timestamp and metadata
 type MetricDataPoint struct {
 	Timestamp  time.Time         `json:"timestamp" yaml:"timestamp"`
@@ -71,8 +104,9 @@ func isValidMetricType(metricType MetricType) bool {
 		MetricTypeCustom,
 	}
 
+	base := metricType.Base()
 	for _, validType := range validTypes {
-		if metricType == validType {
+		if base == validType {
 			return true
 		}
 	}
@@ -134,7 +168,7 @@ func (mdp *MetricDataPoint) SetInterval(interval time.Duration) {
 
 // GetDisplayValue returns a formatted value for display based on the metric type and unit
 func (mdp *MetricDataPoint) GetDisplayValue() string {
-	switch mdp.MetricType {
+	switch mdp.MetricType.Base() {
 	case MetricTypeCPU:
 		if mdp.Unit == "percent" || mdp.Unit == "%" {
 			return fmt.Sprintf("%.1f%%", mdp.Value)
@@ -257,7 +291,7 @@ func (mdp *MetricDataPoint) IsFromPod() bool {
 
 // GetMetricIcon returns an icon/emoji representing the metric type
 func (mdp *MetricDataPoint) GetMetricIcon() string {
-	switch mdp.MetricType {
+	switch mdp.MetricType.Base() {
 	case MetricTypeCPU:
 		return "🏭"
 	case MetricTypeMemory:
@@ -45,15 +45,75 @@ type OwnerReference struct {
 
 // Event represents a Kubernetes event related to a resource
 type Event struct {
+	UID                string      `json:"uid,omitempty" yaml:"uid,omitempty"`
+	Namespace          string      `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Object             EventObject `json:"object,omitempty" yaml:"object,omitempty"`
+	Type               string      `json:"type" yaml:"type"`
+	Reason             string      `json:"reason" yaml:"reason"`
+	Message            string      `json:"message" yaml:"message"`
+	Source             string      `json:"source" yaml:"source"`
+	FirstTimestamp     time.Time   `json:"firstTimestamp" yaml:"firstTimestamp"`
+	LastTimestamp      time.Time   `json:"lastTimestamp" yaml:"lastTimestamp"`
+	Count              int32       `json:"count" yaml:"count"`
+	ReportingComponent string      `json:"reportingComponent,omitempty" yaml:"reportingComponent,omitempty"`
+	ReportingInstance  string      `json:"reportingInstance,omitempty" yaml:"reportingInstance,omitempty"`
+}
+
+// EventObject identifies the object an event is about
+type EventObject struct {
+	Kind      string `json:"kind" yaml:"kind"`
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	UID       string `json:"uid,omitempty" yaml:"uid,omitempty"`
+}
+
+// String returns the object as kind/name
+func (o EventObject) String() string {
+	return strings.ToLower(o.Kind) + "/" + o.Name
+}
+
+// ContainerState is the state of one container of a pod
+type ContainerState struct {
+	Name         string    `json:"name" yaml:"name"`
+	Init         bool      `json:"init,omitempty" yaml:"init,omitempty"`
+	Ready        bool      `json:"ready" yaml:"ready"`
+	RestartCount int32     `json:"restartCount" yaml:"restartCount"`
+	State        string    `json:"state" yaml:"state"` // waiting, running or terminated
+	Reason       string    `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Message      string    `json:"message,omitempty" yaml:"message,omitempty"`
+	ExitCode     int32     `json:"exitCode,omitempty" yaml:"exitCode,omitempty"`
+	StartedAt    time.Time `json:"startedAt,omitempty" yaml:"startedAt,omitempty"`
+
+	// How the previous run ended, e.g. OOMKilled
+	LastReason     string    `json:"lastReason,omitempty" yaml:"lastReason,omitempty"`
+	LastExitCode   int32     `json:"lastExitCode,omitempty" yaml:"lastExitCode,omitempty"`
+	LastFinishedAt time.Time `json:"lastFinishedAt,omitempty" yaml:"lastFinishedAt,omitempty"`
+}
+
+// containerFailureReasons are waiting reasons that mean a container can't
+// run until something changes
+var containerFailureReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// IsFailing reports whether the container is stuck waiting on an error
+func (c ContainerState) IsFailing() bool {
+	return c.State == "waiting" && containerFailureReasons[c.Reason]
+}
+
+// Condition is a status condition of a pod, node or workload
+type Condition struct {
 	Type               string    `json:"type" yaml:"type"`
-	Reason             string    `json:"reason" yaml:"reason"`
-	Message            string    `json:"message" yaml:"message"`
-	Source             string    `json:"source" yaml:"source"`
-	FirstTimestamp     time.Time `json:"firstTimestamp" yaml:"firstTimestamp"`
-	LastTimestamp      time.Time `json:"lastTimestamp" yaml:"lastTimestamp"`
-	Count              int32     `json:"count" yaml:"count"`
-	ReportingComponent string    `json:"reportingComponent,omitempty" yaml:"reportingComponent,omitempty"`
-	ReportingInstance  string    `json:"reportingInstance,omitempty" yaml:"reportingInstance,omitempty"`
+	Status             string    `json:"status" yaml:"status"`
+	Reason             string    `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Message            string    `json:"message,omitempty" yaml:"message,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime,omitempty" yaml:"lastTransitionTime,omitempty"`
 }
 
 // Resource represents a generic Kubernetes resource
@@ -70,6 +130,10 @@ type Resource struct {
 	Ready       string         `json:"ready,omitempty" yaml:"ready,omitempty"`
 	StatusPhase ResourceStatus `json:"statusPhase,omitempty" yaml:"statusPhase,omitempty"`
 	Restarts    int32          `json:"restarts,omitempty" yaml:"restarts,omitempty"`
+
+	// Typed status of pods, nodes and workloads, when known
+	Containers []ContainerState `json:"containers,omitempty" yaml:"containers,omitempty"`
+	Conditions []Condition      `json:"conditions,omitempty" yaml:"conditions,omitempty"`
 }
 
 // NewResource creates a new resource instance with validation
@@ -220,6 +284,16 @@ func (r *Resource) GetController() *OwnerReference {
 	return nil
 }
 
+// GetCondition returns the condition of the given type, or nil
+func (r *Resource) GetCondition(conditionType string) *Condition {
+	for i := range r.Conditions {
+		if r.Conditions[i].Type == conditionType {
+			return &r.Conditions[i]
+		}
+	}
+	return nil
+}
+
 // AddEvent adds an event to the resource
 func (r *Resource) AddEvent(event Event) {
 	r.Events = append(r.Events, event)
@@ -274,8 +348,16 @@ func (r *Resource) ComputeStatus() ResourceStatus {
 	}
 }
 
-// computePodStatus calculates status for Pod resources
+// computePodStatus calculates status for Pod resources. A pod whose
+// containers are stuck, e.g. in CrashLoopBackOff, is failed whatever its
+// phase says.
 func (r *Resource) computePodStatus() ResourceStatus {
+	for _, container := range r.Containers {
+		if container.IsFailing() {
+			return ResourceStatusFailed
+		}
+	}
+
 	if phase, ok := r.Status["phase"].(string); ok {
 		switch phase {
 		case "Running":
@@ -352,6 +434,43 @@ func (r *Resource) computeJobStatus() ResourceStatus {
 
 // computeGenericStatus calculates status for generic resources
 func (r *Resource) computeGenericStatus() ResourceStatus {
+	// Most operators and CRDs report a phase and/or standard conditions
+	if phase, ok := r.Status["phase"].(string); ok {
+		switch strings.ToLower(phase) {
+		case "running", "active", "bound", "available", "ready", "healthy", "established", "deployed":
+			return ResourceStatusRunning
+		case "succeeded", "complete", "completed", "done":
+			return ResourceStatusSucceeded
+		case "pending", "progressing", "provisioning", "creating", "initializing", "waiting":
+			return ResourceStatusPending
+		case "failed", "error", "degraded", "unhealthy", "lost", "notready":
+			return ResourceStatusFailed
+		}
+	}
+
+	if conditions, ok := r.Status["conditions"].([]interface{}); ok {
+		for _, cond := range conditions {
+			condMap, ok := cond.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			condType, _ := condMap["type"].(string)
+			if condType != "Ready" && condType != "Available" {
+				continue
+			}
+			switch condMap["status"] {
+			case "True":
+				return ResourceStatusRunning
+			case "False":
+				reason, _ := condMap["reason"].(string)
+				if strings.Contains(reason, "Fail") || strings.Contains(reason, "Error") {
+					return ResourceStatusFailed
+				}
+				return ResourceStatusPending
+			}
+		}
+	}
+
 	// For generic resources, check if there are any error events
 	for _, event := range r.GetRecentEvents(10 * time.Minute) {
 		if event.Type == "Warning" || event.Type == "Error" {
@@ -451,6 +570,13 @@ func (r *Resource) Clone() *Resource {
 		copy(clone.Events, r.Events)
 	}
 
+	if r.Containers != nil {
+		clone.Containers = append([]ContainerState(nil), r.Containers...)
+	}
+	if r.Conditions != nil {
+		clone.Conditions = append([]Condition(nil), r.Conditions...)
+	}
+
 	return clone
 }
 